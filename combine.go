@@ -0,0 +1,187 @@
+package docreader
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// MergeSeparator MergeDocuments 拼接各文档内容时使用的分隔符
+const MergeSeparator = "\n\n"
+
+// ReadCombined 按顺序使用同一配置读取多个文档，将所有页面合并为一个结果
+// 每个页面保留其在源文件中的 PageNumber 和 PageName；所有文件的格式相同时 Content 使用该格式的拼接方式，
+// 否则所有行直接以换行符连接。Warnings 以源文件路径为前缀，元数据只包含文件数量 file_count。
+// 配置了 DropRepeatedHeaders 时，合并表头相同的多个 CSV/XLSX 文件只保留第一个文件的表头。
+// 任一文件读取失败时返回该错误
+func ReadCombined(paths []string, config *ReadConfig) (*DocumentResult, error) {
+	if config == nil {
+		config = defaultReadConfig()
+	}
+
+	// 各文件的结果在合并后统一生成 Content
+	fileConfig := NewReadConfig()
+	if config != nil {
+		copied := *config
+		fileConfig = &copied
+	}
+	fileConfig.SkipContentString = true
+
+	combined := &DocumentResult{
+		Pages:    make([]PageContent, 0),
+		Metadata: map[string]string{"file_count": strconv.Itoa(len(paths))},
+	}
+
+	dropHeaders := config != nil && config.DropRepeatedHeaders
+	var header *string
+	for i, path := range paths {
+		result, err := ReadDocumentWithConfig(path, fileConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			combined.layout = result.layout
+		} else if result.layout != combined.layout {
+			combined.layout = layoutPlain
+		}
+
+		if dropHeaders && isTabularFormat(path) {
+			if header == nil {
+				// 第一个表格文件的表头全部保留
+				if len(result.Pages) > 0 && len(result.Pages[0].Lines) > 0 {
+					header = &result.Pages[0].Lines[0]
+				}
+			} else {
+				for j := range result.Pages {
+					dropHeaderLine(&result.Pages[j], *header)
+				}
+			}
+		}
+
+		for _, page := range result.Pages {
+			combined.Pages = append(combined.Pages, page)
+			combined.TotalLines += page.TotalLines
+		}
+		combined.TotalPages += result.TotalPages
+		for _, warning := range result.Warnings {
+			combined.Warnings = append(combined.Warnings, path+": "+warning)
+		}
+	}
+
+	if config == nil || !config.SkipContentString {
+		combined.Content = combined.BuildContent()
+	}
+	return combined, nil
+}
+
+// isTabularFormat 判断文件是否为表格格式（CSV/XLSX）
+func isTabularFormat(path string) bool {
+	switch normalizeExt(filepath.Ext(path)) {
+	case ".csv", ".xlsx":
+		return true
+	default:
+		return false
+	}
+}
+
+// dropHeaderLine 页面首行与 header 相同时将其移除
+func dropHeaderLine(page *PageContent, header string) {
+	if len(page.Lines) == 0 || page.Lines[0] != header {
+		return
+	}
+	page.Lines = page.Lines[1:]
+	if len(page.LineNumbers) > 0 {
+		page.LineNumbers = page.LineNumbers[1:]
+	}
+	page.TotalLines = len(page.Lines)
+}
+
+// MergeDocuments 将多个文档合并为一个 Document，nil 文档会被忽略
+// Content 为各文档内容以 MergeSeparator 连接；元数据中所有文档取值一致的键直接保留，
+// 取值冲突的键改为以来源文件名为前缀（如 "a.pdf:author"），文件名重复或为空时使用完整路径或序号（从 0 开始）。
+// 来源文件列表以换行符连接记录在元数据 sources 中，合并结果的 FilePath 为空
+func MergeDocuments(docs ...*Document) *Document {
+	sources := make([]*Document, 0, len(docs))
+	for _, doc := range docs {
+		if doc != nil {
+			sources = append(sources, doc)
+		}
+	}
+
+	contents := make([]string, len(sources))
+	paths := make([]string, len(sources))
+	for i, doc := range sources {
+		contents[i] = doc.Content
+		paths[i] = doc.FilePath
+	}
+
+	// 找出取值冲突的键
+	values := make(map[string]string)
+	conflicts := make(map[string]bool)
+	for _, doc := range sources {
+		for key, value := range doc.Metadata {
+			if existing, ok := values[key]; ok && existing != value {
+				conflicts[key] = true
+			}
+			values[key] = value
+		}
+	}
+
+	metadata := make(map[string]string)
+	names := mergeSourceNames(sources)
+	for i, doc := range sources {
+		for key, value := range doc.Metadata {
+			if conflicts[key] {
+				metadata[names[i]+":"+key] = value
+			} else {
+				metadata[key] = value
+			}
+		}
+	}
+	metadata["sources"] = strings.Join(paths, "\n")
+
+	return &Document{
+		Content:  strings.Join(contents, MergeSeparator),
+		Metadata: metadata,
+	}
+}
+
+// ReadAndMerge 按顺序读取多个文档并合并为一个 Document，任一文件读取失败时返回该错误
+func ReadAndMerge(paths ...string) (*Document, error) {
+	docs := make([]*Document, 0, len(paths))
+	for _, path := range paths {
+		doc, err := ReadDocument(path)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return MergeDocuments(docs...), nil
+}
+
+// mergeSourceNames 返回合并元数据时每个文档使用的前缀：默认为文件名，文件名重复时使用完整路径，仍然重复或为空时使用序号
+func mergeSourceNames(docs []*Document) []string {
+	baseCounts := make(map[string]int)
+	pathCounts := make(map[string]int)
+	for _, doc := range docs {
+		baseCounts[filepath.Base(doc.FilePath)]++
+		pathCounts[doc.FilePath]++
+	}
+
+	names := make([]string, len(docs))
+	for i, doc := range docs {
+		base := filepath.Base(doc.FilePath)
+		switch {
+		case doc.FilePath == "":
+			names[i] = strconv.Itoa(i)
+		case baseCounts[base] == 1:
+			names[i] = base
+		case pathCounts[doc.FilePath] == 1:
+			names[i] = doc.FilePath
+		default:
+			names[i] = strconv.Itoa(i)
+		}
+	}
+	return names
+}
@@ -3,41 +3,72 @@ package docreader
 import (
 	"encoding/csv"
 	"fmt"
-	"os"
+	"io"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// CsvReader 用于读取 .csv 文件
-type CsvReader struct{}
+// CsvReader 用于读取 .csv 和 .tsv 文件
+type CsvReader struct {
+	// Comma 字段分隔符，为 0 时使用逗号；读取 .tsv 文件时为制表符
+	Comma rune
+
+	// CellSeparator ReadText 输出时单元格之间的分隔符，为空时使用 " | "
+	// ReadWithConfig 使用 ReadConfig.CellSeparator
+	CellSeparator string
+
+	fileSource
+}
+
+// newCSVReader 根据分隔符配置创建 CSV 读取器，开头的 UTF-8 BOM 会被去掉，避免第一个表头单元格带上不可见字符
+func (r *CsvReader) newCSVReader(source io.Reader) *csv.Reader {
+	reader := csv.NewReader(newBOMSkippingReader(source))
+	if r.Comma != 0 {
+		reader.Comma = r.Comma
+	}
+	return reader
+}
 
 // ReadText 读取 CSV 文件的文本内容
 func (r *CsvReader) ReadText(filePath string) (string, error) {
+	var builder strings.Builder
+	if err := r.writeText(&builder, filePath); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// writeText 逐条读取 CSV 记录并将格式化文本写入 w
+func (r *CsvReader) writeText(w io.Writer, filePath string) error {
 	// 打开文件
-	file, err := os.Open(filePath)
+	file, err := r.open(filePath)
 	if err != nil {
-		return "", WrapError("CsvReader.ReadText", filePath, ErrFileOpen)
+		return WrapError("CsvReader.ReadText", filePath, ErrFileOpen)
 	}
 	defer file.Close()
 
 	// 创建 CSV 读取器
-	reader := csv.NewReader(file)
+	reader := r.newCSVReader(file)
+	tw := newTextWriter(w)
 
-	// 读取所有记录
-	records, err := reader.ReadAll()
-	if err != nil {
-		return "", WrapError("CsvReader.ReadText", filePath, ErrFileRead)
-	}
+	// 逐条读取并格式化输出
+	for rowIndex := 0; ; rowIndex++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return WrapError("CsvReader.ReadText", filePath, ErrFileRead)
+		}
 
-	var builder strings.Builder
-
-	// 格式化输出
-	for rowIndex, record := range records {
-		builder.WriteString(fmt.Sprintf("Row %d: ", rowIndex+1))
-		builder.WriteString(strings.Join(record, " | "))
-		builder.WriteString("\n")
+		tw.WriteString(fmt.Sprintf("Row %d: ", rowIndex+1))
+		tw.WriteString(strings.Join(record, cellSeparator(r.CellSeparator)))
+		tw.WriteString("\n")
+		if tw.err != nil {
+			return tw.err
+		}
 	}
-
-	return builder.String(), nil
 }
 
 // GetMetadata 获取 CSV 文件的元数据
@@ -45,14 +76,14 @@ func (r *CsvReader) GetMetadata(filePath string) (map[string]string, error) {
 	metadata := make(map[string]string)
 
 	// 打开文件
-	file, err := os.Open(filePath)
+	file, err := r.open(filePath)
 	if err != nil {
 		return nil, WrapError("CsvReader.GetMetadata", filePath, ErrFileOpen)
 	}
 	defer file.Close()
 
 	// 创建 CSV 读取器
-	reader := csv.NewReader(file)
+	reader := r.newCSVReader(file)
 
 	// 读取所有记录
 	records, err := reader.ReadAll()
@@ -66,41 +97,205 @@ func (r *CsvReader) GetMetadata(filePath string) (map[string]string, error) {
 	}
 
 	// 获取文件信息
-	fileInfo, err := os.Stat(filePath)
+	fileInfo, err := r.stat(filePath)
 	if err == nil {
 		metadata["size"] = fmt.Sprintf("%d", fileInfo.Size())
 		metadata["modified"] = fileInfo.ModTime().String()
 	}
 
+	// 单页格式只有一个部分
+	metadata["section_count"] = "1"
+
 	return metadata, nil
 }
 
-// GetRecords 获取 CSV 文件的结构化数据
+// GetRecords 获取 CSV 文件的结构化数据，空文件返回空切片（不是 nil）
 func (r *CsvReader) GetRecords(filePath string) ([][]string, error) {
-	file, err := os.Open(filePath)
+	file, err := r.open(filePath)
 	if err != nil {
 		return nil, WrapError("CsvReader.GetRecords", filePath, ErrFileOpen)
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
+	reader := r.newCSVReader(file)
 	records, err := reader.ReadAll()
 	if err != nil {
 		return nil, WrapError("CsvReader.GetRecords", filePath, ErrFileRead)
 	}
+	if records == nil {
+		records = make([][]string, 0)
+	}
 
 	return records, nil
 }
 
+// ColumnKind CSV 列的数据类型
+type ColumnKind int
+
+const (
+	// ColumnString 字符串（默认），保持原样
+	ColumnString ColumnKind = iota
+
+	// ColumnInt 整数，转换为 int64
+	ColumnInt
+
+	// ColumnFloat 浮点数，转换为 float64
+	ColumnFloat
+
+	// ColumnBool 布尔值，转换为 bool，接受 strconv.ParseBool 支持的写法
+	ColumnBool
+
+	// ColumnDate 日期时间，转换为 time.Time
+	ColumnDate
+)
+
+// ColumnType 描述 CSV 列的类型
+type ColumnType struct {
+	// Kind 列的数据类型
+	Kind ColumnKind
+
+	// DateLayout 仅用于 ColumnDate，time.Parse 使用的格式，为空时使用 "2006-01-02"
+	DateLayout string
+}
+
+// CellError 单个单元格的类型转换错误
+type CellError struct {
+	// Row 记录索引（从0开始）
+	Row int
+
+	// Column 列索引（从0开始）
+	Column int
+
+	// Value 单元格的原始内容
+	Value string
+
+	// Err 转换失败的原因
+	Err error
+}
+
+// Error 实现 error 接口
+func (e CellError) Error() string {
+	return fmt.Sprintf("row %d, column %d: %q: %v", e.Row, e.Column, e.Value, e.Err)
+}
+
+// Unwrap 返回转换失败的原始错误
+func (e CellError) Unwrap() error {
+	return e.Err
+}
+
+// CellErrors 类型转换时收集到的所有单元格错误
+type CellErrors []CellError
+
+// Error 实现 error 接口
+func (e CellErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more cell errors)", e[0].Error(), len(e)-1)
+}
+
+// Unwrap 返回所有单元格错误，支持 errors.Is 和 errors.As
+func (e CellErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, cellErr := range e {
+		errs[i] = cellErr
+	}
+	return errs
+}
+
+// GetTypedRecords 按 schema 读取 CSV 记录并转换每个单元格的类型
+// schema[i] 描述第 i 列，超出 schema 的列按字符串处理；非字符串列的空单元格（去除首尾空白后）转换为 nil。
+// 转换失败的单元格为 nil，并继续处理其余单元格；所有转换错误以 CellErrors 的形式与记录一起返回。
+// 表头行同样会按 schema 转换，需要时请先去掉表头，或忽略 Row 为 0 的错误。文件无法解析时返回 ErrFileRead
+func (r *CsvReader) GetTypedRecords(filePath string, schema []ColumnType) ([][]any, error) {
+	file, err := r.open(filePath)
+	if err != nil {
+		return nil, WrapError("CsvReader.GetTypedRecords", filePath, ErrFileOpen)
+	}
+	defer file.Close()
+
+	records, err := r.newCSVReader(file).ReadAll()
+	if err != nil {
+		return nil, WrapError("CsvReader.GetTypedRecords", filePath, ErrFileRead)
+	}
+
+	var cellErrors CellErrors
+	typed := make([][]any, 0, len(records))
+	for rowIndex, record := range records {
+		row := make([]any, len(record))
+		for colIndex, cell := range record {
+			if colIndex >= len(schema) {
+				row[colIndex] = cell
+				continue
+			}
+			value, err := schema[colIndex].convert(cell)
+			if err != nil {
+				cellErrors = append(cellErrors, CellError{Row: rowIndex, Column: colIndex, Value: cell, Err: err})
+				continue
+			}
+			row[colIndex] = value
+		}
+		typed = append(typed, row)
+	}
+
+	if len(cellErrors) > 0 {
+		return typed, cellErrors
+	}
+	return typed, nil
+}
+
+// convert 将单元格内容转换为列类型对应的值
+func (c ColumnType) convert(cell string) (any, error) {
+	if c.Kind == ColumnString {
+		return cell, nil
+	}
+
+	value := strings.TrimSpace(cell)
+	if value == "" {
+		return nil, nil
+	}
+
+	switch c.Kind {
+	case ColumnInt:
+		return strconv.ParseInt(value, 10, 64)
+	case ColumnFloat:
+		return strconv.ParseFloat(value, 64)
+	case ColumnBool:
+		return strconv.ParseBool(value)
+	case ColumnDate:
+		layout := c.DateLayout
+		if layout == "" {
+			layout = "2006-01-02"
+		}
+		return time.Parse(layout, value)
+	default:
+		return cell, nil
+	}
+}
+
+// Capabilities 返回 CSV 读取器支持的功能：单页，支持表格、列选择和记录
+func (r *CsvReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{
+		Tables:  true,
+		Columns: true,
+		Records: true,
+	}
+}
+
 // ReadWithConfig 根据配置读取 CSV 文件，返回结构化结果
 func (r *CsvReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
-	file, err := os.Open(filePath)
+	if metadataOnly(config) {
+		metadata, err := r.GetMetadata(filePath)
+		return metadataOnlyResult("CsvReader.ReadWithConfig", filePath, 1, metadata, err)
+	}
+
+	file, err := r.open(filePath)
 	if err != nil {
 		return nil, WrapError("CsvReader.ReadWithConfig", filePath, ErrFileOpen)
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
+	reader := r.newCSVReader(file)
 	records, err := reader.ReadAll()
 	if err != nil {
 		return nil, WrapError("CsvReader.ReadWithConfig", filePath, ErrFileRead)
@@ -117,25 +312,22 @@ func (r *CsvReader) ReadWithConfig(filePath string, config *ReadConfig) (*Docume
 	metadata, _ := r.GetMetadata(filePath)
 	result.Metadata = metadata
 
-	// 将每行记录转换为字符串
+	// 将每行记录转换为字符串，先按列选择器筛选单元格
+	colFilter := columnFilter(config)
 	lines := make([]string, 0, len(records))
 	for rowIndex, record := range records {
-		line := fmt.Sprintf("Row %d: %s", rowIndex+1, strings.Join(record, " | "))
-		lines = append(lines, line)
+		cells, _ := filterLinesWithIndexes(record, colFilter)
+		lines = append(lines, formatRow(rowIndex+1, cells, config))
 	}
 
 	// 根据配置筛选行
-	filteredLines := filterLinesForSinglePage(lines, config)
-
-	pageContent := PageContent{
-		PageNumber: 0,
-		Lines:      filteredLines,
-		TotalLines: len(filteredLines),
-	}
+	pageContent := newPageContent(0, lines, singlePageFilter(config), config)
 
 	result.Pages = append(result.Pages, pageContent)
-	result.TotalLines = len(filteredLines)
-	result.Content = strings.Join(filteredLines, "\n")
+	result.TotalLines = pageContent.TotalLines
+	result.finish(config)
+
+	reportProgress(config, 1, 1)
 
 	return result, nil
 }
@@ -0,0 +1,132 @@
+package docreader
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// metadataTimeLayouts 元数据中日期可能使用的格式：OOXML 的 ISO 8601（W3CDTF）以及 time.Time.String() 的输出
+var metadataTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+}
+
+// CreatedTime 返回文档的创建时间，第二个返回值表示是否解析成功
+// 依次读取元数据中的 created（DOCX/XLSX/PPTX，ISO 8601）和 creation_date（PDF，"D:YYYYMMDDHHmmSS+08'00'"）；
+// 基于文件的格式没有创建时间，返回 false
+func (d *Document) CreatedTime() (time.Time, bool) {
+	return metadataTime(d.Metadata, "created", "creation_date")
+}
+
+// ModifiedTime 返回文档的修改时间，第二个返回值表示是否解析成功
+// 依次读取元数据中的 modified（DOCX/XLSX/PPTX 为 ISO 8601，其他格式为文件系统的修改时间）和 modification_date（PDF）
+func (d *Document) ModifiedTime() (time.Time, bool) {
+	return metadataTime(d.Metadata, "modified", "modification_date")
+}
+
+// metadataTime 按顺序查找第一个非空的键并解析其中的日期
+func metadataTime(metadata map[string]string, keys ...string) (time.Time, bool) {
+	for _, key := range keys {
+		if value := strings.TrimSpace(metadata[key]); value != "" {
+			return parseMetadataTime(value)
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseMetadataTime 解析元数据中的日期，支持 ISO 8601、PDF 日期和 time.Time.String() 的格式
+func parseMetadataTime(value string) (time.Time, bool) {
+	// PDF 元数据中的字符串值可能带有引号
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = strings.TrimSpace(unquoted)
+	}
+	// time.Time.String() 可能附带单调时钟读数（" m=+0.001"）
+	if i := strings.Index(value, " m="); i >= 0 {
+		value = value[:i]
+	}
+
+	if strings.HasPrefix(value, "D:") {
+		return parsePdfDate(value[2:])
+	}
+	for _, layout := range metadataTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	// 没有 "D:" 前缀的 PDF 日期
+	return parsePdfDate(value)
+}
+
+// parsePdfDate 解析 PDF 日期 "YYYYMMDDHHmmSSOHH'mm'"（不含 "D:" 前缀）
+// 年份之后的部分都可以省略，省略的月、日为 1，时、分、秒为 0；没有时区时按 UTC 处理
+func parsePdfDate(value string) (time.Time, bool) {
+	fields := []int{0, 1, 1, 0, 0, 0}
+	widths := []int{4, 2, 2, 2, 2, 2}
+	pos := 0
+	for i, width := range widths {
+		if pos+width > len(value) || !isDigits(value[pos:pos+width]) {
+			if i == 0 {
+				return time.Time{}, false
+			}
+			break
+		}
+		fields[i], _ = strconv.Atoi(value[pos : pos+width])
+		pos += width
+	}
+	year, month, day, hour, minute, second := fields[0], fields[1], fields[2], fields[3], fields[4], fields[5]
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, false
+	}
+
+	location := time.UTC
+	if rest := value[pos:]; rest != "" {
+		switch rest[0] {
+		case 'Z':
+			// "Z" 之后可能还有 "00'00'"
+		case '+', '-':
+			offset := strings.Split(strings.TrimSuffix(rest[1:], "'"), "'")
+			if len(offset[0]) != 2 || !isDigits(offset[0]) {
+				return time.Time{}, false
+			}
+			hours, _ := strconv.Atoi(offset[0])
+			minutes := 0
+			if len(offset) > 1 && offset[1] != "" {
+				if !isDigits(offset[1]) {
+					return time.Time{}, false
+				}
+				minutes, _ = strconv.Atoi(offset[1])
+			}
+			seconds := hours*3600 + minutes*60
+			if rest[0] == '-' {
+				seconds = -seconds
+			}
+			location = time.FixedZone("", seconds)
+		default:
+			return time.Time{}, false
+		}
+	}
+
+	t := time.Date(year, time.Month(month), day, hour, minute, second, 0, location)
+	// 拒绝 2 月 30 日这类会被 time.Date 顺延的日期
+	if t.Day() != day {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// isDigits 判断字符串是否非空且只包含 ASCII 数字
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package docreader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMetadataTimes(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		created  string // RFC 3339，为空表示期望解析失败
+		modified string
+	}{
+		{
+			"OOXML",
+			map[string]string{"created": "2024-01-02T03:04:05Z", "modified": "2024-02-03T04:05:06+08:00"},
+			"2024-01-02T03:04:05Z", "2024-02-03T04:05:06+08:00",
+		},
+		{
+			"PDF",
+			map[string]string{"creation_date": "D:20240102030405+08'00'", "modification_date": `"D:20240203"`},
+			"2024-01-02T03:04:05+08:00", "2024-02-03T00:00:00Z",
+		},
+		{
+			"PDF UTC 和负时区",
+			map[string]string{"creation_date": "D:20240102030405Z00'00'", "modification_date": "D:202402030405-05'30"},
+			"2024-01-02T03:04:05Z", "2024-02-03T04:05:00-05:30",
+		},
+		{
+			"文件修改时间",
+			map[string]string{"modified": "2024-03-04 05:06:07.123456789 +0800 CST m=+0.001"},
+			"", "2024-03-04T05:06:07.123456789+08:00",
+		},
+		{
+			"无效日期",
+			map[string]string{"created": "D:20240230", "modified": "yesterday"},
+			"", "",
+		},
+		{"没有元数据", nil, "", ""},
+	}
+
+	check := func(t *testing.T, label string, got time.Time, ok bool, want string) {
+		t.Helper()
+		if want == "" {
+			if ok {
+				t.Errorf("%s 期望解析失败，实际 %v", label, got)
+			}
+			return
+		}
+		expected, _ := time.Parse(time.RFC3339Nano, want)
+		if !ok || !got.Equal(expected) {
+			t.Errorf("%s 期望 %v，实际 %v (%v)", label, expected, got, ok)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &Document{Metadata: tt.metadata}
+			created, ok := doc.CreatedTime()
+			check(t, "创建时间", created, ok, tt.created)
+			modified, ok := doc.ModifiedTime()
+			check(t, "修改时间", modified, ok, tt.modified)
+		})
+	}
+
+	t.Run("读取的文本文件", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "a.txt")
+		if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+			t.Fatalf("创建测试文件失败: %v", err)
+		}
+		mtime := time.Date(2023, 5, 6, 7, 8, 9, 0, time.UTC)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("设置修改时间失败: %v", err)
+		}
+		doc, err := ReadDocument(path)
+		if err != nil {
+			t.Fatalf("读取失败: %v", err)
+		}
+		if modified, ok := doc.ModifiedTime(); !ok || !modified.Equal(mtime) {
+			t.Errorf("期望 %v，实际 %v (%v)", mtime, modified, ok)
+		}
+		if _, ok := doc.CreatedTime(); ok {
+			t.Error("文本文件没有创建时间")
+		}
+	})
+}
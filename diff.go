@@ -0,0 +1,134 @@
+package docreader
+
+import "strings"
+
+// DiffOp 差异行的操作类型
+type DiffOp int
+
+const (
+	// DiffEqual 两个文档中都存在的行
+	DiffEqual DiffOp = iota
+
+	// DiffInsert 只存在于第二个文档中的行（新增）
+	DiffInsert
+
+	// DiffDelete 只存在于第一个文档中的行（删除）
+	DiffDelete
+)
+
+// String 返回操作类型的名称
+func (op DiffOp) String() string {
+	switch op {
+	case DiffEqual:
+		return "equal"
+	case DiffInsert:
+		return "insert"
+	case DiffDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// DiffLine 差异结果中的一行
+type DiffLine struct {
+	// Op 操作类型
+	Op DiffOp
+
+	// Text 行内容
+	Text string
+}
+
+// DiffDocuments 比较两个文档的文本，返回按行计算的最短差异（Myers 算法）
+// 两个文档都通过 ReadDocument 读取，因此可以比较不同格式的文档（如 DOCX 与其导出的 PDF）。
+// 结果按第一个文档的顺序排列，同一位置的删除行在新增行之前；任一文档读取失败时返回该错误
+func DiffDocuments(pathA, pathB string) ([]DiffLine, error) {
+	docA, err := ReadDocument(pathA)
+	if err != nil {
+		return nil, err
+	}
+	docB, err := ReadDocument(pathB)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffLines(strings.Split(docA.Content, "\n"), strings.Split(docB.Content, "\n")), nil
+}
+
+// diffLines 使用 Myers 算法计算 a 到 b 的最短编辑脚本
+func diffLines(a, b []string) []DiffLine {
+	n, m := len(a), len(b)
+	maxD := n + m
+	offset := maxD + 1
+
+	// v[k+offset] 为对角线 k 上到达的最远 x；trace[d] 保存第 d 步编辑之前 v 中对角线 -d 到 d 的部分，用于回溯，
+	// 第 d 步只会读取这一范围，因此内存与编辑距离的平方成正比，而不是与文档长度成正比
+	v := make([]int, 2*maxD+3)
+	trace := make([][]int, 0)
+
+	found := false
+	for d := 0; d <= maxD && !found; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+				x = v[k+1+offset] // 向下移动：插入 b 中的行
+			} else {
+				x = v[k-1+offset] + 1 // 向右移动：删除 a 中的行
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[k+offset] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	// 从终点回溯，逆序生成差异行
+	reversed := make([]DiffLine, 0, maxD)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		// 第 0 步之前只有起点，剩余的都是相同的行
+		if d == 0 {
+			for x > 0 && y > 0 {
+				x, y = x-1, y-1
+				reversed = append(reversed, DiffLine{Op: DiffEqual, Text: a[x]})
+			}
+			break
+		}
+
+		// v[k+d] 为对角线 k 上的 x
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[k-1+d] < v[k+1+d]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[prevK+d]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			reversed = append(reversed, DiffLine{Op: DiffEqual, Text: a[x]})
+		}
+		if x == prevX {
+			reversed = append(reversed, DiffLine{Op: DiffInsert, Text: b[prevY]})
+		} else {
+			reversed = append(reversed, DiffLine{Op: DiffDelete, Text: a[prevX]})
+		}
+		x, y = prevX, prevY
+	}
+
+	result := make([]DiffLine, len(reversed))
+	for i, line := range reversed {
+		result[len(reversed)-1-i] = line
+	}
+	return result
+}
@@ -0,0 +1,45 @@
+package docreader
+
+import "unicode"
+
+// textDirectionThreshold 强方向字符中某一方向的占比达到该值时视为该方向，否则为 "mixed"
+const textDirectionThreshold = 0.8
+
+// TextDirection 根据内容中强方向字符的比例判断文本方向，返回 "ltr"、"rtl" 或 "mixed"
+// 阿拉伯文、希伯来文、叙利亚文等从右到左书写的文字中的字母计为 RTL，其他文字的字母（拉丁文、中文等）计为 LTR；
+// 数字、标点、空白和组合符号不参与计算。某一方向占强方向字符的 80% 以上时返回该方向，
+// 没有任何字母时返回 "ltr"。结果可直接用作 HTML 的 dir 属性（"mixed" 时可使用 dir="auto"）
+func (d *Document) TextDirection() string {
+	return detectTextDirection(d.Content)
+}
+
+// detectTextDirection 统计强 RTL 和强 LTR 字符，按占比判断文本方向
+func detectTextDirection(text string) string {
+	var rtl, ltr int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if isRTLRune(r) {
+			rtl++
+		} else {
+			ltr++
+		}
+	}
+
+	total := rtl + ltr
+	switch {
+	case total == 0 || float64(ltr) >= textDirectionThreshold*float64(total):
+		return "ltr"
+	case float64(rtl) >= textDirectionThreshold*float64(total):
+		return "rtl"
+	default:
+		return "mixed"
+	}
+}
+
+// isRTLRune 判断字母是否属于从右到左书写的文字
+func isRTLRune(r rune) bool {
+	return unicode.In(r, unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko,
+		unicode.Samaritan, unicode.Mandaic, unicode.Adlam, unicode.Hanifi_Rohingya)
+}
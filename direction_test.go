@@ -0,0 +1,30 @@
+package docreader
+
+import "testing"
+
+func TestTextDirection(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"英文", "The quick brown fox jumps over the lazy dog.", "ltr"},
+		{"中文", "这是一个测试文档。", "ltr"},
+		{"阿拉伯文", "هذا مستند تجريبي باللغة العربية.", "rtl"},
+		{"希伯来文", "זהו מסמך בדיקה בעברית.", "rtl"},
+		{"阿拉伯文夹少量英文", "هذا مستند تجريبي عن PDF باللغة العربية", "rtl"},
+		{"混合", "Hello world مرحبا بالعالم", "mixed"},
+		{"阿拉伯数字和标点不计入", "مرحبا ١٢٣ 456 !؟", "rtl"},
+		{"空内容", "", "ltr"},
+		{"只有数字", "12345 -- 678", "ltr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &Document{Content: tt.content}
+			if direction := doc.TextDirection(); direction != tt.expected {
+				t.Errorf("期望 %q，实际 %q", tt.expected, direction)
+			}
+		})
+	}
+}
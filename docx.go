@@ -2,13 +2,27 @@ package docreader
 
 import (
 	"archive/zip"
+	"bytes"
 	"encoding/xml"
+	"errors"
 	"io"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
 // DocxReader 用于读取 .docx 文件
-type DocxReader struct{}
+type DocxReader struct {
+	// TableMode ReadText 输出表格内容的方式，默认同时输出段落和表格
+	// ReadWithConfig 使用 ReadConfig.TableMode
+	TableMode TableMode
+
+	// TrackChangesMode ReadText 处理修订标记的方式，默认保持原样
+	// ReadWithConfig 使用 ReadConfig.TrackChangesMode
+	TrackChangesMode TrackChangesMode
+
+	fileSource
+}
 
 // WordDocument 表示 Word 文档的 XML 结构
 type WordDocument struct {
@@ -46,58 +60,64 @@ type CoreProperties struct {
 
 // ReadText 读取 DOCX 文件的文本内容
 func (r *DocxReader) ReadText(filePath string) (string, error) {
+	var builder strings.Builder
+	if err := r.writeText(&builder, filePath); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// writeText 将 DOCX 文本写入 w
+func (r *DocxReader) writeText(w io.Writer, filePath string) error {
 	// 打开 zip 文件
-	zipReader, err := zip.OpenReader(filePath)
+	zipReader, err := r.openZip(filePath)
 	if err != nil {
-		return "", WrapError("DocxReader.ReadText", filePath, ErrFileOpen)
+		return WrapError("DocxReader.ReadText", filePath, ErrFileOpen)
 	}
 	defer zipReader.Close()
 
-	// 查找并读取 document.xml
-	var documentXML []byte
-	for _, file := range zipReader.File {
-		if file.Name == "word/document.xml" {
-			rc, err := file.Open()
-			if err != nil {
-				return "", WrapError("DocxReader.ReadText", filePath, ErrFileRead)
-			}
-			documentXML, err = io.ReadAll(rc)
-			rc.Close()
-			if err != nil {
-				return "", WrapError("DocxReader.ReadText", filePath, ErrFileRead)
-			}
-			break
-		}
-	}
-
-	if documentXML == nil {
-		return "", WrapError("DocxReader.ReadText", filePath, ErrInvalidFormat)
+	// 读取 document.xml：缺失时为 ErrInvalidFormat，无法读取时为 ErrFileRead
+	documentXML, err := readZipPart(zipReader.Reader, "word/document.xml", newZipLimits(nil))
+	if err != nil {
+		return WrapError("DocxReader.ReadText", filePath, err)
 	}
 
 	// 解析 XML
-	var doc WordDocument
+	var doc docxOrderedDocument
 	if err := xml.Unmarshal(documentXML, &doc); err != nil {
-		return "", WrapError("DocxReader.ReadText", filePath, ErrFileParse)
+		return WrapError("DocxReader.ReadText", filePath, ErrFileParse)
 	}
 
 	// 提取文本
-	var builder strings.Builder
+	builder := newTextWriter(w)
+	opts := docxOptions{tableMode: r.TableMode, trackChanges: r.TrackChangesMode}
 
 	// 提取段落文本
-	for _, para := range doc.Body.Paragraphs {
-		for _, run := range para.Runs {
-			builder.WriteString(run.Text)
+	if opts.tableMode != TableOnly {
+		for _, element := range doc.Body.Elements {
+			if element.XMLName.Local != "p" {
+				continue
+			}
+			for _, run := range docxRuns(element.Content, opts.trackChanges) {
+				builder.WriteString(run.text())
+			}
+			builder.WriteString("\n")
 		}
-		builder.WriteString("\n")
 	}
 
 	// 提取表格文本
-	for _, table := range doc.Body.Tables {
-		for _, row := range table.Rows {
+	if opts.tableMode == TableExclude {
+		return builder.err
+	}
+	for _, element := range doc.Body.Elements {
+		if element.XMLName.Local != "tbl" {
+			continue
+		}
+		for _, row := range element.Rows {
 			for _, cell := range row.Cells {
 				for _, para := range cell.Paragraphs {
-					for _, run := range para.Runs {
-						builder.WriteString(run.Text)
+					for _, run := range docxRuns(para.Content, opts.trackChanges) {
+						builder.WriteString(run.text())
 						builder.WriteString(" ")
 					}
 				}
@@ -107,144 +127,1058 @@ func (r *DocxReader) ReadText(filePath string) (string, error) {
 		}
 	}
 
-	return builder.String(), nil
+	return builder.err
 }
 
 // GetMetadata 获取 DOCX 文件的元数据
 func (r *DocxReader) GetMetadata(filePath string) (map[string]string, error) {
-	zipReader, err := zip.OpenReader(filePath)
+	zipReader, err := r.openZip(filePath)
 	if err != nil {
 		return nil, WrapError("DocxReader.GetMetadata", filePath, ErrFileOpen)
 	}
 	defer zipReader.Close()
 
-	metadata := make(map[string]string)
-
-	// 读取核心属性
-	for _, file := range zipReader.File {
-		if file.Name == "docProps/core.xml" {
-			rc, err := file.Open()
-			if err != nil {
-				continue
-			}
-			data, err := io.ReadAll(rc)
-			rc.Close()
-			if err != nil {
-				continue
-			}
+	limits := newZipLimits(nil)
+	metadata, err := docxMetadata(zipReader.Reader, limits)
+	if err != nil {
+		return nil, WrapError("DocxReader.GetMetadata", filePath, err)
+	}
 
-			var props CoreProperties
-			if err := xml.Unmarshal(data, &props); err == nil {
-				metadata["title"] = props.Title
-				metadata["subject"] = props.Subject
-				metadata["creator"] = props.Creator
-				metadata["description"] = props.Description
-				metadata["created"] = props.Created
-				metadata["modified"] = props.Modified
-			}
-			break
+	// 按分页符统计页数，与 ReadWithConfig 的 TotalPages 一致
+	// 元数据不依赖正文，document.xml 缺失或损坏时不提供 section_count
+	if documentXML, err := readZipPart(zipReader.Reader, "word/document.xml", limits); err == nil {
+		var doc docxOrderedDocument
+		if xml.Unmarshal(documentXML, &doc) == nil {
+			metadata["section_count"] = strconv.Itoa(len(doc.pageLines(docxOptions{})))
 		}
 	}
 
 	return metadata, nil
 }
 
-// ReadWithConfig 根据配置读取 DOCX 文件，返回结构化结果
-// DOCX 文件以段落为单位，将每个段落视为一行
-func (r *DocxReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
-	zipReader, err := zip.OpenReader(filePath)
+// ListParts 列出 DOCX 包中的所有部件名称（zip 条目，不包括目录），按包中的顺序返回
+// 例如 "word/document.xml"、"docProps/app.xml"、"customXml/item1.xml"
+func (r *DocxReader) ListParts(filePath string) ([]string, error) {
+	zipReader, err := r.openZip(filePath)
 	if err != nil {
-		return nil, WrapError("DocxReader.ReadWithConfig", filePath, ErrFileOpen)
+		return nil, WrapError("DocxReader.ListParts", filePath, ErrFileOpen)
 	}
 	defer zipReader.Close()
 
-	// 查找并读取 document.xml
-	var documentXML []byte
+	parts := make([]string, 0, len(zipReader.File))
 	for _, file := range zipReader.File {
-		if file.Name == "word/document.xml" {
-			rc, err := file.Open()
-			if err != nil {
-				return nil, WrapError("DocxReader.ReadWithConfig", filePath, ErrFileRead)
-			}
-			documentXML, err = io.ReadAll(rc)
-			rc.Close()
-			if err != nil {
-				return nil, WrapError("DocxReader.ReadWithConfig", filePath, ErrFileRead)
-			}
-			break
+		if file.FileInfo().IsDir() {
+			continue
 		}
+		parts = append(parts, file.Name)
 	}
+	return parts, nil
+}
 
-	if documentXML == nil {
-		return nil, WrapError("DocxReader.ReadWithConfig", filePath, ErrInvalidFormat)
+// GetPart 读取 DOCX 包中指定部件的原始内容，partName 为 ListParts 返回的名称
+// 部件不存在时返回 ErrInvalidFormat，数据损坏无法读取时返回 ErrFileRead，解压后超过默认大小限制时返回 ErrFileTooLarge
+func (r *DocxReader) GetPart(filePath, partName string) ([]byte, error) {
+	zipReader, err := r.openZip(filePath)
+	if err != nil {
+		return nil, WrapError("DocxReader.GetPart", filePath, ErrFileOpen)
 	}
+	defer zipReader.Close()
 
-	// 解析 XML
-	var doc WordDocument
+	data, err := readZipPart(zipReader.Reader, partName, newZipLimits(nil))
+	if err != nil {
+		return nil, WrapError("DocxReader.GetPart", filePath, err)
+	}
+	return data, nil
+}
+
+// GetDocumentXML 读取正文部件 word/document.xml 的原始内容，用于排查文本提取的问题
+// 错误与 GetPart 相同：正文部件不存在时返回 ErrInvalidFormat
+func (r *DocxReader) GetDocumentXML(filePath string) ([]byte, error) {
+	zipReader, err := r.openZip(filePath)
+	if err != nil {
+		return nil, WrapError("DocxReader.GetDocumentXML", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	data, err := readZipPart(zipReader.Reader, "word/document.xml", newZipLimits(nil))
+	if err != nil {
+		return nil, WrapError("DocxReader.GetDocumentXML", filePath, err)
+	}
+	return data, nil
+}
+
+// docxMetadata 从已打开的 zip 包中提取 DOCX 元数据
+// docProps/core.xml 和 docProps/app.xml 都是可选部件，缺失时忽略；存在但无法读取时返回 ErrFileRead，
+// 此时返回的元数据仍包含另一个部件中可以读取的属性
+func docxMetadata(zipReader *zip.Reader, limits zipLimits) (map[string]string, error) {
+	metadata := make(map[string]string)
+
+	// 读取核心属性
+	data, readErr := readZipPart(zipReader, "docProps/core.xml", limits)
+	if errors.Is(readErr, ErrInvalidFormat) {
+		readErr = nil
+	}
+	if readErr == nil && data != nil {
+		var props CoreProperties
+		if err := xml.Unmarshal(data, &props); err == nil {
+			metadata["title"] = props.Title
+			metadata["subject"] = props.Subject
+			metadata["creator"] = props.Creator
+			metadata["description"] = props.Description
+			metadata["created"] = props.Created
+			metadata["modified"] = props.Modified
+		}
+	}
+
+	// 读取扩展属性（页数、字数、公司等）
+	appProps, err := readAppProperties(zipReader, limits)
+	if err != nil {
+		return metadata, err
+	}
+	appProps.addTo(metadata)
+
+	return metadata, readErr
+}
+
+// Capabilities 返回 DOCX 读取器支持的功能：按分页符分页，支持表格和表单域，可以包含图片，元数据包含文档记录的创建和修改时间
+func (r *DocxReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{
+		Pagination:    true,
+		Tables:        true,
+		Images:        true,
+		FormFields:    true,
+		MetadataDates: true,
+	}
+}
+
+// ReadWithConfig 根据配置读取 DOCX 文件，返回结构化结果
+// DOCX 文件以段落为单位，将每个段落视为一行；手动分页符（<w:br w:type="page"/>）、
+// 段前分页（w:pageBreakBefore）和分节符会开始新的一页，每页中段落在前、表格行在后
+func (r *DocxReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
+	zipReader, err := r.openZip(filePath)
+	if err != nil {
+		return nil, WrapError("DocxReader.ReadWithConfig", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	// 读取 document.xml：缺失时为 ErrInvalidFormat，无法读取时为 ErrFileRead
+	limits := newZipLimits(config)
+	documentXML, err := readZipPart(zipReader.Reader, "word/document.xml", limits)
+	if err != nil {
+		return nil, WrapError("DocxReader.ReadWithConfig", filePath, err)
+	}
+
+	// 解析 XML，并按分页符拆分为多页
+	var doc docxOrderedDocument
 	if err := xml.Unmarshal(documentXML, &doc); err != nil {
 		return nil, WrapError("DocxReader.ReadWithConfig", filePath, ErrFileParse)
 	}
+	var opts docxOptions
+	if config != nil {
+		opts = docxOptions{tableMode: config.TableMode, trackChanges: config.TrackChangesMode}
+	}
+	allPages := doc.pageLines(opts)
+	totalPages := len(allPages)
 
 	result := &DocumentResult{
 		FilePath:   filePath,
-		TotalPages: 1, // DOCX 作为单页处理
+		TotalPages: totalPages,
 		Pages:      make([]PageContent, 0),
 		Metadata:   make(map[string]string),
 	}
 
-	// 获取元数据
-	metadata, _ := r.GetMetadata(filePath)
-	result.Metadata = metadata
+	// 获取元数据（复用已打开的 zip 包）
+	// 元数据部件是可选的，无法读取时记录警告并继续读取正文
+	result.Metadata, err = docxMetadata(zipReader.Reader, limits)
+	if err != nil {
+		if err := result.partialFailure(config, "DocxReader.ReadWithConfig", "metadata: %v", err); err != nil {
+			return nil, err
+		}
+	}
+	result.Metadata["section_count"] = strconv.Itoa(totalPages)
 
-	// 提取所有段落和表格行
-	lines := make([]string, 0)
+	// 只需要元数据时不生成页面内容（页数仍需解析正文中的分页符）
+	if metadataOnly(config) {
+		return metadataOnlyResult("DocxReader.ReadWithConfig", filePath, totalPages, result.Metadata, nil)
+	}
 
-	// 提取段落文本
-	for _, para := range doc.Body.Paragraphs {
-		var lineBuilder strings.Builder
-		for _, run := range para.Runs {
-			lineBuilder.WriteString(run.Text)
+	// 确定要读取的页和每页的行配置
+	pageLineMap := buildPageLineMap(config, totalPages)
+
+	totalLines := 0
+	processed := 0
+
+	for pageIndex := 0; pageIndex < totalPages; pageIndex++ {
+		lineConfig, shouldRead := pageLineMap[pageIndex]
+		if !shouldRead {
+			continue
 		}
-		line := lineBuilder.String()
-		if line != "" {
-			lines = append(lines, line)
+		processed++
+
+		// 根据该页的配置筛选行
+		pageContent := newPageContent(pageIndex, allPages[pageIndex], lineConfig, config)
+
+		result.Pages = append(result.Pages, pageContent)
+		totalLines += pageContent.TotalLines
+
+		reportProgress(config, processed, len(pageLineMap))
+	}
+
+	result.TotalLines = totalLines
+	result.finish(config)
+
+	return result, nil
+}
+
+// docxOptions 控制 DOCX 文本提取的选项
+type docxOptions struct {
+	tableMode    TableMode
+	trackChanges TrackChangesMode
+}
+
+// docxOrderedDocument 按文档顺序保留 body 中的段落和表格，用于按分页符拆分页面
+type docxOrderedDocument struct {
+	XMLName xml.Name `xml:"document"`
+	Body    struct {
+		Elements []docxBodyElement `xml:",any"`
+	} `xml:"body"`
+}
+
+// docxBodyElement 表示 body 的直接子元素：段落（p）使用 Properties 和 Content，表格（tbl）使用 Rows
+type docxBodyElement struct {
+	XMLName    xml.Name
+	Properties docxParagraphProperties `xml:"pPr"`
+	Content    []docxRun               `xml:",any"`
+	Rows       []struct {
+		Cells []struct {
+			Paragraphs []struct {
+				Content []docxRun `xml:",any"`
+			} `xml:"p"`
+		} `xml:"tc"`
+	} `xml:"tr"`
+}
+
+// docxParagraphProperties 表示段落属性中与分页有关的部分
+type docxParagraphProperties struct {
+	PageBreakBefore *struct {
+		Val string `xml:"val,attr"`
+	} `xml:"pageBreakBefore"`
+	SectionProperties *struct {
+		Type struct {
+			Val string `xml:"val,attr"`
+		} `xml:"type"`
+	} `xml:"sectPr"`
+}
+
+// docxRun 表示段落中的一个子元素
+// 对于 run（r），Children 按顺序保留文本和换页符；对于修订标记（ins/del 等），Runs 为其中包含的 run
+type docxRun struct {
+	XMLName  xml.Name
+	Children []struct {
+		XMLName xml.Name
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	} `xml:",any"`
+	Runs []docxRun `xml:"r"`
+}
+
+// text 返回 run 中所有文本（<w:t>，以及删除修订中的 <w:delText>）
+func (run docxRun) text() string {
+	var builder strings.Builder
+	for _, child := range run.Children {
+		if isDocxText(child.XMLName.Local) {
+			builder.WriteString(child.Text)
 		}
 	}
+	return builder.String()
+}
 
-	// 提取表格文本
-	for _, table := range doc.Body.Tables {
-		for _, row := range table.Rows {
-			var rowBuilder strings.Builder
-			for cellIndex, cell := range row.Cells {
-				if cellIndex > 0 {
-					rowBuilder.WriteString("\t")
+// isDocxText 判断 run 的子元素是否为文本元素
+func isDocxText(local string) bool {
+	return local == "t" || local == "delText"
+}
+
+// docxRuns 根据修订模式展开段落内容，返回需要输出的 run
+// 插入修订（ins/moveTo）只在 TrackChangesFinal 中保留，删除修订（del/moveFrom）只在 TrackChangesOriginal 中保留
+func docxRuns(content []docxRun, mode TrackChangesMode) []docxRun {
+	runs := make([]docxRun, 0, len(content))
+	for _, item := range content {
+		switch item.XMLName.Local {
+		case "r":
+			runs = append(runs, item)
+		case "ins", "moveTo":
+			if mode == TrackChangesFinal {
+				runs = append(runs, item.Runs...)
+			}
+		case "del", "moveFrom":
+			if mode == TrackChangesOriginal {
+				runs = append(runs, item.Runs...)
+			}
+		}
+	}
+	return runs
+}
+
+// startsNewPage 判断段落是否设置了段前分页
+func (props docxParagraphProperties) startsNewPage() bool {
+	if props.PageBreakBefore == nil {
+		return false
+	}
+	val := props.PageBreakBefore.Val
+	return val != "0" && val != "false" && val != "off"
+}
+
+// endsSection 判断段落是否以分节符结束；连续分节符（continuous）不开始新页
+func (props docxParagraphProperties) endsSection() bool {
+	return props.SectionProperties != nil && props.SectionProperties.Type.Val != "continuous"
+}
+
+// pageLines 按分页符将文档拆分为多页，返回每页的行
+// 每个分页符开始新的一页，因此页数为分页符数量加一；每页中段落在前、表格行在后
+// opts.tableMode 只决定输出哪些行，不影响分页
+func (doc *docxOrderedDocument) pageLines(opts docxOptions) [][]string {
+	paragraphs := [][]string{{}}
+	tables := [][]string{{}}
+
+	newPage := func() {
+		paragraphs = append(paragraphs, []string{})
+		tables = append(tables, []string{})
+	}
+	addParagraph := func(line string) {
+		if line != "" && opts.tableMode != TableOnly {
+			current := len(paragraphs) - 1
+			paragraphs[current] = append(paragraphs[current], line)
+		}
+	}
+
+	for _, element := range doc.Body.Elements {
+		switch element.XMLName.Local {
+		case "p":
+			if element.Properties.startsNewPage() {
+				newPage()
+			}
+
+			var lineBuilder strings.Builder
+			for _, run := range docxRuns(element.Content, opts.trackChanges) {
+				for _, child := range run.Children {
+					switch {
+					case isDocxText(child.XMLName.Local):
+						lineBuilder.WriteString(child.Text)
+					case child.XMLName.Local == "br" && child.Type == "page":
+						// 分页符之前的文本留在当前页，之后的文本属于下一页
+						addParagraph(lineBuilder.String())
+						lineBuilder.Reset()
+						newPage()
+					}
 				}
-				for _, para := range cell.Paragraphs {
-					for _, run := range para.Runs {
-						rowBuilder.WriteString(run.Text)
-						rowBuilder.WriteString(" ")
+			}
+			addParagraph(lineBuilder.String())
+
+			if element.Properties.endsSection() {
+				newPage()
+			}
+		case "tbl":
+			if opts.tableMode == TableExclude {
+				continue
+			}
+			current := len(tables) - 1
+			for _, row := range element.Rows {
+				var rowBuilder strings.Builder
+				for cellIndex, cell := range row.Cells {
+					if cellIndex > 0 {
+						rowBuilder.WriteString("\t")
+					}
+					for _, para := range cell.Paragraphs {
+						for _, run := range docxRuns(para.Content, opts.trackChanges) {
+							rowBuilder.WriteString(run.text())
+							rowBuilder.WriteString(" ")
+						}
 					}
 				}
+				line := strings.TrimSpace(rowBuilder.String())
+				if line != "" {
+					tables[current] = append(tables[current], line)
+				}
 			}
-			line := strings.TrimSpace(rowBuilder.String())
-			if line != "" {
-				lines = append(lines, line)
+		}
+	}
+
+	pages := make([][]string, len(paragraphs))
+	for i := range paragraphs {
+		pages[i] = append(paragraphs[i], tables[i]...)
+	}
+	return pages
+}
+
+// GetFormFields 提取 DOCX 表单中的字段值
+// 内容控件（w:sdt）以标记（w:tag）为键，没有标记时使用标题（w:alias）；显示占位符文本的控件值为空。
+// 旧式文本表单域（FORMTEXT）以域名称（w:ffData/w:name）为键。多个段落的值以换行符连接，
+// 同名字段只保留第一次出现的值，没有名称的字段会被忽略
+func (r *DocxReader) GetFormFields(filePath string) (map[string]string, error) {
+	zipReader, err := r.openZip(filePath)
+	if err != nil {
+		return nil, WrapError("DocxReader.GetFormFields", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	documentXML, err := readZipPart(zipReader.Reader, "word/document.xml", newZipLimits(nil))
+	if err != nil {
+		return nil, WrapError("DocxReader.GetFormFields", filePath, err)
+	}
+
+	fields, err := docxFormFields(documentXML)
+	if err != nil {
+		return nil, WrapError("DocxReader.GetFormFields", filePath, ErrFileParse)
+	}
+	return fields, nil
+}
+
+// docxFormControl 表示扫描过程中一个尚未结束的内容控件
+type docxFormControl struct {
+	tag         string
+	alias       string
+	placeholder bool
+	inContent   bool
+	value       strings.Builder
+}
+
+// docxFormFields 扫描 document.xml，收集内容控件和旧式文本表单域的值
+func docxFormFields(data []byte) (map[string]string, error) {
+	fields := make(map[string]string)
+	addField := func(name, value string) {
+		if name == "" {
+			return
+		}
+		if _, exists := fields[name]; !exists {
+			fields[name] = value
+		}
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var controls []*docxFormControl
+	inText := false
+
+	// 旧式表单域：begin 中的 ffData 给出名称，separate 与 end 之间的文本为值
+	legacyName := ""
+	inLegacyValue := false
+	var legacyValue strings.Builder
+
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			return fields, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			var current *docxFormControl
+			if len(controls) > 0 {
+				current = controls[len(controls)-1]
+			}
+
+			switch t.Name.Local {
+			case "sdt":
+				controls = append(controls, &docxFormControl{})
+			case "sdtContent":
+				if current != nil {
+					current.inContent = true
+				}
+			case "tag":
+				if current != nil && !current.inContent {
+					current.tag = xmlAttr(t, "val")
+				}
+			case "alias":
+				if current != nil && !current.inContent {
+					current.alias = xmlAttr(t, "val")
+				}
+			case "showingPlcHdr":
+				if current != nil && !current.inContent {
+					val := xmlAttr(t, "val")
+					current.placeholder = val != "0" && val != "false"
+				}
+			case "name":
+				if !inLegacyValue {
+					legacyName = xmlAttr(t, "val")
+				}
+			case "fldChar":
+				switch xmlAttr(t, "fldCharType") {
+				case "separate":
+					if legacyName != "" {
+						inLegacyValue = true
+						legacyValue.Reset()
+					}
+				case "end":
+					if inLegacyValue {
+						addField(legacyName, legacyValue.String())
+					}
+					legacyName = ""
+					inLegacyValue = false
+				}
+			case "t":
+				inText = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				// 段落之间以换行符分隔
+				for _, control := range controls {
+					if control.inContent && control.value.Len() > 0 {
+						control.value.WriteString("\n")
+					}
+				}
+			case "sdt":
+				if len(controls) == 0 {
+					continue
+				}
+				control := controls[len(controls)-1]
+				controls = controls[:len(controls)-1]
+
+				name := control.tag
+				if name == "" {
+					name = control.alias
+				}
+				value := strings.TrimRight(control.value.String(), "\n")
+				if control.placeholder {
+					value = ""
+				}
+				addField(name, value)
+			}
+		case xml.CharData:
+			if !inText {
+				continue
+			}
+			for _, control := range controls {
+				if control.inContent {
+					control.value.Write(t)
+				}
+			}
+			if inLegacyValue {
+				legacyValue.Write(t)
 			}
 		}
 	}
+}
+
+// xmlAttr 返回元素中本地名称为 name 的属性值
+func xmlAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// Comment 文档中的一条批注
+type Comment struct {
+	// ID 批注编号（w:id）
+	ID string
+
+	// Author 作者
+	Author string
+
+	// Initials 作者缩写
+	Initials string
+
+	// Date 批注时间，保持文件中的原始格式（如 "2024-01-02T15:04:05Z"）
+	Date string
+
+	// Text 批注内容，多个段落以换行符连接
+	Text string
+
+	// Done 批注是否已被标记为完成（commentsExtended.xml 的 done）
+	Done bool
+
+	// Replies 对该批注的回复，按文档顺序排列
+	Replies []Comment
+}
+
+// docxComments 表示 word/comments.xml
+type docxComments struct {
+	Comments []struct {
+		ID         string `xml:"id,attr"`
+		Author     string `xml:"author,attr"`
+		Initials   string `xml:"initials,attr"`
+		Date       string `xml:"date,attr"`
+		Paragraphs []struct {
+			ParaID  string    `xml:"paraId,attr"`
+			Content []docxRun `xml:",any"`
+		} `xml:"p"`
+	} `xml:"comment"`
+}
+
+// docxCommentsExtended 表示 word/commentsExtended.xml，以批注最后一个段落的 paraId 记录回复关系和完成状态
+type docxCommentsExtended struct {
+	Entries []struct {
+		ParaID       string `xml:"paraId,attr"`
+		ParentParaID string `xml:"paraIdParent,attr"`
+		Done         string `xml:"done,attr"`
+	} `xml:"commentEx"`
+}
+
+// GetComments 获取文档中的批注，回复嵌套在被回复批注的 Replies 中
+// 回复关系来自 word/commentsExtended.xml（Word 2013 及以后的版本写入），缺少该部件时所有批注都是顶层批注；
+// 被回复的批注不存在时回复作为顶层批注返回。文档没有批注时返回空切片
+func (r *DocxReader) GetComments(filePath string) ([]Comment, error) {
+	zipReader, err := r.openZip(filePath)
+	if err != nil {
+		return nil, WrapError("DocxReader.GetComments", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
 
-	// 根据配置筛选行
-	filteredLines := filterLinesForSinglePage(lines, config)
+	limits := newZipLimits(nil)
+	data, err := readZipPart(zipReader.Reader, "word/comments.xml", limits)
+	if errors.Is(err, ErrInvalidFormat) {
+		return []Comment{}, nil
+	}
+	if err != nil {
+		return nil, WrapError("DocxReader.GetComments", filePath, err)
+	}
 
-	pageContent := PageContent{
-		PageNumber: 0,
-		Lines:      filteredLines,
-		TotalLines: len(filteredLines),
+	var comments docxComments
+	if err := xml.Unmarshal(data, &comments); err != nil {
+		return nil, WrapError("DocxReader.GetComments", filePath, ErrFileParse)
 	}
 
-	result.Pages = append(result.Pages, pageContent)
-	result.TotalLines = len(filteredLines)
-	result.Content = strings.Join(filteredLines, "\n")
+	// 回复关系是可选的
+	var extended docxCommentsExtended
+	data, err = readZipPart(zipReader.Reader, "word/commentsExtended.xml", limits)
+	if err != nil && !errors.Is(err, ErrInvalidFormat) {
+		return nil, WrapError("DocxReader.GetComments", filePath, err)
+	}
+	if err == nil {
+		if err := xml.Unmarshal(data, &extended); err != nil {
+			return nil, WrapError("DocxReader.GetComments", filePath, ErrFileParse)
+		}
+	}
 
-	return result, nil
+	return buildCommentTree(comments, extended), nil
+}
+
+// buildCommentTree 根据 paraId 将回复挂到被回复的批注下
+func buildCommentTree(comments docxComments, extended docxCommentsExtended) []Comment {
+	flat := make([]Comment, len(comments.Comments))
+	byParaID := make(map[string]int)
+	for i, c := range comments.Comments {
+		lines := make([]string, 0, len(c.Paragraphs))
+		for _, paragraph := range c.Paragraphs {
+			var builder strings.Builder
+			for _, run := range docxRuns(paragraph.Content, TrackChangesRaw) {
+				builder.WriteString(run.text())
+			}
+			lines = append(lines, builder.String())
+		}
+		flat[i] = Comment{ID: c.ID, Author: c.Author, Initials: c.Initials, Date: c.Date, Text: strings.Join(lines, "\n")}
+
+		// commentsExtended 使用批注最后一个段落的 paraId
+		if n := len(c.Paragraphs); n > 0 && c.Paragraphs[n-1].ParaID != "" {
+			byParaID[c.Paragraphs[n-1].ParaID] = i
+		}
+	}
+
+	parent := make([]int, len(flat))
+	for i := range parent {
+		parent[i] = -1
+	}
+	for _, entry := range extended.Entries {
+		index, ok := byParaID[entry.ParaID]
+		if !ok {
+			continue
+		}
+		flat[index].Done = entry.Done == "1" || entry.Done == "true"
+		if parentIndex, ok := byParaID[entry.ParentParaID]; ok && parentIndex != index {
+			parent[index] = parentIndex
+		}
+	}
+
+	children := make([][]int, len(flat))
+	roots := make([]int, 0, len(flat))
+	for i := range flat {
+		if parent[i] >= 0 && !commentCycle(parent, i) {
+			children[parent[i]] = append(children[parent[i]], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+
+	var build func(index int) Comment
+	build = func(index int) Comment {
+		comment := flat[index]
+		for _, child := range children[index] {
+			comment.Replies = append(comment.Replies, build(child))
+		}
+		return comment
+	}
+
+	tree := make([]Comment, 0, len(roots))
+	for _, index := range roots {
+		tree = append(tree, build(index))
+	}
+	return tree
+}
+
+// commentCycle 判断沿 parent 向上查找时是否会回到 index，损坏的文件中回复关系可能形成环
+func commentCycle(parent []int, index int) bool {
+	for current, steps := parent[index], 0; current >= 0; current, steps = parent[current], steps+1 {
+		if current == index || steps > len(parent) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDataBindings 提取绑定到自定义 XML 数据存储（customXml/itemN.xml）的内容控件的值
+// 内容控件（w:sdt）的 w:dataBinding 给出数据存储的 ID（w:storeItemID，对应 itemPropsN.xml 的 ds:itemID）和 XPath，
+// 值按 XPath 从自定义 XML 部件中读取，而不是正文中显示的文本。以标记（w:tag）为键，没有标记时使用 XPath；
+// XPath 只支持由元素名称、位置谓词（如 [1]）以及末尾的 @属性 或 text() 组成的绝对路径，
+// 无法解析或在数据中找不到的绑定会被忽略，同一键只保留第一次出现的值。文档没有数据绑定时返回空 map
+func (r *DocxReader) GetDataBindings(filePath string) (map[string]string, error) {
+	zipReader, err := r.openZip(filePath)
+	if err != nil {
+		return nil, WrapError("DocxReader.GetDataBindings", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	limits := newZipLimits(nil)
+	documentXML, err := readZipPart(zipReader.Reader, "word/document.xml", limits)
+	if err != nil {
+		return nil, WrapError("DocxReader.GetDataBindings", filePath, err)
+	}
+	bindings, err := docxDataBindings(documentXML)
+	if err != nil {
+		return nil, WrapError("DocxReader.GetDataBindings", filePath, ErrFileParse)
+	}
+
+	values := make(map[string]string)
+	if len(bindings) == 0 {
+		return values, nil
+	}
+
+	stores, err := docxCustomXMLStores(zipReader.Reader, limits)
+	if err != nil {
+		return nil, WrapError("DocxReader.GetDataBindings", filePath, err)
+	}
+
+	for _, binding := range bindings {
+		key := binding.tag
+		if key == "" {
+			key = binding.xpath
+		}
+		if _, exists := values[key]; exists {
+			continue
+		}
+		if value, ok := binding.resolve(stores); ok {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// docxDataBinding 内容控件的数据绑定（w:dataBinding）
+type docxDataBinding struct {
+	tag         string
+	xpath       string
+	storeItemID string
+	prefixes    map[string]string
+}
+
+// docxCustomXMLStore 一个自定义 XML 数据存储
+type docxCustomXMLStore struct {
+	itemID string
+	root   *xmlNode
+}
+
+// xmlNode 自定义 XML 数据中的一个元素
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*xmlNode
+	text     strings.Builder
+}
+
+// textContent 返回元素及其所有子元素的文本
+func (n *xmlNode) textContent() string {
+	if len(n.children) == 0 {
+		return n.text.String()
+	}
+	var builder strings.Builder
+	var walk func(node *xmlNode)
+	walk = func(node *xmlNode) {
+		builder.WriteString(node.text.String())
+		for _, child := range node.children {
+			walk(child)
+		}
+	}
+	walk(n)
+	return builder.String()
+}
+
+// prefixMappingPattern 匹配 w:prefixMappings 中的 xmlns:前缀='命名空间'
+var prefixMappingPattern = regexp.MustCompile(`xmlns:([\w.-]+)\s*=\s*(?:'([^']*)'|"([^"]*)")`)
+
+// docxDataBindings 扫描 document.xml，按内容控件结束的顺序收集数据绑定
+func docxDataBindings(data []byte) ([]docxDataBinding, error) {
+	type control struct {
+		binding   docxDataBinding
+		inContent bool
+	}
+
+	bindings := make([]docxDataBinding, 0)
+	var controls []*control
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			return bindings, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "sdt" {
+				controls = append(controls, &control{})
+				continue
+			}
+			if len(controls) == 0 {
+				continue
+			}
+			current := controls[len(controls)-1]
+			switch t.Name.Local {
+			case "sdtContent":
+				current.inContent = true
+			case "tag":
+				if !current.inContent {
+					current.binding.tag = xmlAttr(t, "val")
+				}
+			case "dataBinding":
+				if !current.inContent {
+					current.binding.xpath = xmlAttr(t, "xpath")
+					current.binding.storeItemID = xmlAttr(t, "storeItemID")
+					current.binding.prefixes = make(map[string]string)
+					for _, match := range prefixMappingPattern.FindAllStringSubmatch(xmlAttr(t, "prefixMappings"), -1) {
+						current.binding.prefixes[match[1]] = match[2] + match[3]
+					}
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local != "sdt" || len(controls) == 0 {
+				continue
+			}
+			current := controls[len(controls)-1]
+			controls = controls[:len(controls)-1]
+			if current.binding.xpath != "" {
+				bindings = append(bindings, current.binding)
+			}
+		}
+	}
+}
+
+// docxCustomXMLStores 读取包中的自定义 XML 部件（customXml/itemN.xml）及其 itemPropsN.xml 中的数据存储 ID
+// 部件存在但无法读取时返回 ErrFileRead，无法解析时返回 ErrFileParse
+func docxCustomXMLStores(zipReader *zip.Reader, limits zipLimits) ([]docxCustomXMLStore, error) {
+	stores := make([]docxCustomXMLStore, 0)
+	for _, file := range zipReader.File {
+		number, ok := strings.CutPrefix(file.Name, "customXml/item")
+		if !ok {
+			continue
+		}
+		number, ok = strings.CutSuffix(number, ".xml")
+		if _, err := strconv.Atoi(number); !ok || err != nil {
+			continue
+		}
+
+		data, err := readZipFile(file, limits)
+		if err != nil {
+			return nil, err
+		}
+		root, err := parseXMLTree(data)
+		if err != nil {
+			return nil, ErrFileParse
+		}
+		store := docxCustomXMLStore{root: root}
+
+		// 数据存储 ID 是可选的，itemProps 缺失或无法解析时只能通过 XPath 匹配
+		if props, err := readZipPart(zipReader, "customXml/itemProps"+number+".xml", limits); err == nil {
+			var item struct {
+				ItemID string `xml:"itemID,attr"`
+			}
+			if xml.Unmarshal(props, &item) == nil {
+				store.itemID = item.ItemID
+			}
+		}
+		stores = append(stores, store)
+	}
+	return stores, nil
+}
+
+// parseXMLTree 将 XML 解析为元素树，返回一个以文档根元素为唯一子元素的虚拟节点
+func parseXMLTree(data []byte) (*xmlNode, error) {
+	document := &xmlNode{}
+	stack := []*xmlNode{document}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return document, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		current := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name, attrs: t.Attr}
+			current.children = append(current.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			current.text.Write(t)
+		}
+	}
+}
+
+// resolve 在数据存储中查找绑定的值：storeItemID 匹配的数据存储优先，没有匹配时依次尝试所有数据存储
+func (b docxDataBinding) resolve(stores []docxCustomXMLStore) (string, bool) {
+	candidates := make([]docxCustomXMLStore, 0, len(stores))
+	for _, store := range stores {
+		if b.storeItemID != "" && strings.EqualFold(store.itemID, b.storeItemID) {
+			candidates = append(candidates, store)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = stores
+	}
+
+	for _, store := range candidates {
+		if value, ok := evalBindingXPath(store.root, b.xpath, b.prefixes); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// evalBindingXPath 计算 Word 数据绑定使用的简单 XPath，如 "/ns0:root[1]/ns0:item[2]/@id"
+// 没有位置谓词的步骤选择第一个匹配的元素；前缀在 prefixes 中时同时比较命名空间，否则只比较本地名称
+func evalBindingXPath(document *xmlNode, xpath string, prefixes map[string]string) (string, bool) {
+	steps, ok := splitXPath(xpath)
+	if !ok {
+		return "", false
+	}
+
+	current := document
+	for i, step := range steps {
+		last := i == len(steps)-1
+		switch {
+		case step == "text()" && last:
+			return current.text.String(), current != document
+		case strings.HasPrefix(step, "@") && last:
+			space, local := resolveXPathName(step[1:], prefixes)
+			for _, attr := range current.attrs {
+				if attr.Name.Local == local && (space == "" || attr.Name.Space == space) {
+					return attr.Value, true
+				}
+			}
+			return "", false
+		}
+
+		name, position, ok := parseXPathStep(step)
+		if !ok {
+			return "", false
+		}
+		space, local := resolveXPathName(name, prefixes)
+
+		var next *xmlNode
+		count := 0
+		for _, child := range current.children {
+			if (local != "*" && child.name.Local != local) || (space != "" && child.name.Space != space) {
+				continue
+			}
+			count++
+			if count == position {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return "", false
+		}
+		current = next
+	}
+
+	if current == document {
+		return "", false
+	}
+	return current.textContent(), true
+}
+
+// splitXPath 将绝对路径按 "/" 拆分为步骤，忽略谓词和引号中的 "/"；不支持 "//" 等空步骤
+func splitXPath(xpath string) ([]string, bool) {
+	if !strings.HasPrefix(xpath, "/") {
+		return nil, false
+	}
+
+	steps := make([]string, 0)
+	depth := 0
+	var quote rune
+	start := 1
+	for i, r := range xpath {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case r == '/' && depth == 0 && i > 0:
+			steps = append(steps, xpath[start:i])
+			start = i + 1
+		}
+	}
+	steps = append(steps, xpath[start:])
+
+	for _, step := range steps {
+		if step == "" {
+			return nil, false
+		}
+	}
+	return steps, true
+}
+
+// parseXPathStep 解析 "名称" 或 "名称[n]" 形式的步骤，返回名称和位置（从1开始）；其他谓词不受支持
+func parseXPathStep(step string) (string, int, bool) {
+	name, predicate, found := strings.Cut(step, "[")
+	if !found {
+		return name, 1, name != ""
+	}
+	predicate, ok := strings.CutSuffix(predicate, "]")
+	position, err := strconv.Atoi(strings.TrimSpace(predicate))
+	if !ok || err != nil || position < 1 || name == "" {
+		return "", 0, false
+	}
+	return name, position, true
+}
+
+// resolveXPathName 将 "前缀:名称" 解析为命名空间和本地名称，前缀未在 prefixes 中声明时命名空间为空（不比较）
+func resolveXPathName(name string, prefixes map[string]string) (string, string) {
+	prefix, local, found := strings.Cut(name, ":")
+	if !found {
+		return "", name
+	}
+	return prefixes[prefix], local
 }
@@ -0,0 +1,118 @@
+package docreader
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// EmbeddedObject Office 文档中嵌入的对象（如 Word 文档中嵌入的 Excel 工作簿）
+type EmbeddedObject struct {
+	// Name 对象在包中的部件名称，如 "word/embeddings/Microsoft_Excel_Worksheet.xlsx"
+	Name string
+
+	// ContentType 对象的内容类型，来自 [Content_Types].xml，未声明时为空
+	ContentType string
+
+	// Data 对象的原始内容
+	Data []byte
+}
+
+// contentTypes 表示 [Content_Types].xml
+type contentTypes struct {
+	Defaults []struct {
+		Extension   string `xml:"Extension,attr"`
+		ContentType string `xml:"ContentType,attr"`
+	} `xml:"Default"`
+	Overrides []struct {
+		PartName    string `xml:"PartName,attr"`
+		ContentType string `xml:"ContentType,attr"`
+	} `xml:"Override"`
+}
+
+// lookup 返回部件的内容类型：优先使用 Override，其次按扩展名使用 Default
+func (c contentTypes) lookup(partName string) string {
+	for _, override := range c.Overrides {
+		if strings.TrimPrefix(override.PartName, "/") == partName {
+			return override.ContentType
+		}
+	}
+	ext := strings.TrimPrefix(path.Ext(partName), ".")
+	for _, def := range c.Defaults {
+		if strings.EqualFold(def.Extension, ext) {
+			return def.ContentType
+		}
+	}
+	return ""
+}
+
+// GetEmbeddedObjects 获取 DOCX/XLSX/PPTX 中嵌入的对象（word/embeddings、xl/embeddings、ppt/embeddings 目录下的部件），
+// 按包中的顺序返回。嵌入的 Office 文档可以通过 EmbeddedObject.Read 继续读取；
+// 其他格式返回 ErrUnsupportedFormat，单个对象解压后超过默认大小限制时返回 ErrFileTooLarge
+func GetEmbeddedObjects(filePath string) ([]EmbeddedObject, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".docx", ".xlsx", ".pptx":
+	default:
+		return nil, WrapError("GetEmbeddedObjects", filePath, ErrUnsupportedFormat)
+	}
+
+	zipReader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, WrapError("GetEmbeddedObjects", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	limits := newZipLimits(nil)
+
+	// 内容类型是可选的，缺失或无法解析时 ContentType 为空
+	var types contentTypes
+	if data, err := readZipPart(&zipReader.Reader, "[Content_Types].xml", limits); err == nil {
+		_ = xml.Unmarshal(data, &types)
+	} else if !errors.Is(err, ErrInvalidFormat) {
+		return nil, WrapError("GetEmbeddedObjects", filePath, err)
+	}
+
+	objects := make([]EmbeddedObject, 0)
+	for _, file := range zipReader.File {
+		if !isEmbeddedPart(file.Name) {
+			continue
+		}
+		data, err := readZipFile(file, limits)
+		if err != nil {
+			return nil, WrapError("GetEmbeddedObjects", filePath, err)
+		}
+		objects = append(objects, EmbeddedObject{
+			Name:        file.Name,
+			ContentType: types.lookup(file.Name),
+			Data:        data,
+		})
+	}
+
+	return objects, nil
+}
+
+// isEmbeddedPart 判断 zip 条目是否为嵌入对象，即位于 word/、xl/ 或 ppt/ 下的 embeddings 目录中的文件
+func isEmbeddedPart(name string) bool {
+	dir := path.Dir(name)
+	return dir == "word/embeddings" || dir == "xl/embeddings" || dir == "ppt/embeddings"
+}
+
+// Read 根据名称的扩展名读取嵌入的对象，例如嵌入在 Word 文档中的 .xlsx 工作簿
+// 内容直接在内存中读取，不写入临时文件；返回的 Document.FilePath 为对象名称。
+// OLE 复合文档（.bin）等不支持的格式返回 ErrUnsupportedFormat
+func (o EmbeddedObject) Read() (*Document, error) {
+	if newFormatReader(path.Ext(o.Name)) == nil {
+		return nil, WrapError("EmbeddedObject.Read", o.Name, ErrUnsupportedFormat)
+	}
+
+	fsys, fileName := newMemoryFS(o.Name, o.Data)
+	doc, err := ReadDocumentFS(fsys, fileName)
+	if err != nil {
+		return nil, replaceErrorPath(err, fileName, o.Name)
+	}
+	doc.FilePath = o.Name
+	return doc, nil
+}
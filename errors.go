@@ -6,6 +6,10 @@ import (
 )
 
 // 预定义的错误类型
+//
+// 返回结构化数据的方法（如 CsvReader.GetRecords、PptxReader.GetSlides、XlsxReader.GetSheetData）遵循同一约定：
+// 输入中没有内容时返回非 nil 的空切片和 nil 错误，调用方不需要区分 nil 和空切片；
+// 按名称或索引指定的目标不存在时返回对应的错误（ErrSheetNotFound、ErrPageNotFound），而不是空结果
 var (
 	// ErrUnsupportedFormat 不支持的文件格式
 	ErrUnsupportedFormat = errors.New("unsupported file format")
@@ -30,6 +34,21 @@ var (
 
 	// ErrSheetNotFound 工作表不存在
 	ErrSheetNotFound = errors.New("sheet not found")
+
+	// ErrPageNotFound 页面不存在
+	ErrPageNotFound = errors.New("page not found")
+
+	// ErrInvalidQuery 搜索条件无效
+	ErrInvalidQuery = errors.New("invalid search query")
+
+	// ErrUnknownLanguage 无法识别文本语言
+	ErrUnknownLanguage = errors.New("unknown language")
+
+	// ErrInvalidConfig 读取配置无效
+	ErrInvalidConfig = errors.New("invalid read config")
+
+	// ErrFileTooLarge 文件超过允许的大小
+	ErrFileTooLarge = errors.New("file too large")
 )
 
 // DocumentError 文档错误结构
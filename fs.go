@@ -0,0 +1,239 @@
+package docreader
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// ReadDocumentFS 从 fs.FS（如 go:embed 的 embed.FS、zip 包或测试用的 fstest.MapFS）中读取文档
+// name 使用 fs.FS 的路径格式（以 "/" 分隔，不以 "/" 开头），根据扩展名选择读取器。
+// 文件通过 fsys.Open 读取，不会写入磁盘：zip 格式（DOCX/XLSX/PPTX 等）和 PDF 在文件实现了 io.ReaderAt 时直接随机读取，
+// 否则先读入内存。设置了全局默认配置时与 ReadDocument 一样使用该配置读取；
+// 返回的 Document.FilePath 和错误中的路径都是 name
+func ReadDocumentFS(fsys fs.FS, name string) (*Document, error) {
+	reader := newFormatReader(path.Ext(name))
+	if reader == nil {
+		return nil, WrapError("ReadDocumentFS", name, ErrUnsupportedFormat)
+	}
+
+	info, err := fs.Stat(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, WrapError("ReadDocumentFS", name, ErrFileNotFound)
+	}
+	if err != nil {
+		return nil, WrapError("ReadDocumentFS", name, ErrFileOpen)
+	}
+
+	config := defaultReadConfig()
+	if config != nil && config.MaxFileSize > 0 && info.Size() > config.MaxFileSize {
+		return nil, WrapError("ReadDocumentFS", name, ErrFileTooLarge)
+	}
+
+	useFileSystem(reader, fsys)
+	return readDocument(reader, name, config)
+}
+
+// fileSource 决定读取器如何打开文件，嵌入在每个内置读取器中
+// fsys 为 nil 时文件路径是操作系统中的路径；否则是 fsys 中的名称，文件通过 fsys.Open 读取
+type fileSource struct {
+	fsys fs.FS
+}
+
+// fileSystemUser 由嵌入了 fileSource 的读取器实现
+type fileSystemUser interface {
+	useFileSystem(fsys fs.FS)
+}
+
+// useFileSystem 让读取器从 fsys 中读取文件
+func (s *fileSource) useFileSystem(fsys fs.FS) {
+	s.fsys = fsys
+}
+
+// useFileSystem 让 reader 从 fsys 中读取文件，reader 没有嵌入 fileSource 时不做任何操作
+func useFileSystem(reader any, fsys fs.FS) {
+	if user, ok := reader.(fileSystemUser); ok {
+		user.useFileSystem(fsys)
+	}
+}
+
+// open 打开文件
+func (s *fileSource) open(name string) (fs.File, error) {
+	if s.fsys == nil {
+		return os.Open(name)
+	}
+	return s.fsys.Open(name)
+}
+
+// stat 获取文件信息
+func (s *fileSource) stat(name string) (fs.FileInfo, error) {
+	if s.fsys == nil {
+		return os.Stat(name)
+	}
+	return fs.Stat(s.fsys, name)
+}
+
+// readFile 读取文件的全部内容
+func (s *fileSource) readFile(name string) ([]byte, error) {
+	if s.fsys == nil {
+		return os.ReadFile(name)
+	}
+	return fs.ReadFile(s.fsys, name)
+}
+
+// readerAtCloser 支持随机读取的已打开文件
+type readerAtCloser interface {
+	io.ReaderAt
+	io.Closer
+}
+
+// openReaderAt 打开文件用于随机读取，返回文件和大小
+// fs.FS 中的文件没有实现 io.ReaderAt 时，先将其读入内存
+func (s *fileSource) openReaderAt(name string) (readerAtCloser, int64, error) {
+	file, err := s.open(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	if readerAt, ok := file.(readerAtCloser); ok {
+		return readerAt, info.Size(), nil
+	}
+
+	data, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return nil, 0, err
+	}
+	return nopReaderAtCloser{bytes.NewReader(data)}, int64(len(data)), nil
+}
+
+// nopReaderAtCloser 为内存中的数据提供空操作的 Close
+type nopReaderAtCloser struct {
+	io.ReaderAt
+}
+
+// Close 实现 io.Closer 接口
+func (nopReaderAtCloser) Close() error {
+	return nil
+}
+
+// zipFile 已打开的 zip 包，与 zip.ReadCloser 的用法相同
+type zipFile struct {
+	*zip.Reader
+	file io.Closer
+}
+
+// Close 关闭底层文件
+func (z *zipFile) Close() error {
+	return z.file.Close()
+}
+
+// openZip 以 zip 包的形式打开文件
+func (s *fileSource) openZip(name string) (*zipFile, error) {
+	file, size, err := s.openReaderAt(name)
+	if err != nil {
+		return nil, err
+	}
+	zipReader, err := zip.NewReader(file, size)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &zipFile{Reader: zipReader, file: file}, nil
+}
+
+// openPdf 打开 PDF 文件，返回的文件在读取完成后需要关闭
+func (s *fileSource) openPdf(name string) (readerAtCloser, *pdf.Reader, error) {
+	file, size, err := s.openReaderAt(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := pdf.NewReader(file, size)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return file, reader, nil
+}
+
+// memoryFS 只包含一个文件的只读 fs.FS，用于读取已经在内存中的文档（如 zip 条目、嵌入对象、iWork 预览 PDF）
+type memoryFS struct {
+	name string
+	data []byte
+}
+
+// newMemoryFS 创建内存文件系统，文件名为 "document" 加上 name 的扩展名，返回文件系统和文件名
+// 使用固定的文件名可以避免 name 中不符合 fs.ValidPath 的字符（如 zip 条目中的 ".."）
+func newMemoryFS(name string, data []byte) (memoryFS, string) {
+	fileName := "document" + path.Ext(name)
+	return memoryFS{name: fileName, data: data}, fileName
+}
+
+// Open 实现 fs.FS 接口
+func (m memoryFS) Open(name string) (fs.File, error) {
+	if name != m.name {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &memoryFile{Reader: bytes.NewReader(m.data), name: m.name}, nil
+}
+
+// memoryFile memoryFS 中打开的文件，支持 Read、ReadAt 和 Seek
+type memoryFile struct {
+	*bytes.Reader
+	name string
+}
+
+// Stat 实现 fs.File 接口
+func (f *memoryFile) Stat() (fs.FileInfo, error) {
+	return memoryFileInfo{name: f.name, size: f.Size()}, nil
+}
+
+// Close 实现 fs.File 接口
+func (f *memoryFile) Close() error {
+	return nil
+}
+
+// memoryFileInfo memoryFile 的文件信息，修改时间为零值
+type memoryFileInfo struct {
+	name string
+	size int64
+}
+
+func (i memoryFileInfo) Name() string       { return i.name }
+func (i memoryFileInfo) Size() int64        { return i.size }
+func (i memoryFileInfo) Mode() fs.FileMode  { return 0444 }
+func (i memoryFileInfo) ModTime() time.Time { return time.Time{} }
+func (i memoryFileInfo) IsDir() bool        { return false }
+func (i memoryFileInfo) Sys() any           { return nil }
+
+// newMemoryReader 创建读取内存中文档的读取器，根据 name 的扩展名选择格式
+// 返回读取器和传给读取器方法的文件名；不支持的格式返回 nil
+func newMemoryReader(name string, data []byte) (ConfigurableReader, string) {
+	reader := newFormatReader(path.Ext(name))
+	if reader == nil {
+		return nil, ""
+	}
+	fsys, fileName := newMemoryFS(name, data)
+	useFileSystem(reader, fsys)
+	return reader, fileName
+}
+
+// replaceErrorPath 将错误中的文件路径 from 替换为 to，用于隐藏内存文件系统中的文件名
+func replaceErrorPath(err error, from, to string) error {
+	var docErr *DocumentError
+	if errors.As(err, &docErr) && docErr.FilePath == from {
+		docErr.FilePath = to
+	}
+	return err
+}
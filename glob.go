@@ -0,0 +1,128 @@
+package docreader
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReadGlob 读取所有匹配 pattern 的文档，返回以文件路径为键的结果
+// pattern 使用 filepath.Match 的语法（如 "reports/2024/*.pdf"），此外 "**" 作为单独的路径段时匹配任意层目录
+// （如 "docs/**/*.md" 同时匹配 docs/a.md 和 docs/x/y/b.md）。目录和不支持的扩展名会被跳过。
+// 单个文件读取失败不影响其他文件：成功的文档仍在结果中返回，所有失败的 DocumentError 通过 errors.Join 合并后返回；
+// pattern 无效时返回 ErrInvalidQuery
+func ReadGlob(pattern string) (map[string]*Document, error) {
+	paths, err := globFiles(pattern)
+	if err != nil {
+		return nil, WrapError("ReadGlob", pattern, fmt.Errorf("%w: %w", ErrInvalidQuery, err))
+	}
+
+	docs := make(map[string]*Document, len(paths))
+	var errs []error
+	for _, path := range paths {
+		if newFormatReader(filepath.Ext(path)) == nil {
+			continue
+		}
+
+		doc, err := ReadDocument(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		docs[path] = doc
+	}
+
+	return docs, errors.Join(errs...)
+}
+
+// globFiles 返回匹配 pattern 的普通文件，按路径排序
+func globFiles(pattern string) ([]string, error) {
+	if strings.Contains(pattern, "**") {
+		return globRecursive(pattern)
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && info.Mode().IsRegular() {
+			files = append(files, match)
+		}
+	}
+	return files, nil
+}
+
+// globRecursive 遍历 pattern 中第一个通配段之前的目录，匹配包含 "**" 的模式，结果按遍历顺序（字典序）排列
+// 与 filepath.Glob 一样不跟随符号链接进入目录
+func globRecursive(pattern string) ([]string, error) {
+	segments := strings.Split(filepath.ToSlash(filepath.Clean(pattern)), "/")
+	for _, segment := range segments {
+		if segment == "**" {
+			continue
+		}
+		// 提前校验每个路径段，避免遍历后才发现模式无效
+		if _, err := filepath.Match(segment, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	// 根目录为第一个包含通配符的路径段之前的部分
+	rootSegments := 0
+	for rootSegments < len(segments) && !strings.ContainsAny(segments[rootSegments], "*?[\\") {
+		rootSegments++
+	}
+	root := strings.Join(segments[:rootSegments], "/")
+	if root == "" {
+		root = "."
+		if strings.HasPrefix(pattern, "/") {
+			root = "/"
+		}
+	}
+
+	matches := make([]string, 0)
+	err := filepath.WalkDir(filepath.FromSlash(root), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// 无法访问的目录不影响其他匹配
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		if matchGlobSegments(segments, strings.Split(filepath.ToSlash(path), "/")) {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// matchGlobSegments 按路径段匹配，"**" 匹配零个或多个路径段
+func matchGlobSegments(pattern, path []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for skip := 0; skip <= len(path); skip++ {
+				if matchGlobSegments(pattern[1:], path[skip:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(path) == 0 {
+			return false
+		}
+		if ok, _ := filepath.Match(pattern[0], path[0]); !ok {
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0
+}
@@ -1,8 +1,79 @@
 package docreader
 
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
 // helpers.go 包含文档读取的公共辅助函数
 // 这些函数被多个格式读取器共享使用
 
+// contentLayout 描述如何将页面拼接为完整的文本内容
+type contentLayout int
+
+const (
+	// layoutPlain 单页格式（TXT/MD/CSV/RTF/DOCX）：所有行以换行符连接
+	layoutPlain contentLayout = iota
+
+	// layoutPages PDF：每页之后附加页码分隔符
+	layoutPages
+
+	// layoutSlides PPTX：每张幻灯片之前附加标题
+	layoutSlides
+
+	// layoutSheets XLSX：每个工作表之前附加工作表名称
+	layoutSheets
+
+	// layoutFiles ZIP：每个文件之前附加文件在压缩包中的路径
+	layoutFiles
+)
+
+// buildContent 按照布局将页面内容拼接为完整文本
+func buildContent(pages []PageContent, layout contentLayout) string {
+	if layout == layoutPlain {
+		if len(pages) == 1 {
+			return strings.Join(pages[0].Lines, "\n")
+		}
+		lines := make([]string, 0)
+		for _, page := range pages {
+			lines = append(lines, page.Lines...)
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	var builder strings.Builder
+	for _, page := range pages {
+		switch layout {
+		case layoutSlides:
+			builder.WriteString(fmt.Sprintf("\n=== 幻灯片 %d ===\n\n", page.PageNumber))
+		case layoutSheets:
+			builder.WriteString(fmt.Sprintf("\n=== 工作表: %s ===\n\n", page.PageName))
+		case layoutFiles:
+			builder.WriteString(fmt.Sprintf("\n=== 文件: %s ===\n\n", page.PageName))
+		}
+
+		for _, line := range page.Lines {
+			builder.WriteString(line)
+			builder.WriteString("\n")
+		}
+
+		switch layout {
+		case layoutPages:
+			builder.WriteString(fmt.Sprintf("\n--- 第 %d 页 ---\n\n", page.PageNumber))
+		case layoutSheets, layoutFiles:
+			builder.WriteString("\n")
+		}
+	}
+	return builder.String()
+}
+
 // pageLineFilter 存储单页的行过滤配置
 type pageLineFilter struct {
 	lines   map[int]bool // 要读取的行号集合
@@ -19,31 +90,7 @@ func buildPageLineMap(config *ReadConfig, totalPages int) map[int]pageLineFilter
 			if pageConfig.PageIndex < 0 || pageConfig.PageIndex >= totalPages {
 				continue
 			}
-
-			linesSet := make(map[int]bool)
-
-			// 添加离散的行号
-			for _, line := range pageConfig.LineSelector.Indexes {
-				if line >= 0 {
-					linesSet[line] = true
-				}
-			}
-
-			// 添加行范围
-			for _, lineRange := range pageConfig.LineSelector.Ranges {
-				start, end := lineRange[0], lineRange[1]
-				if start < 0 {
-					start = 0
-				}
-				for i := start; i <= end; i++ {
-					linesSet[i] = true
-				}
-			}
-
-			result[pageConfig.PageIndex] = pageLineFilter{
-				lines:   linesSet,
-				readAll: len(linesSet) == 0,
-			}
+			result[pageConfig.PageIndex] = buildLineFilter(pageConfig.LineSelector)
 		}
 		return result
 	}
@@ -68,18 +115,22 @@ func buildGlobalLineFilter(config *ReadConfig) pageLineFilter {
 	if config == nil || (config.LineSelector.Indexes == nil && config.LineSelector.Ranges == nil) {
 		return pageLineFilter{readAll: true}
 	}
+	return buildLineFilter(config.LineSelector)
+}
 
+// buildLineFilter 根据行选择器构建行过滤器
+func buildLineFilter(selector Selector) pageLineFilter {
 	linesSet := make(map[int]bool)
 
 	// 添加离散的行号
-	for _, line := range config.LineSelector.Indexes {
+	for _, line := range selector.Indexes {
 		if line >= 0 {
 			linesSet[line] = true
 		}
 	}
 
 	// 添加行号范围
-	for _, lineRange := range config.LineSelector.Ranges {
+	for _, lineRange := range selector.Ranges {
 		start, end := lineRange[0], lineRange[1]
 		if start < 0 {
 			start = 0
@@ -95,65 +146,124 @@ func buildGlobalLineFilter(config *ReadConfig) pageLineFilter {
 	}
 }
 
-// filterLinesForPage 根据页面配置筛选行
-func filterLinesForPage(lines []string, filter pageLineFilter) []string {
+// columnFilter 根据配置构建列过滤器，未配置列选择器时保留所有列
+func columnFilter(config *ReadConfig) pageLineFilter {
+	if config == nil {
+		return pageLineFilter{readAll: true}
+	}
+	return buildLineFilter(config.ColumnSelector)
+}
+
+// defaultCellSeparator 默认的单元格分隔符
+const defaultCellSeparator = " | "
+
+// cellSeparator 返回实际使用的单元格分隔符，为空时使用默认值
+func cellSeparator(sep string) string {
+	if sep == "" {
+		return defaultCellSeparator
+	}
+	return sep
+}
+
+// formatRow 将一行单元格格式化为文本行，默认添加 "Row N: " 前缀，开启 RawCells 时只输出单元格
+func formatRow(rowNumber int, cells []string, config *ReadConfig) string {
+	if config == nil {
+		return fmt.Sprintf("Row %d: %s", rowNumber, strings.Join(cells, defaultCellSeparator))
+	}
+
+	line := strings.Join(cells, cellSeparator(config.CellSeparator))
+	if config.RawCells {
+		return line
+	}
+	return fmt.Sprintf("Row %d: %s", rowNumber, line)
+}
+
+// filterLinesWithIndexes 根据页面配置筛选行，同时返回每一行在原始行中的索引
+// 读取所有行时不分配索引切片，返回的索引为 nil
+func filterLinesWithIndexes(lines []string, filter pageLineFilter) ([]string, []int) {
 	if filter.readAll {
-		return lines
+		return lines, nil
 	}
 
 	result := make([]string, 0, len(filter.lines))
+	indexes := make([]int, 0, len(filter.lines))
 	for i := 0; i < len(lines); i++ {
 		if filter.lines[i] {
 			result = append(result, lines[i])
+			indexes = append(indexes, i)
 		}
 	}
 
-	return result
+	return result, indexes
 }
 
-// filterLinesForSinglePage 为单页文档筛选行（用于 TXT/MD/CSV/RTF/DOCX）
-func filterLinesForSinglePage(lines []string, config *ReadConfig) []string {
+// singlePageFilter 为单页文档构建行过滤器（用于 TXT/MD/CSV/RTF/DOCX）
+func singlePageFilter(config *ReadConfig) pageLineFilter {
 	if config != nil && len(config.PageConfigs) > 0 {
 		// 查找页面0的配置
 		for _, pageConfig := range config.PageConfigs {
 			if pageConfig.PageIndex == 0 {
-				linesSet := make(map[int]bool)
-
-				// 添加离散行号
-				for _, line := range pageConfig.LineSelector.Indexes {
-					if line >= 0 {
-						linesSet[line] = true
-					}
-				}
-
-				// 添加行范围
-				for _, lineRange := range pageConfig.LineSelector.Ranges {
-					start, end := lineRange[0], lineRange[1]
-					if start < 0 {
-						start = 0
-					}
-					for i := start; i <= end; i++ {
-						linesSet[i] = true
-					}
-				}
-
-				filter := pageLineFilter{
-					lines:   linesSet,
-					readAll: len(linesSet) == 0,
-				}
-
-				return filterLinesForPage(lines, filter)
+				return buildLineFilter(pageConfig.LineSelector)
 			}
 		}
-		return []string{}
+		// 页面0没有配置时不读取任何行
+		return pageLineFilter{lines: map[int]bool{}}
 	}
 
 	// 使用全局配置
 	pageLineMap := buildPageLineMap(config, 1)
 	if filter, ok := pageLineMap[0]; ok {
-		return filterLinesForPage(lines, filter)
+		return filter
+	}
+	return pageLineFilter{readAll: true}
+}
+
+// newPageContent 根据过滤器筛选行并构建页面内容
+// 如果配置了 PreserveLineNumbers，同时记录每一行的原始行号
+func newPageContent(pageNumber int, lines []string, filter pageLineFilter, config *ReadConfig) PageContent {
+	filteredLines, lineNumbers := filterLinesWithIndexes(lines, filter)
+
+	page := PageContent{
+		PageNumber: pageNumber,
+		Lines:      filteredLines,
+		TotalLines: len(filteredLines),
+	}
+	if config != nil && config.PreserveLineNumbers {
+		if lineNumbers == nil {
+			lineNumbers = makeAllPagesSlice(len(filteredLines))
+		}
+		page.LineNumbers = lineNumbers
+	}
+	page.tidyLines(config)
+
+	return page
+}
+
+// tidyLines 按配置对已选中的行进行轻量清理：TrimLines 去除首尾空白，DropEmptyLines 移除空行，
+// DropConsecutiveDuplicateLines 移除与上一行完全相同的行（按此顺序执行）
+// 行选择在此之前完成，因此选择器使用的始终是原始行索引；LineNumbers 与清理后的行保持对应
+func (p *PageContent) tidyLines(config *ReadConfig) {
+	if config == nil {
+		return
+	}
+	if config.TrimLines {
+		for i, line := range p.Lines {
+			p.Lines[i] = strings.TrimSpace(line)
+		}
+	}
+	if config.DropEmptyLines {
+		p.filterLines(func(line string) bool {
+			return strings.TrimSpace(line) != ""
+		})
+	}
+	if config.DropConsecutiveDuplicateLines {
+		previous, first := "", true
+		p.filterLines(func(line string) bool {
+			keep := first || line != previous
+			previous, first = line, false
+			return keep
+		})
 	}
-	return lines
 }
 
 // determinePagesToRead 根据配置确定要读取的页码（索引从0开始）
@@ -209,3 +319,341 @@ func makeAllPagesSlice(totalPages int) []int {
 	}
 	return pages
 }
+
+// reportProgress 调用配置中的进度回调，config 或回调为 nil 时不做任何操作
+func reportProgress(config *ReadConfig, current, total int) {
+	if config == nil || config.ProgressFunc == nil {
+		return
+	}
+	config.ProgressFunc(current, total)
+}
+
+// metadataOnly 判断是否只需要元数据和总页数（ReadConfig.MetadataOnly）
+func metadataOnly(config *ReadConfig) bool {
+	return config != nil && config.MetadataOnly
+}
+
+// metadataOnlyResult 构建 ReadConfig.MetadataOnly 的结果：只包含元数据和总页数，Content 为空，Pages 为 nil
+// err 为获取元数据时的错误，以 op 重新包装后返回
+func metadataOnlyResult(op, filePath string, totalPages int, metadata map[string]string, err error) (*DocumentResult, error) {
+	if err != nil {
+		var docErr *DocumentError
+		if errors.As(err, &docErr) {
+			err = docErr.Err
+		}
+		return nil, WrapError(op, filePath, err)
+	}
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	return &DocumentResult{
+		FilePath:   filePath,
+		TotalPages: totalPages,
+		Metadata:   metadata,
+	}, nil
+}
+
+// textStreamer 支持将文本内容逐步写入 io.Writer 的读取器
+// ReadText 的输出与 writeText 写入的内容完全一致
+type textStreamer interface {
+	writeText(w io.Writer, filePath string) error
+}
+
+// textWriter 包装 io.Writer，记录写入的字节数和第一次出现的错误
+// 出错后的写入都会被忽略，调用方只需在每页处理完后检查 err 即可提前结束
+type textWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+// newTextWriter 创建 textWriter
+func newTextWriter(w io.Writer) *textWriter {
+	return &textWriter{w: w}
+}
+
+// WriteString 写入字符串，出错后不再写入
+func (tw *textWriter) WriteString(s string) {
+	if tw.err != nil {
+		return
+	}
+	n, err := io.WriteString(tw.w, s)
+	tw.n += int64(n)
+	tw.err = err
+}
+
+// countingWriter 记录成功写入底层 io.Writer 的字节数
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write 实现 io.Writer 接口
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// appProperties 表示 OOXML 文档 docProps/app.xml 中的扩展属性，DOCX/PPTX/XLSX 共用
+type appProperties struct {
+	Application string `xml:"Application"`
+	AppVersion  string `xml:"AppVersion"`
+	Company     string `xml:"Company"`
+	Pages       string `xml:"Pages"`
+	Words       string `xml:"Words"`
+	Characters  string `xml:"Characters"`
+}
+
+// readAppProperties 读取 docProps/app.xml；该部件是可选的，缺失或无法解析时返回空属性，存在但无法读取时返回错误
+func readAppProperties(zipReader *zip.Reader, limits zipLimits) (appProperties, error) {
+	var props appProperties
+
+	data, err := readZipPart(zipReader, "docProps/app.xml", limits)
+	if errors.Is(err, ErrInvalidFormat) {
+		return props, nil
+	}
+	if err != nil {
+		return props, err
+	}
+
+	if err := xml.Unmarshal(data, &props); err != nil {
+		return appProperties{}, nil
+	}
+	return props, nil
+}
+
+// addTo 将非空的扩展属性写入元数据
+// 键名为 application、app_version、company、page_count、word_count、character_count
+func (p appProperties) addTo(metadata map[string]string) {
+	fields := []struct {
+		key, value string
+	}{
+		{"application", p.Application},
+		{"app_version", p.AppVersion},
+		{"company", p.Company},
+		{"page_count", p.Pages},
+		{"word_count", p.Words},
+		{"character_count", p.Characters},
+	}
+	for _, field := range fields {
+		if value := strings.TrimSpace(field.value); value != "" {
+			metadata[field.key] = value
+		}
+	}
+}
+
+// zipLimits 读取 zip 格式文档（DOCX/PPTX/XLSX）时的资源限制
+type zipLimits struct {
+	maxPartSize int64 // 单个部件解压后的最大字节数，小于等于 0 表示不限制
+	maxParts    int   // 最多处理的幻灯片/工作表数量，小于等于 0 表示不限制
+}
+
+// newZipLimits 根据配置生成资源限制，未设置的项使用默认值
+func newZipLimits(config *ReadConfig) zipLimits {
+	limits := zipLimits{
+		maxPartSize: DefaultMaxDecompressedSize,
+		maxParts:    DefaultMaxParts,
+	}
+	if config != nil {
+		if config.MaxDecompressedSize != 0 {
+			limits.maxPartSize = config.MaxDecompressedSize
+		}
+		if config.MaxParts != 0 {
+			limits.maxParts = config.MaxParts
+		}
+	}
+	return limits
+}
+
+// tooManyParts 判断部件数量是否超过限制
+func (l zipLimits) tooManyParts(count int) bool {
+	return l.maxParts > 0 && count > l.maxParts
+}
+
+// readZipPart 读取 zip 包中名为 name 的部件
+// 部件不存在时返回 ErrInvalidFormat，部件存在但无法读取（如数据损坏）时返回 ErrFileRead
+func readZipPart(zipReader *zip.Reader, name string, limits zipLimits) ([]byte, error) {
+	for _, file := range zipReader.File {
+		if file.Name == name {
+			return readZipFile(file, limits)
+		}
+	}
+	return nil, ErrInvalidFormat
+}
+
+// readZipFile 读取 zip 包中单个文件的全部内容，失败时返回 ErrFileRead
+// 声明的或实际解压后的大小超过 limits.maxPartSize 时返回 ErrFileTooLarge
+func readZipFile(file *zip.File, limits zipLimits) ([]byte, error) {
+	if limits.maxPartSize > 0 && file.UncompressedSize64 > uint64(limits.maxPartSize) {
+		return nil, ErrFileTooLarge
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, ErrFileRead
+	}
+	defer rc.Close()
+
+	// 不信任头部声明的大小，读取时再限制一次
+	var source io.Reader = rc
+	if limits.maxPartSize > 0 {
+		source = io.LimitReader(rc, limits.maxPartSize+1)
+	}
+
+	data, err := io.ReadAll(source)
+	if err != nil {
+		return nil, ErrFileRead
+	}
+	if limits.maxPartSize > 0 && int64(len(data)) > limits.maxPartSize {
+		return nil, ErrFileTooLarge
+	}
+	return data, nil
+}
+
+// zipBudget 读取压缩包中多个文件时解压后的总大小预算，总预算为 limits.maxPartSize，小于等于 0 表示不限制
+// 用于防止每个文件都不超过单个文件的限制、但合计解压后非常大的压缩包
+type zipBudget struct {
+	limits    zipLimits
+	remaining int64
+}
+
+// newZipBudget 创建总大小预算
+func newZipBudget(limits zipLimits) *zipBudget {
+	return &zipBudget{limits: limits, remaining: limits.maxPartSize}
+}
+
+// read 读取文件并从预算中减去解压后的大小，声明的或实际解压后的大小超过剩余预算时返回 ErrFileTooLarge
+func (b *zipBudget) read(file *zip.File) ([]byte, error) {
+	limits := b.limits
+	if limits.maxPartSize > 0 {
+		if file.UncompressedSize64 > uint64(b.remaining) {
+			return nil, ErrFileTooLarge
+		}
+		// maxPartSize 为 0 表示不限制，预算用完时只能读取声明大小为 0 的文件，archive/zip 会校验实际大小
+		limits.maxPartSize = max(b.remaining, 1)
+	}
+
+	data, err := readZipFile(file, limits)
+	if err != nil {
+		return nil, err
+	}
+	if b.limits.maxPartSize > 0 {
+		b.remaining -= int64(len(data))
+	}
+	return data, nil
+}
+
+// checkZipLimits 在交给第三方库解析前检查 zip 包：任何部件声明的解压大小超过限制，
+// 或匹配 isPart 的部件数量超过限制时返回 ErrFileTooLarge
+// archive/zip 在读取时会校验实际解压大小不超过声明值，因此检查声明值即可防止解压炸弹
+func checkZipLimits(zipReader *zip.Reader, limits zipLimits, isPart func(name string) bool) error {
+	parts := 0
+	for _, file := range zipReader.File {
+		if limits.maxPartSize > 0 && file.UncompressedSize64 > uint64(limits.maxPartSize) {
+			return ErrFileTooLarge
+		}
+		if isPart(file.Name) {
+			parts++
+		}
+	}
+	if limits.tooManyParts(parts) {
+		return ErrFileTooLarge
+	}
+	return nil
+}
+
+// splitParagraphs 将连续的非空行以换行符合并为段落，只含空白的行作为分隔被丢弃
+func splitParagraphs(lines []string) []string {
+	paragraphs := make([]string, 0)
+	var current []string
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, strings.Join(current, "\n"))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, strings.Join(current, "\n"))
+	}
+
+	return paragraphs
+}
+
+// utf8BOM UTF-8 字节顺序标记
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// trimBOM 去掉数据开头的 UTF-8 BOM
+func trimBOM(data []byte) []byte {
+	return bytes.TrimPrefix(data, utf8BOM)
+}
+
+// newBOMSkippingReader 返回跳过开头 UTF-8 BOM 的缓冲读取器
+func newBOMSkippingReader(source io.Reader) *bufio.Reader {
+	reader := bufio.NewReader(source)
+	if prefix, err := reader.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+		reader.Discard(len(utf8BOM))
+	}
+	return reader
+}
+
+// streamFileLines 按行将纯文本文件的原始内容写入 w，避免一次性读入整个文件
+// 开头的 UTF-8 BOM 会被去掉；op 用于错误信息中的操作名称
+func (s *fileSource) streamFileLines(w io.Writer, filePath, op string) error {
+	file, err := s.open(filePath)
+	if err != nil {
+		return WrapError(op, filePath, ErrFileRead)
+	}
+	defer file.Close()
+
+	tw := newTextWriter(w)
+	reader := newBOMSkippingReader(file)
+	for {
+		line, err := reader.ReadString('\n')
+		tw.WriteString(line)
+		if tw.err != nil {
+			return tw.err
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return WrapError(op, filePath, ErrFileRead)
+		}
+	}
+}
+
+// errLimitReached 表示已写满指定的字符数，用于提前结束文本提取
+var errLimitReached = errors.New("rune limit reached")
+
+// runeLimitWriter 最多保存 remaining 个字符，写满后返回 errLimitReached
+type runeLimitWriter struct {
+	builder   strings.Builder
+	remaining int
+}
+
+// Write 实现 io.Writer 接口，超出部分按字符边界截断
+func (w *runeLimitWriter) Write(p []byte) (int, error) {
+	if w.remaining <= 0 {
+		return 0, errLimitReached
+	}
+
+	if count := utf8.RuneCount(p); count <= w.remaining {
+		w.builder.Write(p)
+		w.remaining -= count
+		return len(p), nil
+	}
+
+	n := 0
+	for ; w.remaining > 0; w.remaining-- {
+		_, size := utf8.DecodeRune(p[n:])
+		n += size
+	}
+	w.builder.Write(p[:n])
+	return n, errLimitReached
+}
@@ -0,0 +1,183 @@
+package docreader
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// imageFormats 配置了 OCR 引擎后 ImageReader 支持的图片格式
+// HEIC 没有标准库解码器，只交给 OCR 引擎识别，元数据中没有尺寸
+var imageFormats = []string{".png", ".jpg", ".jpeg", ".gif", ".heic"}
+
+// OCREngine 文字识别引擎，由调用方注入（如封装 Tesseract 或云端 OCR 服务），本包不提供实现
+type OCREngine interface {
+	// Recognize 识别图片中的文字，data 为图片文件的完整内容，format 为图片格式（如 "png"、"jpeg"、"heic"）
+	Recognize(data []byte, format string) (string, error)
+}
+
+var (
+	ocrEngineMu sync.RWMutex
+	ocrEngine   OCREngine
+)
+
+// SetOCREngine 设置包级 OCR 引擎，传入 nil 时清除
+// 设置后 ReadDocument 等函数才会识别图片扩展名（.png、.jpg、.jpeg、.gif、.heic）并使用 ImageReader 读取，
+// 未设置时图片仍然是不支持的格式，因此不使用 OCR 的程序不需要任何额外依赖
+func SetOCREngine(engine OCREngine) {
+	ocrEngineMu.Lock()
+	defer ocrEngineMu.Unlock()
+	ocrEngine = engine
+}
+
+// currentOCREngine 返回包级 OCR 引擎，未设置时返回 nil
+func currentOCREngine() OCREngine {
+	ocrEngineMu.RLock()
+	defer ocrEngineMu.RUnlock()
+	return ocrEngine
+}
+
+// isImageFormat 判断扩展名（已归一化）是否为图片格式
+func isImageFormat(ext string) bool {
+	return slices.Contains(imageFormats, ext)
+}
+
+// ImageReader 通过 OCR 引擎读取图片中的文字
+type ImageReader struct {
+	// Engine 使用的 OCR 引擎，为 nil 时使用 SetOCREngine 设置的包级引擎
+	Engine OCREngine
+
+	fileSource
+}
+
+// ReadText 识别图片中的文字
+func (r *ImageReader) ReadText(filePath string) (string, error) {
+	return r.recognize(filePath, "ImageReader.ReadText")
+}
+
+// writeText 将识别出的文字写入 w
+func (r *ImageReader) writeText(w io.Writer, filePath string) error {
+	text, err := r.recognize(filePath, "ImageReader.ReadText")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, text)
+	return err
+}
+
+// recognize 读取图片并交给 OCR 引擎识别，没有可用的引擎时返回 ErrInvalidConfig
+func (r *ImageReader) recognize(filePath, op string) (string, error) {
+	engine := r.Engine
+	if engine == nil {
+		engine = currentOCREngine()
+	}
+	if engine == nil {
+		return "", WrapError(op, filePath, ErrInvalidConfig)
+	}
+
+	data, err := r.readFile(filePath)
+	if err != nil {
+		return "", WrapError(op, filePath, ErrFileRead)
+	}
+
+	text, err := engine.Recognize(data, imageFormat(filePath, data))
+	if err != nil {
+		return "", WrapError(op, filePath, fmt.Errorf("%w: ocr: %w", ErrFileParse, err))
+	}
+	return text, nil
+}
+
+// imageFormat 返回图片格式：能解码时使用解码器识别的格式，否则使用扩展名（去掉前导点，.jpg 视为 jpeg）
+func imageFormat(filePath string, data []byte) string {
+	if _, format, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		return format
+	}
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(filePath)), ".")
+	if format == "jpg" {
+		format = "jpeg"
+	}
+	return format
+}
+
+// GetMetadata 获取图片的元数据：格式（format）、宽高（width、height，单位为像素）及文件信息
+// 无法解码的图片（如 HEIC）只有根据扩展名得到的格式，没有宽高
+func (r *ImageReader) GetMetadata(filePath string) (map[string]string, error) {
+	fileInfo, err := r.stat(filePath)
+	if err != nil {
+		return nil, WrapError("ImageReader.GetMetadata", filePath, ErrFileNotFound)
+	}
+
+	file, err := r.open(filePath)
+	if err != nil {
+		return nil, WrapError("ImageReader.GetMetadata", filePath, ErrFileOpen)
+	}
+	defer file.Close()
+
+	metadata := make(map[string]string)
+	metadata["size"] = fmt.Sprintf("%d", fileInfo.Size())
+	metadata["modified"] = fileInfo.ModTime().String()
+
+	// DecodeConfig 只读取图片头部
+	if config, format, err := image.DecodeConfig(file); err == nil {
+		metadata["format"] = format
+		metadata["width"] = fmt.Sprintf("%d", config.Width)
+		metadata["height"] = fmt.Sprintf("%d", config.Height)
+	} else {
+		metadata["format"] = imageFormat(filePath, nil)
+	}
+
+	// 单张图片只有一个部分
+	metadata["section_count"] = "1"
+
+	return metadata, nil
+}
+
+// Capabilities 返回图片读取器支持的功能：单页识别文本，没有额外的结构
+func (r *ImageReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{}
+}
+
+// ReadWithConfig 根据配置识别图片中的文字，返回结构化结果
+func (r *ImageReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
+	if metadataOnly(config) {
+		metadata, err := r.GetMetadata(filePath)
+		return metadataOnlyResult("ImageReader.ReadWithConfig", filePath, 1, metadata, err)
+	}
+
+	text, err := r.recognize(filePath, "ImageReader.ReadWithConfig")
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(text, "\n")
+
+	result := &DocumentResult{
+		FilePath:   filePath,
+		TotalPages: 1,
+		Pages:      make([]PageContent, 0),
+		Metadata:   make(map[string]string),
+	}
+
+	// 获取元数据
+	metadata, _ := r.GetMetadata(filePath)
+	result.Metadata = metadata
+
+	// 根据配置筛选行
+	pageContent := newPageContent(0, lines, singlePageFilter(config), config)
+
+	result.Pages = append(result.Pages, pageContent)
+	result.TotalLines = pageContent.TotalLines
+	result.finish(config)
+
+	reportProgress(config, 1, 1)
+
+	return result, nil
+}
@@ -0,0 +1,197 @@
+package docreader
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// iWork（Pages、Numbers、Keynote）文档的正文保存在 IWA（Snappy 压缩的 protobuf）中，本包不解析这种格式。
+// 导出时勾选了“包含预览”的文档会在包中附带预览 PDF，下面的读取器通过 PdfReader 提取预览 PDF 的文本，
+// 作为尽力而为的后备方案：没有预览 PDF 的文档（如只有 preview.jpg 的文档或目录形式的包）返回 ErrInvalidFormat，
+// 预览只包含文档的一部分（如 Numbers 只预览第一个工作表）时结果也只有这一部分
+
+// PagesReader 用于读取 Apple Pages 文档（.pages），提取包中预览 PDF 的文本
+type PagesReader struct {
+	fileSource
+}
+
+// NumbersReader 用于读取 Apple Numbers 表格（.numbers），提取包中预览 PDF 的文本
+type NumbersReader struct {
+	fileSource
+}
+
+// KeynoteReader 用于读取 Apple Keynote 演示文稿（.key），提取包中预览 PDF 的文本
+type KeynoteReader struct {
+	fileSource
+}
+
+// ReadText 读取 Pages 文档预览 PDF 的文本内容
+func (r *PagesReader) ReadText(filePath string) (string, error) {
+	return r.readIworkText(filePath, "PagesReader.ReadText")
+}
+
+// writeText 将 Pages 文档预览 PDF 的文本写入 w
+func (r *PagesReader) writeText(w io.Writer, filePath string) error {
+	return r.writeIworkText(w, filePath, "PagesReader.ReadText")
+}
+
+// GetMetadata 获取 Pages 文档的元数据
+func (r *PagesReader) GetMetadata(filePath string) (map[string]string, error) {
+	return r.iworkMetadata(filePath, "PagesReader.GetMetadata")
+}
+
+// Capabilities 返回 Pages 读取器支持的功能，与 PDF 相同
+func (r *PagesReader) Capabilities() ReaderCapabilities {
+	return (&PdfReader{}).Capabilities()
+}
+
+// ReadWithConfig 根据配置读取 Pages 文档的预览 PDF，返回结构化结果
+func (r *PagesReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
+	return r.readIworkWithConfig(filePath, config, "PagesReader.ReadWithConfig")
+}
+
+// ReadText 读取 Numbers 表格预览 PDF 的文本内容
+func (r *NumbersReader) ReadText(filePath string) (string, error) {
+	return r.readIworkText(filePath, "NumbersReader.ReadText")
+}
+
+// writeText 将 Numbers 表格预览 PDF 的文本写入 w
+func (r *NumbersReader) writeText(w io.Writer, filePath string) error {
+	return r.writeIworkText(w, filePath, "NumbersReader.ReadText")
+}
+
+// GetMetadata 获取 Numbers 表格的元数据
+func (r *NumbersReader) GetMetadata(filePath string) (map[string]string, error) {
+	return r.iworkMetadata(filePath, "NumbersReader.GetMetadata")
+}
+
+// Capabilities 返回 Numbers 读取器支持的功能，与 PDF 相同（预览 PDF 中没有表格结构）
+func (r *NumbersReader) Capabilities() ReaderCapabilities {
+	return (&PdfReader{}).Capabilities()
+}
+
+// ReadWithConfig 根据配置读取 Numbers 表格的预览 PDF，返回结构化结果
+func (r *NumbersReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
+	return r.readIworkWithConfig(filePath, config, "NumbersReader.ReadWithConfig")
+}
+
+// ReadText 读取 Keynote 演示文稿预览 PDF 的文本内容
+func (r *KeynoteReader) ReadText(filePath string) (string, error) {
+	return r.readIworkText(filePath, "KeynoteReader.ReadText")
+}
+
+// writeText 将 Keynote 演示文稿预览 PDF 的文本写入 w
+func (r *KeynoteReader) writeText(w io.Writer, filePath string) error {
+	return r.writeIworkText(w, filePath, "KeynoteReader.ReadText")
+}
+
+// GetMetadata 获取 Keynote 演示文稿的元数据
+func (r *KeynoteReader) GetMetadata(filePath string) (map[string]string, error) {
+	return r.iworkMetadata(filePath, "KeynoteReader.GetMetadata")
+}
+
+// Capabilities 返回 Keynote 读取器支持的功能，与 PDF 相同（预览 PDF 的每一页对应一张幻灯片）
+func (r *KeynoteReader) Capabilities() ReaderCapabilities {
+	return (&PdfReader{}).Capabilities()
+}
+
+// ReadWithConfig 根据配置读取 Keynote 演示文稿的预览 PDF，返回结构化结果
+func (r *KeynoteReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
+	return r.readIworkWithConfig(filePath, config, "KeynoteReader.ReadWithConfig")
+}
+
+// readIworkText 读取 iWork 包中预览 PDF 的文本
+func (s *fileSource) readIworkText(filePath, op string) (string, error) {
+	var builder strings.Builder
+	if err := s.writeIworkText(&builder, filePath, op); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// writeIworkText 将 iWork 包中预览 PDF 的文本写入 w
+func (s *fileSource) writeIworkText(w io.Writer, filePath, op string) error {
+	return s.withIworkPreview(filePath, op, newZipLimits(nil), func(preview fileSource, previewName string) error {
+		return (&PdfReader{fileSource: preview}).writeText(w, previewName)
+	})
+}
+
+// iworkMetadata 获取预览 PDF 的元数据，文件大小和修改时间为 iWork 文件本身的信息
+func (s *fileSource) iworkMetadata(filePath, op string) (map[string]string, error) {
+	var metadata map[string]string
+	err := s.withIworkPreview(filePath, op, newZipLimits(nil), func(preview fileSource, previewName string) error {
+		var err error
+		metadata, err = (&PdfReader{fileSource: preview}).GetMetadata(previewName)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.addIworkFileInfo(metadata, filePath)
+	return metadata, nil
+}
+
+// readIworkWithConfig 根据配置读取 iWork 包中的预览 PDF
+func (s *fileSource) readIworkWithConfig(filePath string, config *ReadConfig, op string) (*DocumentResult, error) {
+	var result *DocumentResult
+	err := s.withIworkPreview(filePath, op, newZipLimits(config), func(preview fileSource, previewName string) error {
+		var err error
+		result, err = (&PdfReader{fileSource: preview}).ReadWithConfig(previewName, config)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.FilePath = filePath
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]string)
+	}
+	s.addIworkFileInfo(result.Metadata, filePath)
+	return result, nil
+}
+
+// addIworkFileInfo 用 iWork 文件本身的大小和修改时间替换预览 PDF 的信息
+func (s *fileSource) addIworkFileInfo(metadata map[string]string, filePath string) {
+	if fileInfo, err := s.stat(filePath); err == nil {
+		metadata["size"] = fmt.Sprintf("%d", fileInfo.Size())
+		metadata["modified"] = fileInfo.ModTime().String()
+	}
+}
+
+// isIworkPreview 判断 zip 条目是否为 iWork 的预览 PDF（preview.pdf 或旧版本的 QuickLook/Preview.pdf）
+func isIworkPreview(name string) bool {
+	return strings.EqualFold(name, "preview.pdf") || strings.EqualFold(name, "QuickLook/Preview.pdf")
+}
+
+// withIworkPreview 将 iWork 包中的预览 PDF 读入内存并调用 fn，fn 通过 preview 读取名为 previewName 的预览 PDF
+// 预览 PDF 解压后的大小超过 limits 时返回 ErrFileTooLarge；
+// 文件无法作为 zip 打开时返回 ErrFileOpen，没有预览 PDF 时返回 ErrInvalidFormat；
+// fn 返回的错误中的预览 PDF 文件名会被替换为 filePath
+func (s *fileSource) withIworkPreview(filePath, op string, limits zipLimits, fn func(preview fileSource, previewName string) error) error {
+	zipReader, err := s.openZip(filePath)
+	if err != nil {
+		return WrapError(op, filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	var preview *zip.File
+	for _, file := range zipReader.File {
+		if isIworkPreview(file.Name) {
+			preview = file
+			break
+		}
+	}
+	if preview == nil {
+		return WrapError(op, filePath, ErrInvalidFormat)
+	}
+
+	data, err := readZipFile(preview, limits)
+	if err != nil {
+		return WrapError(op, filePath, err)
+	}
+	fsys, previewName := newMemoryFS(preview.Name, data)
+	return replaceErrorPath(fn(fileSource{fsys: fsys}, previewName), previewName, filePath)
+}
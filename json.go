@@ -0,0 +1,314 @@
+package docreader
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// JsonReader 用于读取 .json 和 .jsonl 文件
+// .json 文件按原始键顺序展开为 "路径: 值" 形式的行，.jsonl 文件的每一行作为一条记录
+type JsonReader struct {
+	fileSource
+}
+
+// isJSONLines 判断文件是否为 JSON Lines 格式
+func isJSONLines(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".jsonl"
+}
+
+// ReadText 读取 JSON 文件的文本内容
+func (r *JsonReader) ReadText(filePath string) (string, error) {
+	var builder strings.Builder
+	if err := r.writeText(&builder, filePath); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// writeText 逐行将展开后的 JSON 文本写入 w
+func (r *JsonReader) writeText(w io.Writer, filePath string) error {
+	tw := newTextWriter(w)
+	return r.forEachLine(filePath, "JsonReader.ReadText", func(line string) error {
+		tw.WriteString(line)
+		tw.WriteString("\n")
+		return tw.err
+	})
+}
+
+// forEachLine 按顺序生成文件的文本行，emit 返回错误时停止并返回该错误
+func (r *JsonReader) forEachLine(filePath, op string, emit func(line string) error) error {
+	file, err := r.open(filePath)
+	if err != nil {
+		return WrapError(op, filePath, ErrFileOpen)
+	}
+	defer file.Close()
+
+	if !isJSONLines(filePath) {
+		var emitErr error
+		err := decodeJSONDocument(file, func(path, value string) error {
+			emitErr = emit(formatJSONPair(path, value))
+			return emitErr
+		})
+		if emitErr != nil {
+			return emitErr
+		}
+		if err != nil {
+			return WrapError(op, filePath, ErrInvalidFormat)
+		}
+		return nil
+	}
+
+	// JSON Lines：每个非空行是一条独立的记录
+	reader := bufio.NewReader(file)
+	recordIndex := 0
+	for {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return WrapError(op, filePath, ErrFileRead)
+		}
+
+		if strings.TrimSpace(line) != "" {
+			var pairs []string
+			err := decodeJSONDocument(strings.NewReader(line), func(path, value string) error {
+				pairs = append(pairs, formatJSONPair(path, value))
+				return nil
+			})
+			if err != nil {
+				return WrapError(op, filePath, ErrInvalidFormat)
+			}
+
+			recordIndex++
+			if err := emit(fmt.Sprintf("Record %d: %s", recordIndex, strings.Join(pairs, " | "))); err != nil {
+				return err
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+// formatJSONPair 将路径和值格式化为一行文本，顶层标量只输出值
+func formatJSONPair(path, value string) string {
+	if path == "" {
+		return value
+	}
+	return path + ": " + value
+}
+
+// decodeJSONDocument 解析一个完整的 JSON 值，按出现顺序对每个叶子节点调用 emit
+// 对象路径使用 "." 连接键名，数组使用 "[索引]"；空对象和空数组作为叶子输出
+func decodeJSONDocument(source io.Reader, emit func(path, value string) error) error {
+	decoder := json.NewDecoder(source)
+	decoder.UseNumber()
+
+	if err := flattenJSONValue(decoder, "", emit); err != nil {
+		return err
+	}
+
+	// 一个文档只能包含一个 JSON 值
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("unexpected data after top-level value")
+	}
+	return nil
+}
+
+// flattenJSONValue 递归展开 decoder 中的下一个 JSON 值
+func flattenJSONValue(decoder *json.Decoder, path string, emit func(path, value string) error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		count := 0
+		for decoder.More() {
+			childPath := fmt.Sprintf("%s[%d]", path, count)
+			if value == '{' {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				childPath = keyToken.(string)
+				if path != "" {
+					childPath = path + "." + childPath
+				}
+			}
+
+			if err := flattenJSONValue(decoder, childPath, emit); err != nil {
+				return err
+			}
+			count++
+		}
+
+		// 读取结束符
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+
+		if count == 0 {
+			if value == '{' {
+				return emit(path, "{}")
+			}
+			return emit(path, "[]")
+		}
+		return nil
+	case string:
+		return emit(path, value)
+	case json.Number:
+		return emit(path, value.String())
+	case bool:
+		return emit(path, strconv.FormatBool(value))
+	default:
+		return emit(path, "null")
+	}
+}
+
+// GetMetadata 获取 JSON 文件的元数据
+// .json 文件包含顶层类型（type）以及键数量（keys）或元素数量（elements），.jsonl 文件包含记录数（records）
+func (r *JsonReader) GetMetadata(filePath string) (map[string]string, error) {
+	metadata := make(map[string]string)
+
+	if isJSONLines(filePath) {
+		records := 0
+		err := r.forEachLine(filePath, "JsonReader.GetMetadata", func(string) error {
+			records++
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		metadata["records"] = fmt.Sprintf("%d", records)
+	} else {
+		file, err := r.open(filePath)
+		if err != nil {
+			return nil, WrapError("JsonReader.GetMetadata", filePath, ErrFileOpen)
+		}
+		defer file.Close()
+
+		if err := jsonTopLevelMetadata(file, metadata); err != nil {
+			return nil, WrapError("JsonReader.GetMetadata", filePath, ErrInvalidFormat)
+		}
+	}
+
+	// 获取文件信息
+	fileInfo, err := r.stat(filePath)
+	if err == nil {
+		metadata["size"] = fmt.Sprintf("%d", fileInfo.Size())
+		metadata["modified"] = fileInfo.ModTime().String()
+	}
+
+	// 单页格式只有一个部分
+	metadata["section_count"] = "1"
+
+	return metadata, nil
+}
+
+// jsonTopLevelMetadata 读取顶层 JSON 值的类型和成员数量
+func jsonTopLevelMetadata(source io.Reader, metadata map[string]string) error {
+	decoder := json.NewDecoder(source)
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		count := 0
+		for decoder.More() {
+			if value == '{' {
+				if _, err := decoder.Token(); err != nil {
+					return err
+				}
+			}
+			var member json.RawMessage
+			if err := decoder.Decode(&member); err != nil {
+				return err
+			}
+			count++
+		}
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+
+		if value == '{' {
+			metadata["type"] = "object"
+			metadata["keys"] = fmt.Sprintf("%d", count)
+		} else {
+			metadata["type"] = "array"
+			metadata["elements"] = fmt.Sprintf("%d", count)
+		}
+	case string:
+		metadata["type"] = "string"
+	case json.Number:
+		metadata["type"] = "number"
+	case bool:
+		metadata["type"] = "boolean"
+	default:
+		metadata["type"] = "null"
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("unexpected data after top-level value")
+	}
+	return nil
+}
+
+// Capabilities 返回 JSON 读取器支持的功能：单页，.jsonl 文件的每一行是一条记录
+func (r *JsonReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{
+		Records: true,
+	}
+}
+
+// ReadWithConfig 根据配置读取 JSON 文件，返回结构化结果
+// 整个文件作为单页处理，.json 的每个叶子节点或 .jsonl 的每条记录为一行
+func (r *JsonReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
+	if metadataOnly(config) {
+		metadata, err := r.GetMetadata(filePath)
+		return metadataOnlyResult("JsonReader.ReadWithConfig", filePath, 1, metadata, err)
+	}
+
+	lines := make([]string, 0)
+	err := r.forEachLine(filePath, "JsonReader.ReadWithConfig", func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DocumentResult{
+		FilePath:   filePath,
+		TotalPages: 1,
+		Pages:      make([]PageContent, 0),
+		Metadata:   make(map[string]string),
+	}
+
+	// 获取元数据
+	metadata, _ := r.GetMetadata(filePath)
+	if metadata != nil {
+		result.Metadata = metadata
+	}
+
+	// 根据配置筛选行
+	pageContent := newPageContent(0, lines, singlePageFilter(config), config)
+
+	result.Pages = append(result.Pages, pageContent)
+	result.TotalLines = pageContent.TotalLines
+	result.finish(config)
+
+	reportProgress(config, 1, 1)
+
+	return result, nil
+}
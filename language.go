@@ -0,0 +1,129 @@
+package docreader
+
+import (
+	"strings"
+	"unicode"
+)
+
+// latinStopwords 常见拉丁字母语言的高频词，用于区分英语和其他拉丁语系语言
+var latinStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "it", "with", "for", "this", "are", "was", "be", "on"},
+	"fr": {"le", "la", "les", "et", "des", "est", "une", "un", "du", "que", "dans", "pour", "pas", "sur", "avec"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "zu", "den", "mit", "von", "sich", "auf", "ich"},
+	"es": {"el", "la", "los", "las", "y", "es", "que", "de", "en", "por", "con", "para", "una", "del", "se"},
+	"pt": {"o", "os", "as", "e", "é", "que", "de", "em", "um", "uma", "para", "com", "não", "do", "da"},
+	"it": {"il", "lo", "gli", "e", "è", "che", "di", "per", "una", "non", "con", "sono", "del", "della", "nel"},
+	"nl": {"de", "het", "een", "en", "is", "van", "niet", "dat", "op", "te", "met", "zijn", "voor", "ik", "ook"},
+}
+
+// DetectLanguage 检测文档内容的主要语言，返回 ISO 639-1 语言代码
+// 首先按 Unicode 文字区间判断书写系统（中文/日文/韩文/西里尔文等），
+// 对拉丁字母文本再根据常见高频词区分英语和其他语言。无法识别时返回 ErrUnknownLanguage。
+func (d *Document) DetectLanguage() (string, error) {
+	language := detectLanguage(d.Content)
+	if language == "" {
+		return "", WrapError("Document.DetectLanguage", d.FilePath, ErrUnknownLanguage)
+	}
+	return language, nil
+}
+
+// detectLanguage 根据文字区间和高频词检测文本语言，无法识别时返回空字符串
+func detectLanguage(text string) string {
+	var han, kana, hangul, latin, cyrillic, arabic, hebrew, greek, thai, devanagari int
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Arabic, r):
+			arabic++
+		case unicode.Is(unicode.Hebrew, r):
+			hebrew++
+		case unicode.Is(unicode.Greek, r):
+			greek++
+		case unicode.Is(unicode.Thai, r):
+			thai++
+		case unicode.Is(unicode.Devanagari, r):
+			devanagari++
+		}
+	}
+
+	// 中日韩文字作为一个整体参与比较，避免汉字和假名被拆开计数
+	cjk := han + kana + hangul
+	scripts := []struct {
+		language string
+		count    int
+	}{
+		{"", cjk},
+		{"", latin},
+		{"ru", cyrillic},
+		{"ar", arabic},
+		{"he", hebrew},
+		{"el", greek},
+		{"th", thai},
+		{"hi", devanagari},
+	}
+
+	best := 0
+	for i := range scripts {
+		if scripts[i].count > scripts[best].count {
+			best = i
+		}
+	}
+	if scripts[best].count == 0 {
+		return ""
+	}
+
+	switch best {
+	case 0:
+		return detectCJKLanguage(han, kana, hangul)
+	case 1:
+		return detectLatinLanguage(text)
+	default:
+		return scripts[best].language
+	}
+}
+
+// detectCJKLanguage 根据汉字、假名和谚文的数量区分中文、日文和韩文
+func detectCJKLanguage(han, kana, hangul int) string {
+	if hangul > han+kana {
+		return "ko"
+	}
+	// 日文中假名占比通常较高，中文文本中几乎不出现假名
+	if kana > 0 && kana*10 >= han+kana {
+		return "ja"
+	}
+	return "zh"
+}
+
+// detectLatinLanguage 根据高频词出现次数区分拉丁字母语言
+func detectLatinLanguage(text string) string {
+	counts := make(map[string]int)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		counts[word]++
+	}
+
+	bestLanguage, bestScore := "", 0
+	// 按固定顺序遍历，保证得分相同时结果稳定
+	for _, language := range []string{"en", "fr", "de", "es", "pt", "it", "nl"} {
+		score := 0
+		for _, word := range latinStopwords[language] {
+			score += counts[word]
+		}
+		if score > bestScore {
+			bestLanguage, bestScore = language, score
+		}
+	}
+
+	return bestLanguage
+}
@@ -0,0 +1,42 @@
+package docreader
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"中文", "这是一个用于测试的中文文档，包含一些 English 单词。", "zh"},
+		{"日文", "これは日本語のテスト文書です。漢字も含まれています。", "ja"},
+		{"韩文", "이것은 한국어 테스트 문서입니다.", "ko"},
+		{"俄文", "Это тестовый документ на русском языке.", "ru"},
+		{"英文", "This is the test document and it is written in English.", "en"},
+		{"法文", "Le document est une copie et les pages sont dans la boîte.", "fr"},
+		{"德文", "Das ist nicht der Text, und die Seite ist ein Test.", "de"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &Document{Content: tt.content}
+			language, err := doc.DetectLanguage()
+			if err != nil {
+				t.Fatalf("检测失败: %v", err)
+			}
+			if language != tt.expected {
+				t.Errorf("期望 %s，实际 %s", tt.expected, language)
+			}
+		})
+	}
+
+	t.Run("无法识别", func(t *testing.T) {
+		doc := &Document{Content: "12345 !!!"}
+		if _, err := doc.DetectLanguage(); !errors.Is(err, ErrUnknownLanguage) {
+			t.Errorf("期望 ErrUnknownLanguage，实际: %v", err)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package docreader
+
+import "sync"
+
+// LogLevelWarn 读取器跳过无法读取的页/幻灯片/工作表等可恢复的问题时使用的日志级别
+const LogLevelWarn = "warn"
+
+// LogFunc 日志钩子，level 为日志级别（如 LogLevelWarn），msg 为简短的英文描述，
+// kv 为交替出现的键和值，例如 "op", "PdfReader.ReadText", "file", path, "page", 3, "error", err
+type LogFunc func(level, msg string, kv ...any)
+
+var (
+	loggerMu sync.RWMutex
+	logger   LogFunc
+)
+
+// SetLogger 设置包级日志钩子，传入 nil 恢复默认的空操作
+// 读取器在因错误跳过某一页、幻灯片或工作表并继续读取时调用该钩子，便于发现部分提取失败的问题。
+// 钩子可能在多个 goroutine 中被同时调用，需要自行保证并发安全
+func SetLogger(fn LogFunc) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	logger = fn
+}
+
+// logWarn 通过日志钩子记录一条警告，未设置钩子时不做任何事
+func logWarn(msg string, kv ...any) {
+	loggerMu.RLock()
+	fn := logger
+	loggerMu.RUnlock()
+
+	if fn != nil {
+		fn(LogLevelWarn, msg, kv...)
+	}
+}
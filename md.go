@@ -2,22 +2,27 @@ package docreader
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"strings"
 )
 
 // MdReader 用于读取 .md 文件
-type MdReader struct{}
+type MdReader struct {
+	fileSource
+}
 
 // ReadText 读取 Markdown 文件的文本内容
 func (r *MdReader) ReadText(filePath string) (string, error) {
-	// 读取文件内容
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return "", WrapError("MdReader.ReadText", filePath, ErrFileRead)
+	var builder strings.Builder
+	if err := r.writeText(&builder, filePath); err != nil {
+		return "", err
 	}
+	return builder.String(), nil
+}
 
-	return string(data), nil
+// writeText 按行将文件内容写入 w
+func (r *MdReader) writeText(w io.Writer, filePath string) error {
+	return r.streamFileLines(w, filePath, "MdReader.ReadText")
 }
 
 // GetMetadata 获取 Markdown 文件的元数据
@@ -25,7 +30,7 @@ func (r *MdReader) GetMetadata(filePath string) (map[string]string, error) {
 	metadata := make(map[string]string)
 
 	// 获取文件信息
-	fileInfo, err := os.Stat(filePath)
+	fileInfo, err := r.stat(filePath)
 	if err != nil {
 		return nil, WrapError("MdReader.GetMetadata", filePath, ErrFileNotFound)
 	}
@@ -33,18 +38,269 @@ func (r *MdReader) GetMetadata(filePath string) (map[string]string, error) {
 	metadata["size"] = fmt.Sprintf("%d", fileInfo.Size())
 	metadata["modified"] = fileInfo.ModTime().String()
 
+	// 单页格式只有一个部分
+	metadata["section_count"] = "1"
+
 	return metadata, nil
 }
 
+// Capabilities 返回 Markdown 读取器支持的功能：单页文本，可以提取管道表格
+func (r *MdReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{Tables: true}
+}
+
+// GetTables 提取 Markdown 文件中的 GFM 管道表格
+// 表格由表头行、分隔行（如 ---|:---:）和随后的数据行组成，遇到空行或不含 "|" 的行时结束。
+// 单元格去除首尾空白，转义的 "\|" 还原为 "|"；数据行的单元格少于表头时补空字符串，多余的忽略。
+// 代码块（``` 或 ~~~ 围栏）中的内容不会被识别为表格
+func (r *MdReader) GetTables(filePath string) ([]Table, error) {
+	data, err := r.readFile(filePath)
+	if err != nil {
+		return nil, WrapError("MdReader.GetTables", filePath, ErrFileRead)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(trimBOM(data)), "\r\n", "\n"), "\n")
+	tables := make([]Table, 0)
+
+	var fence mdFence
+	for i := 0; i < len(lines); i++ {
+		if fence.update(lines[i]) {
+			continue
+		}
+		if i+1 >= len(lines) || !strings.Contains(lines[i], "|") {
+			continue
+		}
+
+		header := splitMdTableRow(lines[i])
+		if !isMdTableDelimiter(lines[i+1], len(header)) {
+			continue
+		}
+
+		table := Table{Header: header, Rows: make([][]string, 0)}
+		i += 2
+		for ; i < len(lines); i++ {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" || !strings.Contains(line, "|") || isMdFenceLine(line) {
+				break
+			}
+			table.Rows = append(table.Rows, fitMdTableRow(splitMdTableRow(line), len(header)))
+		}
+		tables = append(tables, table)
+		// 结束表格的行可能是围栏的开始，需要重新处理
+		i--
+	}
+
+	return tables, nil
+}
+
+// CodeBlock Markdown 中的围栏代码块
+type CodeBlock struct {
+	// Language 信息字符串的第一个单词（如 "go"、"sql"），没有信息字符串时为空
+	Language string
+
+	// Code 代码内容，不包含围栏行，行之间以 "\n" 分隔
+	Code string
+
+	// Line 开始围栏所在的行号（从1开始）
+	Line int
+}
+
+// GetCodeBlocks 提取 Markdown 文件中的围栏代码块（``` 或 ~~~）
+// 代码行会去掉与开始围栏相同的缩进；没有结束围栏的代码块延续到文件末尾。不识别缩进 4 个空格的代码块
+func (r *MdReader) GetCodeBlocks(filePath string) ([]CodeBlock, error) {
+	data, err := r.readFile(filePath)
+	if err != nil {
+		return nil, WrapError("MdReader.GetCodeBlocks", filePath, ErrFileRead)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(trimBOM(data)), "\r\n", "\n"), "\n")
+	blocks := make([]CodeBlock, 0)
+
+	var fence mdFence
+	var current *CodeBlock
+	var code []string
+	indent := 0
+	for i, line := range lines {
+		opening := fence.marker == ""
+		if !fence.update(line) {
+			continue
+		}
+
+		if opening {
+			_, info, _ := parseMdFence(line)
+			language := ""
+			if fields := strings.Fields(info); len(fields) > 0 {
+				language = fields[0]
+			}
+			current = &CodeBlock{Language: language, Line: i + 1}
+			code = code[:0]
+			indent = len(line) - len(strings.TrimLeft(line, " "))
+			continue
+		}
+
+		if fence.marker == "" {
+			// 结束围栏
+			current.Code = strings.Join(code, "\n")
+			blocks = append(blocks, *current)
+			current = nil
+			continue
+		}
+		code = append(code, trimMdIndent(line, indent))
+	}
+
+	// 未闭合的代码块延续到文件末尾，去掉文件末尾换行产生的空行
+	if current != nil {
+		if len(code) > 0 && code[len(code)-1] == "" {
+			code = code[:len(code)-1]
+		}
+		current.Code = strings.Join(code, "\n")
+		blocks = append(blocks, *current)
+	}
+
+	return blocks, nil
+}
+
+// trimMdIndent 去掉行首最多 indent 个空格
+func trimMdIndent(line string, indent int) string {
+	for i := 0; i < indent && strings.HasPrefix(line, " "); i++ {
+		line = line[1:]
+	}
+	return line
+}
+
+// mdFence 记录当前是否处于围栏代码块中
+type mdFence struct {
+	marker string
+}
+
+// update 处理一行，返回该行是否属于代码块（包括开始和结束围栏）
+func (f *mdFence) update(line string) bool {
+	marker, _, ok := parseMdFence(line)
+	if f.marker == "" {
+		if ok {
+			f.marker = marker
+			return true
+		}
+		return false
+	}
+
+	// 结束围栏使用相同字符，长度不小于开始围栏，且不带信息字符串
+	if ok && marker[0] == f.marker[0] && len(marker) >= len(f.marker) {
+		if _, info, _ := parseMdFence(line); info == "" {
+			f.marker = ""
+		}
+	}
+	return true
+}
+
+// parseMdFence 解析围栏行，返回围栏标记（如 "```"）和信息字符串
+// 围栏最多缩进 3 个空格，由至少 3 个 ` 或 ~ 组成；` 围栏的信息字符串不能包含 `
+func parseMdFence(line string) (marker, info string, ok bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || trimmed == "" {
+		return "", "", false
+	}
+
+	char := trimmed[0]
+	if char != '`' && char != '~' {
+		return "", "", false
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == char {
+		n++
+	}
+	if n < 3 {
+		return "", "", false
+	}
+
+	info = strings.TrimSpace(trimmed[n:])
+	if char == '`' && strings.Contains(info, "`") {
+		return "", "", false
+	}
+	return trimmed[:n], info, true
+}
+
+// isMdFenceLine 判断一行是否为围栏行
+func isMdFenceLine(line string) bool {
+	_, _, ok := parseMdFence(line)
+	return ok
+}
+
+// splitMdTableRow 将表格行拆分为单元格，去掉可选的首尾 "|"，并还原转义的 "\|"
+func splitMdTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = line[:len(line)-1]
+	}
+
+	cells := make([]string, 0)
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// isMdTableDelimiter 判断一行是否为列数为 columns 的表格分隔行，如 "| --- | :---: |"
+func isMdTableDelimiter(line string, columns int) bool {
+	if !strings.Contains(line, "-") {
+		return false
+	}
+	cells := splitMdTableRow(line)
+	if len(cells) != columns {
+		return false
+	}
+	// 只有一列时分隔行必须包含 "|"，否则会与 Setext 标题的下划线混淆
+	if columns == 1 && !strings.Contains(line, "|") {
+		return false
+	}
+	for _, cell := range cells {
+		cell = strings.TrimSuffix(strings.TrimPrefix(cell, ":"), ":")
+		if cell == "" || strings.Trim(cell, "-") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// fitMdTableRow 将数据行的单元格数调整为 columns
+func fitMdTableRow(cells []string, columns int) []string {
+	if len(cells) > columns {
+		return cells[:columns]
+	}
+	for len(cells) < columns {
+		cells = append(cells, "")
+	}
+	return cells
+}
+
 // ReadWithConfig 根据配置读取 Markdown 文件，返回结构化结果
 func (r *MdReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
-	data, err := os.ReadFile(filePath)
+	if metadataOnly(config) {
+		metadata, err := r.GetMetadata(filePath)
+		return metadataOnlyResult("MdReader.ReadWithConfig", filePath, 1, metadata, err)
+	}
+
+	data, err := r.readFile(filePath)
 	if err != nil {
 		return nil, WrapError("MdReader.ReadWithConfig", filePath, ErrFileRead)
 	}
 
-	content := string(data)
+	content := string(trimBOM(data))
 	lines := strings.Split(content, "\n")
+	if config != nil && config.SplitMode == SplitParagraphs {
+		lines = splitParagraphs(lines)
+	}
 
 	result := &DocumentResult{
 		FilePath:   filePath,
@@ -58,17 +314,13 @@ func (r *MdReader) ReadWithConfig(filePath string, config *ReadConfig) (*Documen
 	result.Metadata = metadata
 
 	// 根据配置筛选行
-	filteredLines := filterLinesForSinglePage(lines, config)
-
-	pageContent := PageContent{
-		PageNumber: 0,
-		Lines:      filteredLines,
-		TotalLines: len(filteredLines),
-	}
+	pageContent := newPageContent(0, lines, singlePageFilter(config), config)
 
 	result.Pages = append(result.Pages, pageContent)
-	result.TotalLines = len(filteredLines)
-	result.Content = strings.Join(filteredLines, "\n")
+	result.TotalLines = pageContent.TotalLines
+	result.finish(config)
+
+	reportProgress(config, 1, 1)
 
 	return result, nil
 }
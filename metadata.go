@@ -0,0 +1,207 @@
+package docreader
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// MetadataResult 批量读取元数据时单个文件的结果
+type MetadataResult struct {
+	// FilePath 文件路径
+	FilePath string
+
+	// Metadata 元数据，读取失败时为 nil
+	Metadata map[string]string
+
+	// Err 读取失败时的错误
+	Err error
+}
+
+// ReadMetadata 根据文件扩展名以最小的代价读取文档元数据，适合只需要编目信息的场景
+// 与各读取器的 GetMetadata 不同，这里只读取描述性的部件：
+//   - DOCX：docProps/core.xml 和 docProps/app.xml，不解析正文（因此没有需要统计分页符的 section_count）
+//   - XLSX：docProps/core.xml、docProps/app.xml 和 xl/workbook.xml 中的工作表名称，不加载工作表
+//   - PPTX：核心属性、扩展属性和幻灯片数量，不解析幻灯片
+//   - PDF：文档信息字典和页数，不提取页面文本
+//   - Pages/Numbers/Keynote：预览 PDF 的文档信息字典和页数，大小和修改时间为 iWork 文件本身的信息
+//   - ZIP：受支持的文件数量和路径，不解压文件
+//   - 其他文本格式：文件大小和修改时间，不读取内容
+func ReadMetadata(filePath string) (map[string]string, error) {
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, WrapError("ReadMetadata", filePath, ErrFileNotFound)
+	}
+
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if newFormatReader(ext) == nil {
+		return nil, WrapError("ReadMetadata", filePath, ErrUnsupportedFormat)
+	}
+
+	switch ext {
+	case ".docx":
+		return docxCatalogMetadata(filePath)
+	case ".xlsx":
+		return xlsxCatalogMetadata(filePath)
+	case ".pptx":
+		return (&PptxReader{}).GetMetadata(filePath)
+	case ".pdf":
+		return pdfCatalogMetadata(filePath)
+	case ".pages", ".numbers", ".key":
+		return iworkCatalogMetadata(filePath)
+	case ".zip":
+		return (&ZipReader{}).GetMetadata(filePath)
+	default:
+		return fileCatalogMetadata(filePath)
+	}
+}
+
+// ReadMetadataBatch 并发读取多个文件的元数据，结果与 paths 的顺序一一对应
+// concurrency 为同时读取的文件数，小于等于 0 时使用 runtime.NumCPU()；单个文件失败不影响其他文件
+func ReadMetadataBatch(paths []string, concurrency int) []MetadataResult {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	if concurrency > len(paths) {
+		concurrency = len(paths)
+	}
+
+	results := make([]MetadataResult, len(paths))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				metadata, err := ReadMetadata(paths[index])
+				results[index] = MetadataResult{
+					FilePath: paths[index],
+					Metadata: metadata,
+					Err:      err,
+				}
+			}
+		}()
+	}
+
+	for index := range paths {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// docxCatalogMetadata 只读取 DOCX 的文档属性部件
+// DOCX 的 section_count 是正文中按分页符划分的页数，不解析正文无法得到，因此结果中没有该键；
+// app.xml 中的 pages 是保存时排版得到的页数，与 section_count 的含义不同
+func docxCatalogMetadata(filePath string) (map[string]string, error) {
+	zipReader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, WrapError("ReadMetadata", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	metadata, err := docxMetadata(&zipReader.Reader, newZipLimits(nil))
+	if err != nil {
+		return nil, WrapError("ReadMetadata", filePath, err)
+	}
+	return metadata, nil
+}
+
+// xlsxWorkbook xl/workbook.xml 中的工作表列表
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxCatalogMetadata 读取 XLSX 的文档属性和工作表名称，不加载工作表内容
+func xlsxCatalogMetadata(filePath string) (map[string]string, error) {
+	zipReader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, WrapError("ReadMetadata", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	limits := newZipLimits(nil)
+	metadata, err := docxMetadata(&zipReader.Reader, limits)
+	if err != nil {
+		return nil, WrapError("ReadMetadata", filePath, err)
+	}
+
+	// 工作簿部件是必需的
+	data, err := readZipPart(&zipReader.Reader, "xl/workbook.xml", limits)
+	if err != nil {
+		return nil, WrapError("ReadMetadata", filePath, err)
+	}
+	var workbook xlsxWorkbook
+	if err := xml.Unmarshal(data, &workbook); err != nil {
+		return nil, WrapError("ReadMetadata", filePath, ErrFileParse)
+	}
+
+	sheets := make([]string, 0, len(workbook.Sheets))
+	for _, sheet := range workbook.Sheets {
+		sheets = append(sheets, sheet.Name)
+	}
+	metadata["sheets"] = strings.Join(sheets, ", ")
+	metadata["sheet_count"] = fmt.Sprintf("%d", len(sheets))
+	metadata["section_count"] = metadata["sheet_count"]
+
+	return metadata, nil
+}
+
+// pdfCatalogMetadata 只读取 PDF 的文件头、文档信息字典和页数
+func pdfCatalogMetadata(filePath string) (map[string]string, error) {
+	f, reader, err := pdf.Open(filePath)
+	if err != nil {
+		return nil, WrapError("ReadMetadata", filePath, ErrFileOpen)
+	}
+	defer f.Close()
+
+	return pdfInfoMetadata(f, reader), nil
+}
+
+// iworkCatalogMetadata 只读取 iWork 包中预览 PDF 的文件头、文档信息字典和页数，
+// 与 iWork 读取器的 GetMetadata 一致，文件大小和修改时间为 iWork 文件本身的信息
+func iworkCatalogMetadata(filePath string) (map[string]string, error) {
+	source := &fileSource{}
+	var metadata map[string]string
+	err := source.withIworkPreview(filePath, "ReadMetadata", newZipLimits(nil), func(preview fileSource, previewName string) error {
+		f, reader, err := preview.openPdf(previewName)
+		if err != nil {
+			return WrapError("ReadMetadata", previewName, ErrFileOpen)
+		}
+		defer f.Close()
+
+		metadata = pdfInfoMetadata(f, reader)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	source.addIworkFileInfo(metadata, filePath)
+	return metadata, nil
+}
+
+// fileCatalogMetadata 只读取文件系统信息，适用于单页的文本格式
+func fileCatalogMetadata(filePath string) (map[string]string, error) {
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return nil, WrapError("ReadMetadata", filePath, ErrFileRead)
+	}
+
+	return map[string]string{
+		"size":          fmt.Sprintf("%d", fileInfo.Size()),
+		"modified":      fileInfo.ModTime().String(),
+		"section_count": "1",
+	}, nil
+}
@@ -1,28 +1,81 @@
 package docreader
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/ledongthuc/pdf"
 )
 
 // PdfReader 用于读取 .pdf 文件
-type PdfReader struct{}
+type PdfReader struct {
+	fileSource
+}
+
+// PageSize 表示 PDF 页面的尺寸，单位为点（1/72 英寸）
+type PageSize struct {
+	Width    float64 // 宽度，取自 MediaBox
+	Height   float64 // 高度，取自 MediaBox
+	Rotation int     // 顺时针旋转角度，取值为 0、90、180 或 270
+}
+
+// IsLandscape 判断页面在应用旋转后是否为横向
+func (s PageSize) IsLandscape() bool {
+	if s.Rotation == 90 || s.Rotation == 270 {
+		return s.Height > s.Width
+	}
+	return s.Width > s.Height
+}
+
+// TextElement PDF 页面上的一个文本片段及其位置
+// 坐标使用 PDF 用户空间单位（点，1/72 英寸），原点位于页面左下角，X 向右增大，Y 向上增大；
+// 已应用内容流中的变换矩阵，但没有应用页面的 Rotate
+type TextElement struct {
+	// Text 片段文本
+	Text string
+
+	// X 片段起点（基线左端）的横坐标
+	X float64
+
+	// Y 片段基线的纵坐标
+	Y float64
+
+	// W 片段宽度，字体没有宽度信息（如未提供 Widths 的标准 14 字体）时为 0
+	W float64
+
+	// H 片段高度，取字号
+	H float64
+}
 
 // ReadText 读取 PDF 文件的文本内容
 func (r *PdfReader) ReadText(filePath string) (string, error) {
+	var builder strings.Builder
+	if err := r.writeText(&builder, filePath); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// writeText 逐页将 PDF 文本写入 w，写入出错时立即停止
+func (r *PdfReader) writeText(w io.Writer, filePath string) error {
 	// 打开 PDF 文件
-	f, reader, err := pdf.Open(filePath)
+	f, reader, err := r.openPdf(filePath)
 	if err != nil {
-		return "", WrapError("PdfReader.ReadText", filePath, ErrFileOpen)
+		return WrapError("PdfReader.ReadText", filePath, ErrFileOpen)
 	}
 	defer f.Close()
 
 	// 获取总页数
 	totalPages := reader.NumPage()
 
-	var content strings.Builder
+	tw := newTextWriter(w)
 
 	// 逐页读取文本
 	for pageNum := 1; pageNum <= totalPages; pageNum++ {
@@ -34,26 +87,91 @@ func (r *PdfReader) ReadText(filePath string) (string, error) {
 		text, err := page.GetPlainText(nil)
 		if err != nil {
 			// 如果某页读取失败，继续读取下一页
+			logWarn("skipped page", "op", "PdfReader.ReadText", "file", filePath, "page", pageNum, "error", err)
 			continue
 		}
 
-		content.WriteString(text)
-		content.WriteString("\n\n--- 第 " + fmt.Sprintf("%d", pageNum) + " 页 ---\n\n")
+		tw.WriteString(text)
+		tw.WriteString("\n\n--- 第 " + fmt.Sprintf("%d", pageNum) + " 页 ---\n\n")
+		if tw.err != nil {
+			return tw.err
+		}
 	}
 
-	return content.String(), nil
+	return nil
 }
 
 // GetMetadata 获取 PDF 文件的元数据
 func (r *PdfReader) GetMetadata(filePath string) (map[string]string, error) {
-	f, reader, err := pdf.Open(filePath)
+	f, reader, err := r.openPdf(filePath)
 	if err != nil {
 		return nil, WrapError("PdfReader.GetMetadata", filePath, ErrFileOpen)
 	}
 	defer f.Close()
 
+	metadata := pdfInfoMetadata(f, reader)
+	metadata["has_text_layer"] = strconv.FormatBool(pdfHasTextLayer(reader))
+	addPdfPageSize(metadata, reader)
+
+	return metadata, nil
+}
+
+// addPdfPageSize 记录首页的尺寸和方向
+func addPdfPageSize(metadata map[string]string, reader *pdf.Reader) {
+	if reader.NumPage() == 0 {
+		return
+	}
+	size := pdfPageSize(reader.Page(1))
+	metadata["page_size"] = strconv.FormatFloat(size.Width, 'f', -1, 64) + "x" + strconv.FormatFloat(size.Height, 'f', -1, 64)
+	if size.IsLandscape() {
+		metadata["page_orientation"] = "landscape"
+	} else {
+		metadata["page_orientation"] = "portrait"
+	}
+}
+
+// pdfHeaderSize 文件头、线性化字典所在的文件开头字节数（PDF 规范要求线性化字典位于前 1024 字节内）
+const pdfHeaderSize = 1024
+
+var (
+	// pdfVersionPattern 匹配文件头 "%PDF-1.7" 中的版本号
+	pdfVersionPattern = regexp.MustCompile(`%PDF-(\d+\.\d+)`)
+
+	// pdfObjectPattern 匹配间接对象的开头，如 "43 0 obj"
+	pdfObjectPattern = regexp.MustCompile(`\d+\s+\d+\s+obj\b`)
+)
+
+// pdfInfoMetadata 读取文件头、文档信息字典、目录和页数，不解析页面内容
+// pdf_version 来自文件头，linearized 表示第一个对象是线性化字典（针对网页浏览优化），
+// encrypted 表示尾部字典包含 /Encrypt，page_mode 为目录的 /PageMode（缺省为 UseNone）
+func pdfInfoMetadata(file io.ReaderAt, reader *pdf.Reader) map[string]string {
 	metadata := make(map[string]string)
 
+	// 文件头：版本号和线性化字典
+	header := make([]byte, pdfHeaderSize)
+	n, _ := file.ReadAt(header, 0)
+	header = header[:n]
+	linearized := false
+	if loc := pdfVersionPattern.FindSubmatchIndex(header); loc != nil {
+		metadata["pdf_version"] = string(header[loc[2]:loc[3]])
+		rest := header[loc[1]:]
+		if obj := pdfObjectPattern.FindIndex(rest); obj != nil {
+			body := rest[obj[1]:]
+			if end := bytes.Index(body, []byte("endobj")); end >= 0 {
+				body = body[:end]
+			}
+			linearized = bytes.Contains(body, []byte("/Linearized"))
+		}
+	}
+	metadata["linearized"] = strconv.FormatBool(linearized)
+
+	metadata["encrypted"] = strconv.FormatBool(!reader.Trailer().Key("Encrypt").IsNull())
+	pageMode := reader.Trailer().Key("Root").Key("PageMode").Name()
+	if pageMode == "" {
+		pageMode = "UseNone"
+	}
+	metadata["page_mode"] = pageMode
+
 	// 获取基本信息
 	if !reader.Trailer().IsNull() && !reader.Trailer().Key("Info").IsNull() {
 		info := reader.Trailer().Key("Info")
@@ -82,35 +200,422 @@ func (r *PdfReader) GetMetadata(filePath string) (map[string]string, error) {
 	}
 
 	metadata["pages"] = fmt.Sprintf("%d", reader.NumPage())
+	metadata["section_count"] = metadata["pages"]
 
-	return metadata, nil
+	return metadata
+}
+
+// GetPageDimensions 获取每一页的尺寸和旋转角度，结果按页码顺序排列
+func (r *PdfReader) GetPageDimensions(filePath string) ([]PageSize, error) {
+	f, reader, err := r.openPdf(filePath)
+	if err != nil {
+		return nil, WrapError("PdfReader.GetPageDimensions", filePath, ErrFileOpen)
+	}
+	defer f.Close()
+
+	totalPages := reader.NumPage()
+	sizes := make([]PageSize, 0, totalPages)
+	for pageNum := 1; pageNum <= totalPages; pageNum++ {
+		sizes = append(sizes, pdfPageSize(reader.Page(pageNum)))
+	}
+
+	return sizes, nil
+}
+
+// GetTextElements 获取指定页（从0开始）上的文本片段及其坐标，按内容流中的绘制顺序排列
+// 片段的粒度由 PDF 库决定，通常每个字符一个片段；页码超出范围时返回 ErrPageNotFound，
+// 内容流无法解析时返回 ErrFileParse
+func (r *PdfReader) GetTextElements(filePath string, page int) (elements []TextElement, err error) {
+	f, reader, err := r.openPdf(filePath)
+	if err != nil {
+		return nil, WrapError("PdfReader.GetTextElements", filePath, ErrFileOpen)
+	}
+	defer f.Close()
+
+	if page < 0 || page >= reader.NumPage() {
+		return nil, WrapError("PdfReader.GetTextElements", filePath, ErrPageNotFound)
+	}
+	p := reader.Page(page + 1)
+	if p.V.IsNull() {
+		return nil, WrapError("PdfReader.GetTextElements", filePath, ErrPageNotFound)
+	}
+
+	// PDF 库在内容流格式错误时会 panic
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			elements = nil
+			err = WrapError("PdfReader.GetTextElements", filePath, fmt.Errorf("page %d: %v: %w", page+1, recovered, ErrFileParse))
+		}
+	}()
+
+	texts := p.Content().Text
+	elements = make([]TextElement, 0, len(texts))
+	for _, text := range texts {
+		elements = append(elements, TextElement{
+			Text: text.S,
+			X:    text.X,
+			Y:    text.Y,
+			W:    text.W,
+			H:    text.FontSize,
+		})
+	}
+
+	return elements, nil
+}
+
+const (
+	// textLayerSamplePages 判断文本层时最多检查的页数（从第一页开始）
+	textLayerSamplePages = 5
+
+	// textLayerMinChars 判断为有文本层所需的最少非空白字符数
+	textLayerMinChars = 20
+)
+
+// HasTextLayer 判断 PDF 是否包含可提取的文本层，用于区分扫描件和数字文档
+// 检查前几页的文本，非空白字符达到一定数量时返回 true；只包含图像的扫描件返回 false，
+// 可以据此只将扫描件交给 OCR 处理
+func (r *PdfReader) HasTextLayer(filePath string) (bool, error) {
+	f, reader, err := r.openPdf(filePath)
+	if err != nil {
+		return false, WrapError("PdfReader.HasTextLayer", filePath, ErrFileOpen)
+	}
+	defer f.Close()
+
+	return pdfHasTextLayer(reader), nil
+}
+
+// pdfHasTextLayer 统计前 textLayerSamplePages 页的非空白字符数，达到 textLayerMinChars 时返回 true
+func pdfHasTextLayer(reader *pdf.Reader) bool {
+	chars := 0
+	for pageNum := 1; pageNum <= reader.NumPage() && pageNum <= textLayerSamplePages; pageNum++ {
+		page := reader.Page(pageNum)
+		if page.V.IsNull() {
+			continue
+		}
+		text, err := page.GetPlainText(nil)
+		if err != nil {
+			continue
+		}
+		chars += nonSpaceChars(text)
+		if chars >= textLayerMinChars {
+			return true
+		}
+	}
+	return false
+}
+
+// nonSpaceChars 统计文本中的非空白字符数
+func nonSpaceChars(text string) int {
+	n := 0
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// GetFonts 获取文档使用的字体名称（BaseFont），按首次出现的顺序返回并去重
+// 字体来自每一页的资源字典（可从父节点继承），也包括页面中表单 XObject 的资源；
+// 子集字体保留原始名称（如 "ABCDEF+Helvetica"），没有 BaseFont 的字体（如 Type3）会被忽略
+func (r *PdfReader) GetFonts(filePath string) ([]string, error) {
+	f, reader, err := r.openPdf(filePath)
+	if err != nil {
+		return nil, WrapError("PdfReader.GetFonts", filePath, ErrFileOpen)
+	}
+	defer f.Close()
+
+	fonts := make([]string, 0)
+	seen := make(map[string]bool)
+	for pageNum := 1; pageNum <= reader.NumPage(); pageNum++ {
+		page := reader.Page(pageNum)
+		if page.V.IsNull() {
+			continue
+		}
+		collectPdfFonts(page.Resources(), 0, func(name string) {
+			if !seen[name] {
+				seen[name] = true
+				fonts = append(fonts, name)
+			}
+		})
+	}
+
+	return fonts, nil
+}
+
+// maxPdfResourceDepth 递归查找表单 XObject 资源的最大深度，防止循环引用
+const maxPdfResourceDepth = 8
+
+// collectPdfFonts 对资源字典中每个字体的 BaseFont 调用 add，并递归处理表单 XObject 的资源
+func collectPdfFonts(resources pdf.Value, depth int, add func(name string)) {
+	if resources.IsNull() || depth > maxPdfResourceDepth {
+		return
+	}
+
+	fontDict := resources.Key("Font")
+	for _, key := range fontDict.Keys() {
+		if name := fontDict.Key(key).Key("BaseFont").Name(); name != "" {
+			add(name)
+		}
+	}
+
+	xobjects := resources.Key("XObject")
+	for _, key := range xobjects.Keys() {
+		xobject := xobjects.Key(key)
+		if xobject.Key("Subtype").Name() == "Form" {
+			collectPdfFonts(xobject.Key("Resources"), depth+1, add)
+		}
+	}
+}
+
+// GetPageLabels 获取每一页的页码标签（目录中 /PageLabels 数字树定义的印刷页码），结果按页码顺序排列
+// 例如前言使用小写罗马数字、正文使用阿拉伯数字的文档返回 ["i", "ii", "iii", "1", "2", ...]；
+// 支持 /S 样式（D、R、r、A、a）、/P 前缀和 /St 起始值。没有定义页码标签的文档或页面使用从1开始的页码
+func (r *PdfReader) GetPageLabels(filePath string) ([]string, error) {
+	f, reader, err := r.openPdf(filePath)
+	if err != nil {
+		return nil, WrapError("PdfReader.GetPageLabels", filePath, ErrFileOpen)
+	}
+	defer f.Close()
+
+	return pdfPageLabels(reader), nil
+}
+
+// pdfLabelRange 页码标签数字树中的一项，从 start 页（从0开始）开始使用该样式
+type pdfLabelRange struct {
+	start  int
+	style  string
+	prefix string
+	first  int
+}
+
+const (
+	// maxPdfNumberTreeDepth 递归读取数字树 /Kids 的最大深度，防止循环引用
+	maxPdfNumberTreeDepth = 32
+
+	// maxPdfNumberTreeNodes 读取数字树时最多访问的节点数，防止 /Kids 重复引用同一节点时访问次数指数增长
+	maxPdfNumberTreeNodes = 4096
+
+	// maxPdfPageLabelStart 页码标签 /St 起始值的上限，更大的值按上限处理
+	maxPdfPageLabelStart = 1 << 30
+
+	// maxPdfRomanPageNumber 罗马数字页码的上限，更大的页码使用阿拉伯数字
+	maxPdfRomanPageNumber = 3999
+
+	// maxPdfLetterPageNumber 字母页码的上限（ZZ...Z 共 26 个字母），更大的页码使用阿拉伯数字
+	maxPdfLetterPageNumber = 26 * 26
+)
+
+// pdfPageLabels 计算每一页的页码标签
+func pdfPageLabels(reader *pdf.Reader) []string {
+	ranges := make([]pdfLabelRange, 0)
+	visited := 0
+	collectPdfLabelRanges(reader.Trailer().Key("Root").Key("PageLabels"), 0, &visited, &ranges)
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+
+	totalPages := reader.NumPage()
+	labels := make([]string, totalPages)
+	current := -1
+	for page := 0; page < totalPages; page++ {
+		for current+1 < len(ranges) && ranges[current+1].start <= page {
+			current++
+		}
+		if current < 0 {
+			labels[page] = strconv.Itoa(page + 1)
+			continue
+		}
+		labelRange := ranges[current]
+		labels[page] = labelRange.prefix + formatPdfPageNumber(labelRange.style, labelRange.first+page-labelRange.start)
+	}
+	return labels
+}
+
+// collectPdfLabelRanges 读取数字树节点的 /Nums 和 /Kids，将其中的页码标签字典追加到 ranges
+// visited 为已访问的节点数，达到 maxPdfNumberTreeNodes 后忽略其余节点
+func collectPdfLabelRanges(node pdf.Value, depth int, visited *int, ranges *[]pdfLabelRange) {
+	if node.Kind() != pdf.Dict || depth > maxPdfNumberTreeDepth || *visited >= maxPdfNumberTreeNodes {
+		return
+	}
+	*visited++
+
+	nums := node.Key("Nums")
+	for i := 0; i+1 < nums.Len(); i += 2 {
+		key, label := nums.Index(i), nums.Index(i+1)
+		if key.Kind() != pdf.Integer || key.Int64() < 0 {
+			continue
+		}
+		labelRange := pdfLabelRange{
+			start:  int(key.Int64()),
+			style:  label.Key("S").Name(),
+			prefix: label.Key("P").Text(),
+			first:  1,
+		}
+		if st := label.Key("St"); st.Kind() == pdf.Integer && st.Int64() >= 1 {
+			labelRange.first = int(min(st.Int64(), maxPdfPageLabelStart))
+		}
+		*ranges = append(*ranges, labelRange)
+	}
+
+	kids := node.Key("Kids")
+	for i := 0; i < kids.Len(); i++ {
+		collectPdfLabelRanges(kids.Index(i), depth+1, visited, ranges)
+	}
+}
+
+// formatPdfPageNumber 按 /S 样式格式化页码数字，没有样式时标签只包含前缀
+func formatPdfPageNumber(style string, n int) string {
+	switch style {
+	case "D":
+		return strconv.Itoa(n)
+	case "R":
+		return toRoman(n)
+	case "r":
+		return strings.ToLower(toRoman(n))
+	case "A":
+		return toPageLetters(n)
+	case "a":
+		return strings.ToLower(toPageLetters(n))
+	default:
+		return ""
+	}
+}
+
+// toRoman 将正整数转换为大写罗马数字，超过 maxPdfRomanPageNumber 时返回阿拉伯数字
+func toRoman(n int) string {
+	if n > maxPdfRomanPageNumber {
+		return strconv.Itoa(n)
+	}
+
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+
+	var builder strings.Builder
+	for i, value := range values {
+		for n >= value {
+			builder.WriteString(symbols[i])
+			n -= value
+		}
+	}
+	return builder.String()
+}
+
+// toPageLetters 将正整数转换为 PDF 字母页码：A 到 Z，之后为 AA 到 ZZ、AAA 到 ZZZ，依此类推
+// 超过 maxPdfLetterPageNumber 时返回阿拉伯数字
+func toPageLetters(n int) string {
+	if n < 1 {
+		return ""
+	}
+	if n > maxPdfLetterPageNumber {
+		return strconv.Itoa(n)
+	}
+	return strings.Repeat(string(rune('A'+(n-1)%26)), (n-1)/26+1)
+}
+
+// resolvePdfPageLabels 将 config.PageLabels 中的页码标签解析为页索引并加入页面选择器，返回新的配置
+// 同一标签对应多页时全部选中；有标签未匹配到任何页时返回 ErrPageNotFound
+func resolvePdfPageLabels(reader *pdf.Reader, config *ReadConfig) (*ReadConfig, error) {
+	indexes := make(map[string][]int)
+	for page, label := range pdfPageLabels(reader) {
+		indexes[label] = append(indexes[label], page)
+	}
+
+	resolved := *config
+	resolved.PageSelector.Indexes = append([]int(nil), config.PageSelector.Indexes...)
+	for _, label := range config.PageLabels {
+		pages, ok := indexes[label]
+		if !ok {
+			return nil, fmt.Errorf("%w: page label %q", ErrPageNotFound, label)
+		}
+		resolved.PageSelector.Indexes = append(resolved.PageSelector.Indexes, pages...)
+	}
+	return &resolved, nil
+}
+
+// pdfPageSize 读取页面的 MediaBox 和 Rotate，两者均可从父节点继承
+func pdfPageSize(page pdf.Page) PageSize {
+	var size PageSize
+
+	box := pdfInherited(page.V, "MediaBox")
+	if box.Len() == 4 {
+		size.Width = math.Abs(box.Index(2).Float64() - box.Index(0).Float64())
+		size.Height = math.Abs(box.Index(3).Float64() - box.Index(1).Float64())
+	}
+
+	rotation := int(pdfInherited(page.V, "Rotate").Int64()) % 360
+	if rotation < 0 {
+		rotation += 360
+	}
+	size.Rotation = rotation
+
+	return size
+}
+
+// maxPdfParentDepth 沿 /Parent 向上查找可继承属性的最大层数，防止循环引用
+const maxPdfParentDepth = 32
+
+// pdfInherited 查找页面属性，页面本身未设置时沿页面树向上查找，最多查找 maxPdfParentDepth 层
+func pdfInherited(v pdf.Value, key string) pdf.Value {
+	for depth := 0; depth <= maxPdfParentDepth && !v.IsNull(); depth, v = depth+1, v.Key("Parent") {
+		if value := v.Key(key); !value.IsNull() {
+			return value
+		}
+	}
+	return pdf.Value{}
+}
+
+// Capabilities 返回 PDF 读取器支持的功能：按页读取，可以包含图片，元数据包含文档记录的创建和修改时间
+func (r *PdfReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{
+		Pagination:    true,
+		Images:        true,
+		MetadataDates: true,
+	}
 }
 
 // ReadWithConfig 根据配置读取 PDF 文件，返回结构化结果
 func (r *PdfReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
-	f, reader, err := pdf.Open(filePath)
+	f, reader, err := r.openPdf(filePath)
 	if err != nil {
 		return nil, WrapError("PdfReader.ReadWithConfig", filePath, ErrFileOpen)
 	}
 	defer f.Close()
 
+	// 只需要元数据时只读取文档信息字典和页数，不提取任何页面的文本（因此没有 has_text_layer）
+	if metadataOnly(config) {
+		metadata := pdfInfoMetadata(f, reader)
+		addPdfPageSize(metadata, reader)
+		return metadataOnlyResult("PdfReader.ReadWithConfig", filePath, reader.NumPage(), metadata, nil)
+	}
+
+	// 将页码标签解析为页索引
+	if config != nil && len(config.PageLabels) > 0 {
+		config, err = resolvePdfPageLabels(reader, config)
+		if err != nil {
+			return nil, WrapError("PdfReader.ReadWithConfig", filePath, err)
+		}
+	}
+
 	totalPages := reader.NumPage()
 	result := &DocumentResult{
 		FilePath:   filePath,
 		TotalPages: totalPages,
 		Pages:      make([]PageContent, 0),
 		Metadata:   make(map[string]string),
+		layout:     layoutPages,
 	}
 
-	// 获取元数据
-	metadata, _ := r.GetMetadata(filePath)
-	result.Metadata = metadata
+	// 获取元数据（复用已打开的文件，has_text_layer 在提取页面文本时确定）
+	result.Metadata = pdfInfoMetadata(f, reader)
+	addPdfPageSize(result.Metadata, reader)
+
+	// 前 textLayerSamplePages 页的非空白字符数和已检查的页数，用于判断是否有文本层
+	textChars, sampledPages := 0, 0
 
 	// 确定要读取的页码和每页的行配置
 	pageLineMap := buildPageLineMap(config, totalPages)
 
-	var contentBuilder strings.Builder
 	totalLines := 0
+	processed := 0
 
 	// 按页码顺序处理
 	for pageIndex := 0; pageIndex < totalPages; pageIndex++ {
@@ -119,42 +624,98 @@ func (r *PdfReader) ReadWithConfig(filePath string, config *ReadConfig) (*Docume
 			continue
 		}
 
+		processed++
+
 		// PDF库的页码从1开始，所以需要+1
 		page := reader.Page(pageIndex + 1)
+		if pageIndex < textLayerSamplePages {
+			sampledPages++
+		}
 		if page.V.IsNull() {
+			placeholder, err := pdfPageFailure(result, config, pageIndex, "page %d: page object not found", pageIndex+1)
+			if err != nil {
+				return nil, err
+			}
+			if placeholder != nil {
+				result.Pages = append(result.Pages, *placeholder)
+				totalLines += placeholder.TotalLines
+			}
+			reportProgress(config, processed, len(pageLineMap))
 			continue
 		}
 
 		text, err := page.GetPlainText(nil)
 		if err != nil {
+			logWarn("skipped page", "op", "PdfReader.ReadWithConfig", "file", filePath, "page", pageIndex+1, "error", err)
+			placeholder, err := pdfPageFailure(result, config, pageIndex, "page %d: failed to extract text: %v", pageIndex+1, err)
+			if err != nil {
+				return nil, err
+			}
+			if placeholder != nil {
+				result.Pages = append(result.Pages, *placeholder)
+				totalLines += placeholder.TotalLines
+			}
+			reportProgress(config, processed, len(pageLineMap))
 			continue
 		}
 
+		if pageIndex < textLayerSamplePages {
+			textChars += nonSpaceChars(text)
+		}
+
 		// 按行分割
 		lines := strings.Split(text, "\n")
 
 		// 根据该页的配置筛选行
-		filteredLines := filterLinesForPage(lines, lineConfig)
-
-		pageContent := PageContent{
-			PageNumber: pageIndex,
-			Lines:      filteredLines,
-			TotalLines: len(filteredLines),
-		}
+		pageContent := newPageContent(pageIndex, lines, lineConfig, config)
 
 		result.Pages = append(result.Pages, pageContent)
-		totalLines += len(filteredLines)
+		totalLines += pageContent.TotalLines
 
-		// 构建完整内容
-		for _, line := range filteredLines {
-			contentBuilder.WriteString(line)
-			contentBuilder.WriteString("\n")
-		}
-		contentBuilder.WriteString(fmt.Sprintf("\n--- 第 %d 页 ---\n\n", pageIndex))
+		reportProgress(config, processed, len(pageLineMap))
+	}
+
+	// 与 pdfHasTextLayer 的判断一致：已提取的文本足够时为 true；
+	// 只有前 textLayerSamplePages 页都已检查过才能确定为 false，否则不设置 has_text_layer
+	if textChars >= textLayerMinChars {
+		result.Metadata["has_text_layer"] = "true"
+	} else if sampledPages == min(totalPages, textLayerSamplePages) {
+		result.Metadata["has_text_layer"] = "false"
 	}
 
 	result.TotalLines = totalLines
-	result.Content = contentBuilder.String()
+	result.finish(config)
 
 	return result, nil
 }
+
+// pdfPageFailure 按 PdfPageErrorMode 处理读取失败的页：PdfPageError 时返回错误；
+// 否则通过 partialFailure 记录警告，PdfPagePlaceholder 时返回只包含占位行的页面，PdfPageSkip 时返回 nil
+// 占位行不受行选择器和 TrimLines 等选项影响
+func pdfPageFailure(result *DocumentResult, config *ReadConfig, pageIndex int, format string, args ...any) (*PageContent, error) {
+	mode := PdfPageSkip
+	if config != nil {
+		mode = config.PdfPageErrorMode
+	}
+
+	if mode == PdfPageError {
+		msg := fmt.Sprintf(format, args...)
+		return nil, WrapError("PdfReader.ReadWithConfig", result.FilePath, fmt.Errorf("%s: %w", msg, ErrFileParse))
+	}
+	if err := result.partialFailure(config, "PdfReader.ReadWithConfig", format, args...); err != nil {
+		return nil, err
+	}
+	if mode != PdfPagePlaceholder {
+		return nil, nil
+	}
+
+	placeholder := PageContent{
+		PageNumber: pageIndex,
+		Lines:      []string{fmt.Sprintf("[page %d unreadable]", pageIndex+1)},
+		TotalLines: 1,
+	}
+	if config != nil && config.PreserveLineNumbers {
+		placeholder.LineNumbers = []int{0}
+	}
+	return &placeholder, nil
+}
@@ -2,32 +2,120 @@ package docreader
 
 import (
 	"archive/zip"
+	"bytes"
+	"cmp"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
 	"strings"
 )
 
 // PptxReader 用于读取 .pptx 文件
-type PptxReader struct{}
+type PptxReader struct {
+	fileSource
+}
+
+// OpenedPptx 表示一个已打开的 PPTX 文件
+// 多次读取（文本、元数据、幻灯片等）会复用同一个 zip 句柄和已解析的幻灯片，
+// 使用完毕后需要调用 Close 释放资源。OpenedPptx 不是并发安全的。
+type OpenedPptx struct {
+	filePath  string
+	zipReader *zipFile
+
+	// slides 缓存解析后的幻灯片，首次使用时解析
+	slides       []Slide
+	slidesParsed bool
+}
 
 // Slide 表示幻灯片的 XML 结构
 type Slide struct {
-	XMLName   xml.Name `xml:"sld"`
+	XMLName xml.Name `xml:"sld"`
+
+	// ShowMasterSp 是否显示版式（母版）中的非占位符形状，为 "0" 或 "false" 时不显示
+	ShowMasterSp string `xml:"showMasterSp,attr"`
+
 	CommonSld struct {
 		ShapeTree struct {
-			Shapes []struct {
-				TextBody struct {
-					Paragraphs []struct {
-						Runs []struct {
-							Text string `xml:"t"`
-						} `xml:"r"`
-					} `xml:"p"`
-				} `xml:"txBody"`
-			} `xml:"sp"`
+			Shapes   []SlideShape `xml:"sp"`
+			Pictures []struct {
+				NonVisual struct {
+					Props ShapeAltText `xml:"cNvPr"`
+				} `xml:"nvPicPr"`
+			} `xml:"pic"`
+			GraphicFrames []struct {
+				NonVisual struct {
+					Props ShapeAltText `xml:"cNvPr"`
+				} `xml:"nvGraphicFramePr"`
+			} `xml:"graphicFrame"`
 		} `xml:"spTree"`
 	} `xml:"cSld"`
+
+	// partName 幻灯片在 zip 包中的部件名称，用于查找版式和母版
+	partName string
+
+	// err 幻灯片无法读取（ErrFileRead）或解析（ErrFileParse）时的错误，此时幻灯片没有内容
+	err error
+}
+
+// SlideShape 幻灯片、版式或母版中的一个形状
+type SlideShape struct {
+	NonVisual struct {
+		Props ShapeAltText `xml:"cNvPr"`
+
+		// Placeholder 占位符信息（nvPr/ph），不是占位符的形状为 nil
+		Placeholder *ShapePlaceholder `xml:"nvPr>ph"`
+	} `xml:"nvSpPr"`
+	TextBody struct {
+		Paragraphs []struct {
+			Runs []struct {
+				Text string `xml:"t"`
+			} `xml:"r"`
+		} `xml:"p"`
+	} `xml:"txBody"`
+}
+
+// ShapePlaceholder 占位符的类型和索引，如页脚为 type="ftr"；正文占位符的 type 通常为空
+type ShapePlaceholder struct {
+	Type  string `xml:"type,attr"`
+	Index string `xml:"idx,attr"`
+}
+
+// lines 返回形状中的非空段落，每个段落作为一行
+func (s SlideShape) lines() []string {
+	lines := make([]string, 0)
+	for _, para := range s.TextBody.Paragraphs {
+		var lineBuilder strings.Builder
+		for _, run := range para.Runs {
+			lineBuilder.WriteString(run.Text)
+		}
+		if line := lineBuilder.String(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// ShapeAltText 形状、图片或图形框的替代文字（cNvPr 的 title 和 descr 属性）
+type ShapeAltText struct {
+	Title       string `xml:"title,attr"`
+	Description string `xml:"descr,attr"`
+}
+
+// text 返回替代文字：标题和说明都存在时以 ": " 连接，否则返回非空的一项
+func (a ShapeAltText) text() string {
+	title := strings.TrimSpace(a.Title)
+	descr := strings.TrimSpace(a.Description)
+	switch {
+	case title != "" && descr != "":
+		return title + ": " + descr
+	case title != "":
+		return title
+	default:
+		return descr
+	}
 }
 
 // PresentationProps 表示演示文稿属性
@@ -41,263 +129,794 @@ type PresentationProps struct {
 	Modified string   `xml:"modified"`
 }
 
-// ReadText 读取 PPTX 文件的文本内容
-func (r *PptxReader) ReadText(filePath string) (string, error) {
-	// 打开 zip 文件
-	zipReader, err := zip.OpenReader(filePath)
+// Section 演示文稿中的一个节
+type Section struct {
+	// Name 节名称
+	Name string
+
+	// SlideIndices 节中幻灯片的索引（从 0 开始），按演示顺序排列
+	SlideIndices []int
+}
+
+// pptxPresentation ppt/presentation.xml 中的幻灯片列表和节列表
+type pptxPresentation struct {
+	SlideIDs   []pptxSlideID `xml:"sldIdLst>sldId"`
+	Extensions []struct {
+		Sections []struct {
+			Name     string `xml:"name,attr"`
+			SlideIDs []struct {
+				ID string `xml:"id,attr"`
+			} `xml:"sldIdLst>sldId"`
+		} `xml:"sectionLst>section"`
+	} `xml:"extLst>ext"`
+}
+
+// pptxSlideID 幻灯片列表中的一项，同时带有 id 和 r:id 两个本地名相同的属性
+type pptxSlideID struct {
+	Attrs []xml.Attr `xml:",any,attr"`
+}
+
+// id 返回幻灯片编号（无命名空间的 id 属性）
+func (s pptxSlideID) id() string {
+	for _, attr := range s.Attrs {
+		if attr.Name.Local == "id" && attr.Name.Space == "" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// relationshipID 返回指向幻灯片部件的关系编号（r:id 属性）
+func (s pptxSlideID) relationshipID() string {
+	for _, attr := range s.Attrs {
+		if attr.Name.Local == "id" && attr.Name.Space != "" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// pptxRelationships 部件关系文件（.rels）
+type pptxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// resolvePptxTarget 将 presentation.xml 的关系目标转换为 zip 条目名称
+// 相对目标以 ppt/ 为基准，以 "/" 开头的目标是包内绝对路径
+func resolvePptxTarget(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join("ppt", target)
+}
+
+// OpenPptx 打开 PPTX 文件，返回可复用的 OpenedPptx
+func OpenPptx(filePath string) (*OpenedPptx, error) {
+	return (&fileSource{}).openPptx(filePath)
+}
+
+// openPptx 从读取器的文件来源打开 PPTX 文件
+func (s *fileSource) openPptx(filePath string) (*OpenedPptx, error) {
+	zipReader, err := s.openZip(filePath)
 	if err != nil {
-		return "", WrapError("PptxReader.ReadText", filePath, ErrFileOpen)
+		return nil, WrapError("OpenPptx", filePath, ErrFileOpen)
 	}
-	defer zipReader.Close()
 
-	var builder strings.Builder
-	slideNum := 1
-
-	// 遍历所有文件，查找幻灯片
-	for _, file := range zipReader.File {
-		// 检查是否是幻灯片文件
-		if strings.HasPrefix(file.Name, "ppt/slides/slide") && strings.HasSuffix(file.Name, ".xml") {
-			// 读取幻灯片内容
-			rc, err := file.Open()
-			if err != nil {
-				continue
+	return &OpenedPptx{
+		filePath:  filePath,
+		zipReader: zipReader,
+	}, nil
+}
+
+// Close 关闭底层文件
+func (p *OpenedPptx) Close() error {
+	return p.zipReader.Close()
+}
+
+// parsedSlides 返回解析后的幻灯片，只在首次成功解析时缓存
+func (p *OpenedPptx) parsedSlides(limits zipLimits) ([]Slide, error) {
+	if !p.slidesParsed {
+		var slides []Slide
+		err := p.forEachSlide(limits, func(slide Slide) bool {
+			slides = append(slides, slide)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		p.slides = slides
+		p.slidesParsed = true
+	}
+	return p.slides, nil
+}
+
+// forEachSlide 按顺序遍历幻灯片，fn 返回 false 时停止遍历
+// 已缓存解析结果时直接使用缓存，否则逐张解析（不写入缓存）
+// 无法读取或解析的幻灯片不会中止遍历，而是以设置了 err 的空幻灯片传给 fn；超过资源限制时返回 ErrFileTooLarge
+func (p *OpenedPptx) forEachSlide(limits zipLimits, fn func(slide Slide) bool) error {
+	if p.slidesParsed {
+		if limits.tooManyParts(len(p.slides)) {
+			return ErrFileTooLarge
+		}
+		for _, slide := range p.slides {
+			if !fn(slide) {
+				return nil
 			}
+		}
+		return nil
+	}
 
-			slideXML, err := io.ReadAll(rc)
-			rc.Close()
-			if err != nil {
-				continue
+	count := 0
+	for _, file := range p.zipReader.File {
+		if isSlidePart(file.Name) {
+			count++
+			if limits.tooManyParts(count) {
+				return ErrFileTooLarge
 			}
 
-			// 解析 XML
 			var slide Slide
-			if err := xml.Unmarshal(slideXML, &slide); err != nil {
-				continue
+			slideXML, err := readZipFile(file, limits)
+			if errors.Is(err, ErrFileTooLarge) {
+				return err
 			}
-
-			// 提取文本
-			builder.WriteString(fmt.Sprintf("\n=== 幻灯片 %d ===\n\n", slideNum))
-
-			for _, shape := range slide.CommonSld.ShapeTree.Shapes {
-				for _, para := range shape.TextBody.Paragraphs {
-					for _, run := range para.Runs {
-						builder.WriteString(run.Text)
-					}
-					builder.WriteString("\n")
-				}
+			if err != nil {
+				slide = Slide{err: err}
+			} else if err := xml.Unmarshal(slideXML, &slide); err != nil {
+				slide = Slide{err: ErrFileParse}
 			}
+			slide.partName = file.Name
 
-			slideNum++
+			if !fn(slide) {
+				return nil
+			}
 		}
 	}
+	return nil
+}
 
-	if slideNum == 1 {
-		return "", WrapError("PptxReader.ReadText", filePath, ErrEmptyFile)
+// ReadText 读取 PPTX 文件的文本内容
+func (p *OpenedPptx) ReadText() (string, error) {
+	var builder strings.Builder
+	if err := p.writeText(&builder); err != nil {
+		return "", err
 	}
-
 	return builder.String(), nil
 }
 
+// writeText 逐张将幻灯片文本写入 w，写入出错时停止解析后续幻灯片
+// 无法读取或解析的幻灯片被跳过，其余幻灯片的编号不变；所有幻灯片都无法读取时返回第一张幻灯片的错误
+func (p *OpenedPptx) writeText(w io.Writer) error {
+	tw := newTextWriter(w)
+	slideNum := 0
+	written := 0
+	var firstErr error
+
+	err := p.forEachSlide(newZipLimits(nil), func(slide Slide) bool {
+		slideNum++
+		if slide.err != nil {
+			logWarn("skipped slide", "op", "PptxReader.ReadText", "file", p.filePath, "slide", slideNum, "error", slide.err)
+			firstErr = cmp.Or(firstErr, slide.err)
+			return true
+		}
+
+		// 提取文本
+		tw.WriteString(fmt.Sprintf("\n=== 幻灯片 %d ===\n\n", slideNum))
+		tw.WriteString(slideText(slide))
+		written++
+		return tw.err == nil
+	})
+
+	if tw.err != nil {
+		return tw.err
+	}
+	if err != nil {
+		return WrapError("PptxReader.ReadText", p.filePath, err)
+	}
+	if written == 0 {
+		// 所有幻灯片都无法读取时返回第一张的错误，而不是 ErrEmptyFile
+		return WrapError("PptxReader.ReadText", p.filePath, cmp.Or(firstErr, ErrEmptyFile))
+	}
+	return nil
+}
+
 // GetMetadata 获取 PPTX 文件的元数据
-func (r *PptxReader) GetMetadata(filePath string) (map[string]string, error) {
-	zipReader, err := zip.OpenReader(filePath)
+func (p *OpenedPptx) GetMetadata() (map[string]string, error) {
+	metadata, err := p.metadata()
 	if err != nil {
-		return nil, WrapError("PptxReader.GetMetadata", filePath, ErrFileOpen)
+		return nil, WrapError("PptxReader.GetMetadata", p.filePath, err)
 	}
-	defer zipReader.Close()
+	return metadata, nil
+}
 
+// metadata 提取 PPTX 元数据
+// docProps/core.xml 和 docProps/app.xml 都是可选部件，缺失时忽略；存在但无法读取时返回 ErrFileRead，
+// 此时返回的元数据仍包含其余可以读取的属性和幻灯片数量
+func (p *OpenedPptx) metadata() (map[string]string, error) {
 	metadata := make(map[string]string)
 
 	// 读取核心属性
-	for _, file := range zipReader.File {
-		if file.Name == "docProps/core.xml" {
-			rc, err := file.Open()
-			if err != nil {
-				continue
-			}
-
-			data, err := io.ReadAll(rc)
-			rc.Close()
-			if err != nil {
-				continue
-			}
-
-			var props PresentationProps
-			if err := xml.Unmarshal(data, &props); err == nil {
-				metadata["title"] = props.Title
-				metadata["subject"] = props.Subject
-				metadata["creator"] = props.Creator
-				metadata["keywords"] = props.Keywords
-				metadata["created"] = props.Created
-				metadata["modified"] = props.Modified
-			}
-			break
+	data, readErr := readZipPart(p.zipReader.Reader, "docProps/core.xml", newZipLimits(nil))
+	if errors.Is(readErr, ErrInvalidFormat) {
+		readErr = nil
+	}
+	if readErr == nil {
+		var props PresentationProps
+		if err := xml.Unmarshal(data, &props); err == nil {
+			metadata["title"] = props.Title
+			metadata["subject"] = props.Subject
+			metadata["creator"] = props.Creator
+			metadata["keywords"] = props.Keywords
+			metadata["created"] = props.Created
+			metadata["modified"] = props.Modified
 		}
 	}
 
+	// 读取扩展属性（公司、应用程序等）
+	appProps, err := readAppProperties(p.zipReader.Reader, newZipLimits(nil))
+	if err != nil {
+		readErr = err
+	} else {
+		appProps.addTo(metadata)
+	}
+
 	// 统计幻灯片数量
 	slideCount := 0
-	for _, file := range zipReader.File {
+	for _, file := range p.zipReader.File {
 		if matched, _ := filepath.Match("ppt/slides/slide*.xml", file.Name); matched {
 			slideCount++
 		}
 	}
 	metadata["slide_count"] = fmt.Sprintf("%d", slideCount)
+	metadata["section_count"] = metadata["slide_count"]
 
-	return metadata, nil
+	return metadata, readErr
 }
 
-// GetSlides 获取所有幻灯片的文本内容（按幻灯片分组）
-func (r *PptxReader) GetSlides(filePath string) ([]string, error) {
-	zipReader, err := zip.OpenReader(filePath)
+// GetSlides 获取所有幻灯片的文本内容（按幻灯片分组），没有幻灯片时返回空切片（不是 nil）
+// 无法读取或解析的幻灯片对应空字符串，保持索引与幻灯片顺序一致
+func (p *OpenedPptx) GetSlides() ([]string, error) {
+	parsed, err := p.parsedSlides(newZipLimits(nil))
 	if err != nil {
-		return nil, WrapError("PptxReader.GetSlides", filePath, ErrFileOpen)
+		return nil, WrapError("PptxReader.GetSlides", p.filePath, err)
 	}
-	defer zipReader.Close()
-
-	var slides []string
-
-	for _, file := range zipReader.File {
-		if strings.HasPrefix(file.Name, "ppt/slides/slide") && strings.HasSuffix(file.Name, ".xml") {
-			rc, err := file.Open()
-			if err != nil {
-				continue
-			}
 
-			slideXML, err := io.ReadAll(rc)
-			rc.Close()
-			if err != nil {
-				continue
-			}
-
-			var slide Slide
-			if err := xml.Unmarshal(slideXML, &slide); err != nil {
-				continue
-			}
-
-			var builder strings.Builder
-			for _, shape := range slide.CommonSld.ShapeTree.Shapes {
-				for _, para := range shape.TextBody.Paragraphs {
-					for _, run := range para.Runs {
-						builder.WriteString(run.Text)
-					}
-					builder.WriteString("\n")
-				}
-			}
+	slides := make([]string, 0, len(parsed))
+	for _, slide := range parsed {
+		slides = append(slides, slideText(slide))
+	}
+	return slides, nil
+}
 
-			slides = append(slides, builder.String())
-		}
+// GetAltTexts 获取每张幻灯片中形状和图片的替代文字，外层切片按幻灯片顺序排列
+// 没有替代文字或无法读取的幻灯片对应空切片
+func (p *OpenedPptx) GetAltTexts() ([][]string, error) {
+	parsed, err := p.parsedSlides(newZipLimits(nil))
+	if err != nil {
+		return nil, WrapError("PptxReader.GetAltTexts", p.filePath, err)
 	}
 
-	return slides, nil
+	altTexts := make([][]string, 0, len(parsed))
+	for _, slide := range parsed {
+		altTexts = append(altTexts, slideAltTexts(slide))
+	}
+	return altTexts, nil
 }
 
-// ReadWithConfig 根据配置读取 PPTX 文件，返回结构化结果
-func (r *PptxReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
-	zipReader, err := zip.OpenReader(filePath)
+// GetSections 获取演示文稿的节（ppt/presentation.xml 中的 sectionLst）
+// 每个节的 SlideIndices 是从 0 开始的幻灯片索引，与 DocumentResult 的页码一致，可直接用于 WithPages；
+// 演示文稿没有定义节时返回空切片。presentation.xml 缺失时返回 ErrInvalidFormat
+func (p *OpenedPptx) GetSections() ([]Section, error) {
+	limits := newZipLimits(nil)
+	data, err := readZipPart(p.zipReader.Reader, "ppt/presentation.xml", limits)
 	if err != nil {
-		return nil, WrapError("PptxReader.ReadWithConfig", filePath, ErrFileOpen)
+		return nil, WrapError("PptxReader.GetSections", p.filePath, err)
+	}
+	var presentation pptxPresentation
+	if err := xml.Unmarshal(data, &presentation); err != nil {
+		return nil, WrapError("PptxReader.GetSections", p.filePath, ErrFileParse)
 	}
-	defer zipReader.Close()
 
-	// 先获取所有幻灯片
-	type slideData struct {
-		index   int
-		content string
-		lines   []string
+	// 关系文件把 r:id 映射到幻灯片部件；缺失时无法定位幻灯片
+	data, err = readZipPart(p.zipReader.Reader, "ppt/_rels/presentation.xml.rels", limits)
+	if err != nil {
+		return nil, WrapError("PptxReader.GetSections", p.filePath, err)
+	}
+	var rels pptxRelationships
+	if err := xml.Unmarshal(data, &rels); err != nil {
+		return nil, WrapError("PptxReader.GetSections", p.filePath, ErrFileParse)
+	}
+	targets := make(map[string]string, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		targets[rel.ID] = resolvePptxTarget(rel.Target)
 	}
 
-	allSlides := make([]slideData, 0)
+	// 幻灯片索引按读取器遍历幻灯片部件的顺序计算
+	partIndexes := make(map[string]int)
+	for _, file := range p.zipReader.File {
+		if isSlidePart(file.Name) {
+			partIndexes[file.Name] = len(partIndexes)
+		}
+	}
 
-	for _, file := range zipReader.File {
-		if strings.HasPrefix(file.Name, "ppt/slides/slide") && strings.HasSuffix(file.Name, ".xml") {
-			rc, err := file.Open()
-			if err != nil {
-				continue
+	slideIndexes := make(map[string]int, len(presentation.SlideIDs))
+	for _, slideID := range presentation.SlideIDs {
+		if index, ok := partIndexes[targets[slideID.relationshipID()]]; ok {
+			slideIndexes[slideID.id()] = index
+		}
+	}
+
+	sections := make([]Section, 0)
+	for _, ext := range presentation.Extensions {
+		for _, section := range ext.Sections {
+			indices := make([]int, 0, len(section.SlideIDs))
+			for _, slideID := range section.SlideIDs {
+				if index, ok := slideIndexes[slideID.ID]; ok {
+					indices = append(indices, index)
+				}
 			}
+			sections = append(sections, Section{Name: section.Name, SlideIndices: indices})
+		}
+	}
+	return sections, nil
+}
 
-			slideXML, err := io.ReadAll(rc)
-			rc.Close()
+// GetSlideXML 读取第 slideIndex 张幻灯片（从0开始，与 DocumentResult 的页码一致）部件的原始内容，用于排查文本提取的问题
+// 索引超出范围时返回 ErrPageNotFound，数据损坏无法读取时返回 ErrFileRead，解压后超过默认大小限制时返回 ErrFileTooLarge
+func (p *OpenedPptx) GetSlideXML(slideIndex int) ([]byte, error) {
+	count := 0
+	for _, file := range p.zipReader.File {
+		if !isSlidePart(file.Name) {
+			continue
+		}
+		if count == slideIndex {
+			data, err := readZipFile(file, newZipLimits(nil))
 			if err != nil {
-				continue
+				return nil, WrapError("PptxReader.GetSlideXML", p.filePath, err)
 			}
+			return data, nil
+		}
+		count++
+	}
+	return nil, WrapError("PptxReader.GetSlideXML", p.filePath, ErrPageNotFound)
+}
 
-			var slide Slide
-			if err := xml.Unmarshal(slideXML, &slide); err != nil {
-				continue
+// ReadWithConfig 根据配置读取 PPTX 文件，返回结构化结果
+// 尚未缓存解析结果时只解析到最后一张选中的幻灯片，之后的幻灯片不会被读取
+func (p *OpenedPptx) ReadWithConfig(config *ReadConfig) (*DocumentResult, error) {
+	limits := newZipLimits(config)
+
+	totalSlides := len(p.slides)
+	if !p.slidesParsed {
+		totalSlides = 0
+		for _, file := range p.zipReader.File {
+			if isSlidePart(file.Name) {
+				totalSlides++
 			}
+		}
+		if limits.tooManyParts(totalSlides) {
+			return nil, WrapError("PptxReader.ReadWithConfig", p.filePath, ErrFileTooLarge)
+		}
+	}
 
-			lines := make([]string, 0)
-			for _, shape := range slide.CommonSld.ShapeTree.Shapes {
-				for _, para := range shape.TextBody.Paragraphs {
-					var lineBuilder strings.Builder
-					for _, run := range para.Runs {
-						lineBuilder.WriteString(run.Text)
-					}
-					line := lineBuilder.String()
-					if line != "" {
-						lines = append(lines, line)
-					}
-				}
+	// 只需要元数据时不解析幻灯片
+	if metadataOnly(config) {
+		result := &DocumentResult{FilePath: p.filePath, TotalPages: totalSlides}
+		metadata, err := p.metadata()
+		if err != nil {
+			if err := result.partialFailure(config, "PptxReader.ReadWithConfig", "metadata: %v", err); err != nil {
+				return nil, err
 			}
-
-			allSlides = append(allSlides, slideData{
-				index:   len(allSlides),
-				lines:   lines,
-				content: strings.Join(lines, "\n"),
-			})
 		}
+		result.Metadata = metadata
+		return result, nil
 	}
 
-	totalSlides := len(allSlides)
+	// 确定要读取的幻灯片和每页的行配置
+	pageLineMap := buildPageLineMap(config, totalSlides)
+	lastSlide := -1
+	for slideIndex := range pageLineMap {
+		lastSlide = max(lastSlide, slideIndex)
+	}
+
+	var parsed []Slide
+	var err error
+	if lastSlide == totalSlides-1 {
+		parsed, err = p.parsedSlides(limits)
+	} else {
+		err = p.forEachSlide(limits, func(slide Slide) bool {
+			parsed = append(parsed, slide)
+			return len(parsed) <= lastSlide
+		})
+	}
+	if err != nil {
+		return nil, WrapError("PptxReader.ReadWithConfig", p.filePath, err)
+	}
 
 	result := &DocumentResult{
-		FilePath:   filePath,
+		FilePath:   p.filePath,
 		TotalPages: totalSlides,
 		Pages:      make([]PageContent, 0),
 		Metadata:   make(map[string]string),
+		layout:     layoutSlides,
 	}
 
-	// 获取元数据
-	metadata, _ := r.GetMetadata(filePath)
-	result.Metadata = metadata
+	// 获取元数据（复用已打开的 zip 包）
+	// 元数据部件是可选的，无法读取时记录警告并继续读取幻灯片
+	result.Metadata, err = p.metadata()
+	if err != nil {
+		if err := result.partialFailure(config, "PptxReader.ReadWithConfig", "metadata: %v", err); err != nil {
+			return nil, err
+		}
+	}
 
-	// 确定要读取的幻灯片和每页的行配置
-	pageLineMap := buildPageLineMap(config, totalSlides)
+	// 需要时在每张幻灯片的文本之后追加替代文字
+	includeAltText := config != nil && config.IncludeAltText
+
+	// 需要时合并版式和母版中的文本
+	var masterText *pptxMasterText
+	if config != nil && config.IncludeMasterText {
+		masterText = newPptxMasterText(p.zipReader.Reader, limits)
+	}
 
-	var contentBuilder strings.Builder
 	totalLines := 0
+	processed := 0
 
-	for slideIndex := 0; slideIndex < totalSlides; slideIndex++ {
+	for slideIndex := 0; slideIndex < len(parsed); slideIndex++ {
 		lineConfig, shouldRead := pageLineMap[slideIndex]
 		if !shouldRead {
 			continue
 		}
+		processed++
 
-		slide := allSlides[slideIndex]
-
-		// 根据该页的配置筛选行
-		filteredLines := filterLinesForPage(slide.lines, lineConfig)
+		// 无法读取或解析的幻灯片被跳过，其余幻灯片照常读取
+		if slideErr := parsed[slideIndex].err; slideErr != nil {
+			if err := result.partialFailure(config, "PptxReader.ReadWithConfig", "slide %d: %v", slideIndex+1, slideErr); err != nil {
+				return nil, err
+			}
+			reportProgress(config, processed, len(pageLineMap))
+			continue
+		}
 
-		pageContent := PageContent{
-			PageNumber: slideIndex,
-			Lines:      filteredLines,
-			TotalLines: len(filteredLines),
+		lines := slideLines(parsed[slideIndex])
+		if masterText != nil {
+			lines, err = masterText.lines(parsed[slideIndex])
+			if err != nil {
+				return nil, WrapError("PptxReader.ReadWithConfig", p.filePath, err)
+			}
 		}
+		if includeAltText {
+			lines = append(lines, slideAltTexts(parsed[slideIndex])...)
+		}
+
+		// 根据该页的配置筛选行
+		pageContent := newPageContent(slideIndex, lines, lineConfig, config)
 
 		result.Pages = append(result.Pages, pageContent)
-		totalLines += len(filteredLines)
+		totalLines += pageContent.TotalLines
 
-		// 构建完整内容
-		contentBuilder.WriteString(fmt.Sprintf("\n=== 幻灯片 %d ===\n\n", slideIndex))
-		for _, line := range filteredLines {
-			contentBuilder.WriteString(line)
-			contentBuilder.WriteString("\n")
-		}
+		reportProgress(config, processed, len(pageLineMap))
 	}
 
 	result.TotalLines = totalLines
-	result.Content = contentBuilder.String()
+	result.finish(config)
 
 	return result, nil
 }
+
+// isSlidePart 判断 zip 条目是否为幻灯片部件
+func isSlidePart(name string) bool {
+	return strings.HasPrefix(name, "ppt/slides/slide") && strings.HasSuffix(name, ".xml")
+}
+
+// slideText 提取幻灯片的全部文本，每个段落一行（保留空段落）
+func slideText(slide Slide) string {
+	var builder strings.Builder
+	for _, shape := range slide.CommonSld.ShapeTree.Shapes {
+		for _, para := range shape.TextBody.Paragraphs {
+			for _, run := range para.Runs {
+				builder.WriteString(run.Text)
+			}
+			builder.WriteString("\n")
+		}
+	}
+	return builder.String()
+}
+
+// slideLines 提取幻灯片的非空段落，每个段落作为一行
+func slideLines(slide Slide) []string {
+	lines := make([]string, 0)
+	for _, shape := range slide.CommonSld.ShapeTree.Shapes {
+		lines = append(lines, shape.lines()...)
+	}
+	return lines
+}
+
+// slideAltTexts 按形状、图片、图形框的顺序提取幻灯片中非空的替代文字
+func slideAltTexts(slide Slide) []string {
+	tree := slide.CommonSld.ShapeTree
+	props := make([]ShapeAltText, 0, len(tree.Shapes)+len(tree.Pictures)+len(tree.GraphicFrames))
+	for _, shape := range tree.Shapes {
+		props = append(props, shape.NonVisual.Props)
+	}
+	for _, picture := range tree.Pictures {
+		props = append(props, picture.NonVisual.Props)
+	}
+	for _, frame := range tree.GraphicFrames {
+		props = append(props, frame.NonVisual.Props)
+	}
+
+	texts := make([]string, 0)
+	for _, prop := range props {
+		if text := prop.text(); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return texts
+}
+
+// pptxInheritedPlaceholders 幻灯片中为空时从版式和母版继承文本的占位符类型：日期、页脚、页眉和幻灯片编号
+// 标题和正文占位符在版式中只有“单击此处编辑母版标题样式”这样的提示文字，不会继承
+var pptxInheritedPlaceholders = map[string]bool{"dt": true, "ftr": true, "hdr": true, "sldNum": true}
+
+// pptxMasterText 沿幻灯片 → 版式（slideLayout）→ 母版（slideMaster）的关系链读取 ReadConfig.IncludeMasterText 需要的文本
+// 解析过的版式和母版按部件名称缓存
+type pptxMasterText struct {
+	zipReader *zip.Reader
+	limits    zipLimits
+	templates map[string]*Slide
+}
+
+// newPptxMasterText 创建版式和母版文本的解析器
+func newPptxMasterText(zipReader *zip.Reader, limits zipLimits) *pptxMasterText {
+	return &pptxMasterText{
+		zipReader: zipReader,
+		limits:    limits,
+		templates: make(map[string]*Slide),
+	}
+}
+
+// lines 返回合并了版式和母版文本的幻灯片行：
+// 幻灯片中为空的日期、页脚等占位符使用版式（其次是母版）中同类型占位符的文本，
+// 之后依次追加母版和版式中非占位符形状的文本（如保密声明横幅），showMasterSp 为 "0" 时不追加被隐藏的部分
+// 缺少关系文件、版式或母版时忽略对应的文本；部件无法读取时返回 ErrFileRead，无法解析时返回 ErrFileParse
+func (m *pptxMasterText) lines(slide Slide) ([]string, error) {
+	layoutName, err := m.relatedPart(slide.partName, "slideLayout")
+	if err != nil {
+		return nil, err
+	}
+	layout, err := m.template(layoutName)
+	if err != nil {
+		return nil, err
+	}
+	var master *Slide
+	if layout != nil {
+		masterName, err := m.relatedPart(layoutName, "slideMaster")
+		if err != nil {
+			return nil, err
+		}
+		if master, err = m.template(masterName); err != nil {
+			return nil, err
+		}
+	}
+
+	lines := make([]string, 0)
+	for _, shape := range slide.CommonSld.ShapeTree.Shapes {
+		shapeLines := shape.lines()
+		if placeholder := shape.NonVisual.Placeholder; len(shapeLines) == 0 && placeholder != nil && pptxInheritedPlaceholders[placeholder.Type] {
+			shapeLines = placeholderLines(layout, placeholder.Type)
+			if len(shapeLines) == 0 {
+				shapeLines = placeholderLines(master, placeholder.Type)
+			}
+		}
+		lines = append(lines, shapeLines...)
+	}
+
+	if layout != nil && showsMasterShapes(slide.ShowMasterSp) {
+		if master != nil && showsMasterShapes(layout.ShowMasterSp) {
+			lines = append(lines, staticShapeLines(master)...)
+		}
+		lines = append(lines, staticShapeLines(layout)...)
+	}
+	return lines, nil
+}
+
+// relatedPart 返回部件关系文件中第一个类型为 relType 的关系指向的部件名称，没有关系文件或关系时返回空字符串
+func (m *pptxMasterText) relatedPart(partName, relType string) (string, error) {
+	if partName == "" {
+		return "", nil
+	}
+	dir := path.Dir(partName)
+	data, err := readZipPart(m.zipReader, path.Join(dir, "_rels", path.Base(partName)+".rels"), m.limits)
+	if errors.Is(err, ErrInvalidFormat) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var rels pptxRelationships
+	if err := xml.Unmarshal(data, &rels); err != nil {
+		return "", ErrFileParse
+	}
+	for _, rel := range rels.Relationships {
+		if strings.HasSuffix(rel.Type, "/"+relType) {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), nil
+			}
+			return path.Join(dir, rel.Target), nil
+		}
+	}
+	return "", nil
+}
+
+// template 读取并解析版式或母版部件，部件名称为空或部件不存在时返回 nil
+// 版式（sldLayout）和母版（sldMaster）的 cSld 与幻灯片结构相同，解析时将根元素视为 sld 以复用 Slide
+func (m *pptxMasterText) template(partName string) (*Slide, error) {
+	if partName == "" {
+		return nil, nil
+	}
+	if template, ok := m.templates[partName]; ok {
+		return template, nil
+	}
+
+	data, err := readZipPart(m.zipReader, partName, m.limits)
+	if errors.Is(err, ErrInvalidFormat) {
+		m.templates[partName] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var template Slide
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, ErrFileParse
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			start.Name.Local = "sld"
+			if err := decoder.DecodeElement(&template, &start); err != nil {
+				return nil, ErrFileParse
+			}
+			break
+		}
+	}
+
+	template.partName = partName
+	m.templates[partName] = &template
+	return &template, nil
+}
+
+// placeholderLines 返回版式或母版中第一个类型为 placeholderType 且有文本的占位符的行
+func placeholderLines(template *Slide, placeholderType string) []string {
+	if template == nil {
+		return nil
+	}
+	for _, shape := range template.CommonSld.ShapeTree.Shapes {
+		if placeholder := shape.NonVisual.Placeholder; placeholder != nil && placeholder.Type == placeholderType {
+			if lines := shape.lines(); len(lines) > 0 {
+				return lines
+			}
+		}
+	}
+	return nil
+}
+
+// staticShapeLines 返回版式或母版中非占位符形状的行，这些形状会显示在使用该版式的每一张幻灯片上
+func staticShapeLines(template *Slide) []string {
+	lines := make([]string, 0)
+	for _, shape := range template.CommonSld.ShapeTree.Shapes {
+		if shape.NonVisual.Placeholder == nil {
+			lines = append(lines, shape.lines()...)
+		}
+	}
+	return lines
+}
+
+// showsMasterShapes 判断 showMasterSp 属性是否允许显示上一级的非占位符形状，默认显示
+func showsMasterShapes(value string) bool {
+	return value != "0" && value != "false"
+}
+
+// ReadText 读取 PPTX 文件的文本内容
+func (r *PptxReader) ReadText(filePath string) (string, error) {
+	var builder strings.Builder
+	if err := r.writeText(&builder, filePath); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// writeText 逐张将幻灯片文本写入 w
+func (r *PptxReader) writeText(w io.Writer, filePath string) error {
+	opened, err := r.openPptx(filePath)
+	if err != nil {
+		return WrapError("PptxReader.ReadText", filePath, ErrFileOpen)
+	}
+	defer opened.Close()
+
+	return opened.writeText(w)
+}
+
+// GetMetadata 获取 PPTX 文件的元数据
+func (r *PptxReader) GetMetadata(filePath string) (map[string]string, error) {
+	opened, err := r.openPptx(filePath)
+	if err != nil {
+		return nil, WrapError("PptxReader.GetMetadata", filePath, ErrFileOpen)
+	}
+	defer opened.Close()
+
+	return opened.GetMetadata()
+}
+
+// GetSlides 获取所有幻灯片的文本内容（按幻灯片分组），没有幻灯片时返回空切片（不是 nil）
+func (r *PptxReader) GetSlides(filePath string) ([]string, error) {
+	opened, err := r.openPptx(filePath)
+	if err != nil {
+		return nil, WrapError("PptxReader.GetSlides", filePath, ErrFileOpen)
+	}
+	defer opened.Close()
+
+	return opened.GetSlides()
+}
+
+// GetAltTexts 获取每张幻灯片中形状和图片的替代文字（无障碍说明）
+func (r *PptxReader) GetAltTexts(filePath string) ([][]string, error) {
+	opened, err := r.openPptx(filePath)
+	if err != nil {
+		return nil, WrapError("PptxReader.GetAltTexts", filePath, ErrFileOpen)
+	}
+	defer opened.Close()
+
+	return opened.GetAltTexts()
+}
+
+// GetSlideXML 读取指定幻灯片（索引从0开始）部件的原始内容，用于排查文本提取的问题
+func (r *PptxReader) GetSlideXML(filePath string, slideIndex int) ([]byte, error) {
+	opened, err := r.openPptx(filePath)
+	if err != nil {
+		return nil, WrapError("PptxReader.GetSlideXML", filePath, ErrFileOpen)
+	}
+	defer opened.Close()
+
+	return opened.GetSlideXML(slideIndex)
+}
+
+// GetSections 获取演示文稿的节及每个节包含的幻灯片索引
+func (r *PptxReader) GetSections(filePath string) ([]Section, error) {
+	opened, err := r.openPptx(filePath)
+	if err != nil {
+		return nil, WrapError("PptxReader.GetSections", filePath, ErrFileOpen)
+	}
+	defer opened.Close()
+
+	return opened.GetSections()
+}
+
+// Capabilities 返回 PPTX 读取器支持的功能：按幻灯片分页，支持替代文字，可以包含图片，元数据包含文档记录的创建和修改时间
+func (r *PptxReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{
+		Pagination:    true,
+		Slides:        true,
+		AltText:       true,
+		Images:        true,
+		MetadataDates: true,
+	}
+}
+
+// ReadWithConfig 根据配置读取 PPTX 文件，返回结构化结果
+func (r *PptxReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
+	opened, err := r.openPptx(filePath)
+	if err != nil {
+		return nil, WrapError("PptxReader.ReadWithConfig", filePath, ErrFileOpen)
+	}
+	defer opened.Close()
+
+	return opened.ReadWithConfig(config)
+}
@@ -0,0 +1,384 @@
+package docreader
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// ReadDocumentRaw 以最快的方式提取文档的纯文本，适合全文索引
+// 与 ReadDocument 不同，结果中不包含页/幻灯片/工作表分隔符、行号前缀或 JSON 路径等装饰，
+// 也不读取元数据：段落、行和记录之间以换行符分隔，同一行的单元格之间以空格分隔
+func ReadDocumentRaw(filePath string) (string, error) {
+	return (&fileSource{}).readRaw(filePath)
+}
+
+// readRaw 从文件来源中提取文档的纯文本，实现 ReadDocumentRaw
+func (s *fileSource) readRaw(filePath string) (string, error) {
+	// 检查文件是否存在
+	if _, err := s.stat(filePath); errors.Is(err, fs.ErrNotExist) {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrFileNotFound)
+	}
+
+	// 支持的格式与 ReadDocument 一致（图片只在设置了 OCR 引擎时才被支持）
+	ext := strings.ToLower(filepath.Ext(filePath))
+	reader := newFormatReader(ext)
+	if reader == nil {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrUnsupportedFormat)
+	}
+
+	// 常见格式使用不生成分隔符和装饰的快速路径，其他格式使用读取器的 ReadText
+	switch ext {
+	case ".docx":
+		return s.rawDocxText(filePath)
+	case ".pdf":
+		return s.rawPdfText(filePath)
+	case ".xlsx":
+		return s.rawXlsxText(filePath)
+	case ".pptx":
+		return s.rawPptxText(filePath)
+	case ".txt", ".md", ".markdown":
+		return s.rawPlainText(filePath)
+	case ".csv":
+		return s.rawCsvText(filePath, ',')
+	case ".tsv":
+		return s.rawCsvText(filePath, '\t')
+	case ".json", ".jsonl":
+		return s.rawJSONText(filePath)
+	case ".xml":
+		return s.rawXMLText(filePath)
+	case ".pages", ".numbers", ".key":
+		return s.rawIworkText(filePath)
+	case ".zip":
+		return s.rawZipText(filePath)
+	default:
+		useFileSystem(reader, s.fsys)
+		return reader.ReadText(filePath)
+	}
+}
+
+// rawIworkText 提取 iWork 包中预览 PDF 的纯文本
+func (s *fileSource) rawIworkText(filePath string) (string, error) {
+	var text string
+	err := s.withIworkPreview(filePath, "ReadDocumentRaw", newZipLimits(nil), func(preview fileSource, previewName string) error {
+		var err error
+		text, err = preview.rawPdfText(previewName)
+		return err
+	})
+	return text, err
+}
+
+// rawZipText 依次提取压缩包中每个受支持文件的纯文本，文件之间以空行分隔，读取失败的文件被跳过
+// 所有文件解压后的总大小超过默认的 MaxDecompressedSize 时返回 ErrFileTooLarge
+func (s *fileSource) rawZipText(filePath string) (string, error) {
+	zipReader, err := s.openZip(filePath)
+	if err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	limits := newZipLimits(nil)
+	budget := newZipBudget(limits)
+	entries := zipDocumentEntries(zipReader.Reader)
+	if limits.tooManyParts(len(entries)) {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrFileTooLarge)
+	}
+
+	texts := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		data, err := budget.read(entry)
+		if errors.Is(err, ErrFileTooLarge) {
+			return "", WrapError("ReadDocumentRaw", filePath, ErrFileTooLarge)
+		}
+		if err != nil {
+			continue
+		}
+		fsys, fileName := newMemoryFS(entry.Name, data)
+		if text, err := (&fileSource{fsys: fsys}).readRaw(fileName); err == nil {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, "\n\n"), nil
+}
+
+// rawPlainText 直接返回文件内容（去掉开头的 UTF-8 BOM），只分配一次
+func (s *fileSource) rawPlainText(filePath string) (string, error) {
+	data, err := s.readFile(filePath)
+	if err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrFileRead)
+	}
+	return string(trimBOM(data)), nil
+}
+
+// rawCsvText 输出所有单元格，单元格之间以空格分隔，每条记录一行
+func (s *fileSource) rawCsvText(filePath string, comma rune) (string, error) {
+	data, err := s.readFile(filePath)
+	if err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrFileRead)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(trimBOM(data)))
+	reader.Comma = comma
+	reader.ReuseRecord = true
+
+	var builder strings.Builder
+	builder.Grow(len(data))
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", WrapError("ReadDocumentRaw", filePath, ErrFileRead)
+		}
+		writeRawCells(&builder, record)
+	}
+
+	return builder.String(), nil
+}
+
+// writeRawCells 写入一行中的非空单元格，单元格之间以空格分隔；整行为空时不输出
+func writeRawCells(builder *strings.Builder, cells []string) {
+	written := false
+	for _, cell := range cells {
+		if cell == "" {
+			continue
+		}
+		if written {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(cell)
+		written = true
+	}
+	if written {
+		builder.WriteByte('\n')
+	}
+}
+
+// rawXlsxText 使用行迭代器输出所有工作表的单元格，不输出工作表标题
+func (s *fileSource) rawXlsxText(filePath string) (string, error) {
+	f, err := s.openXlsx(filePath, newZipLimits(nil))
+	if err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, err)
+	}
+	defer f.Close()
+
+	var builder strings.Builder
+	for _, sheetName := range f.GetSheetList() {
+		rows, err := f.Rows(sheetName)
+		if err != nil {
+			logWarn("skipped sheet", "op", "ReadDocumentRaw", "file", filePath, "sheet", sheetName, "error", err)
+			continue
+		}
+		for rows.Next() {
+			cells, err := rows.Columns()
+			if err != nil {
+				logWarn("stopped reading sheet", "op", "ReadDocumentRaw", "file", filePath, "sheet", sheetName, "error", err)
+				break
+			}
+			writeRawCells(&builder, cells)
+		}
+		rows.Close()
+	}
+
+	return builder.String(), nil
+}
+
+// rawPdfText 依次输出每一页的文本，不添加页码分隔符
+func (s *fileSource) rawPdfText(filePath string) (string, error) {
+	f, reader, err := s.openPdf(filePath)
+	if err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrFileOpen)
+	}
+	defer f.Close()
+
+	var builder strings.Builder
+	for pageNum := 1; pageNum <= reader.NumPage(); pageNum++ {
+		page := reader.Page(pageNum)
+		if page.V.IsNull() {
+			continue
+		}
+		text, err := page.GetPlainText(nil)
+		if err != nil {
+			logWarn("skipped page", "op", "ReadDocumentRaw", "file", filePath, "page", pageNum, "error", err)
+			continue
+		}
+		builder.WriteString(text)
+		builder.WriteByte('\n')
+	}
+
+	return builder.String(), nil
+}
+
+// rawDocxText 以流的方式扫描 document.xml，按文档顺序输出段落文本（包括表格中的段落）
+func (s *fileSource) rawDocxText(filePath string) (string, error) {
+	zipReader, err := s.openZip(filePath)
+	if err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	documentXML, err := readZipPart(zipReader.Reader, "word/document.xml", newZipLimits(nil))
+	if err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, err)
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(documentXML) / 4)
+	if err := writeOOXMLText(&builder, documentXML); err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrFileParse)
+	}
+
+	return builder.String(), nil
+}
+
+// rawPptxText 以流的方式扫描每张幻灯片，输出段落文本，不添加幻灯片标题
+func (s *fileSource) rawPptxText(filePath string) (string, error) {
+	zipReader, err := s.openZip(filePath)
+	if err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	limits := newZipLimits(nil)
+	var builder strings.Builder
+	count := 0
+	for _, file := range zipReader.File {
+		if !isSlidePart(file.Name) {
+			continue
+		}
+		count++
+		if limits.tooManyParts(count) {
+			return "", WrapError("ReadDocumentRaw", filePath, ErrFileTooLarge)
+		}
+
+		slideXML, err := readZipFile(file, limits)
+		if err != nil {
+			return "", WrapError("ReadDocumentRaw", filePath, err)
+		}
+		if err := writeOOXMLText(&builder, slideXML); err != nil {
+			return "", WrapError("ReadDocumentRaw", filePath, ErrFileParse)
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// writeOOXMLText 扫描 WordprocessingML/DrawingML 文本：收集 <t> 元素的文本，
+// 在每个非空段落（<p>）结束时换行。不构建完整的结构体，比 xml.Unmarshal 更快
+func writeOOXMLText(builder *strings.Builder, data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	inText := false
+	paragraphHasText := false
+
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "t" {
+				inText = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				if paragraphHasText {
+					builder.WriteByte('\n')
+					paragraphHasText = false
+				}
+			}
+		case xml.CharData:
+			if inText && len(t) > 0 {
+				builder.Write(t)
+				paragraphHasText = true
+			}
+		}
+	}
+}
+
+// rawJSONText 只输出 JSON 的叶子值，每个值一行；.jsonl 的每条记录一行，值之间以空格分隔
+func (s *fileSource) rawJSONText(filePath string) (string, error) {
+	file, err := s.open(filePath)
+	if err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrFileOpen)
+	}
+	defer file.Close()
+
+	var builder strings.Builder
+
+	if !isJSONLines(filePath) {
+		err := decodeJSONDocument(file, func(_, value string) error {
+			builder.WriteString(value)
+			builder.WriteByte('\n')
+			return nil
+		})
+		if err != nil {
+			return "", WrapError("ReadDocumentRaw", filePath, ErrInvalidFormat)
+		}
+		return builder.String(), nil
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return "", WrapError("ReadDocumentRaw", filePath, ErrFileRead)
+		}
+
+		if strings.TrimSpace(line) != "" {
+			first := true
+			err := decodeJSONDocument(strings.NewReader(line), func(_, value string) error {
+				if !first {
+					builder.WriteByte(' ')
+				}
+				builder.WriteString(value)
+				first = false
+				return nil
+			})
+			if err != nil {
+				return "", WrapError("ReadDocumentRaw", filePath, ErrInvalidFormat)
+			}
+			builder.WriteByte('\n')
+		}
+
+		if readErr == io.EOF {
+			return builder.String(), nil
+		}
+	}
+}
+
+// rawXMLText 只输出 XML 叶子元素的文本，每个元素一行
+func (s *fileSource) rawXMLText(filePath string) (string, error) {
+	file, err := s.open(filePath)
+	if err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrFileOpen)
+	}
+	defer file.Close()
+
+	var builder strings.Builder
+	_, err = scanXML(file, func(_, text string) error {
+		builder.WriteString(text)
+		builder.WriteByte('\n')
+		return nil
+	})
+	if err != nil {
+		return "", WrapError("ReadDocumentRaw", filePath, ErrInvalidFormat)
+	}
+
+	return builder.String(), nil
+}
@@ -1,16 +1,32 @@
 package docreader
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // 支持的文档格式列表
-var supportedFormats = []string{".docx", ".pdf", ".xlsx", ".pptx", ".txt", ".csv", ".md", ".markdown", ".rtf"}
+var supportedFormats = []string{".docx", ".pdf", ".xlsx", ".pptx", ".txt", ".csv", ".tsv", ".md", ".markdown", ".rtf", ".json", ".jsonl", ".xml", ".pages", ".numbers", ".key", ".zip"}
 
 // DocumentReader 定义了文档读取器的通用接口
+//
+// 并发安全：内置读取器（DocxReader、PdfReader、XlsxReader 等）只在字段中保存配置（如 CsvReader.Comma），
+// 读取过程中不修改自身状态，也不在读取之间缓存数据，因此同一个读取器实例以及 ReadDocument 等顶层函数
+// 都可以在多个 goroutine 中并发使用，前提是读取期间不修改读取器的字段。
+// OpenedPptx 持有打开的文件和解析缓存，不是并发安全的。以后为读取器加入缓存时必须保持这一保证（见 TestConcurrentReads）
 type DocumentReader interface {
 	// ReadText 读取文档的文本内容
 	ReadText(filePath string) (string, error)
@@ -27,6 +43,52 @@ type ConfigurableReader interface {
 	ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error)
 }
 
+// ReaderCapabilities 描述读取器支持的功能，便于调用方在运行时按功能启用或禁用操作，而不必判断具体的读取器类型
+type ReaderCapabilities struct {
+	// Pagination 内容分为多页（页、幻灯片或工作表），PageSelector 可以选择其中一部分
+	Pagination bool
+
+	// Tables 能够输出表格结构（DOCX 的 TableMode、XLSX 的 GetSheetData、CSV 的 GetRecords、Markdown 的 GetTables）
+	Tables bool
+
+	// Columns 支持 ColumnSelector 和 RawCells 等按列处理的选项
+	Columns bool
+
+	// Sheets 支持按名称选择工作表（SheetNames）
+	Sheets bool
+
+	// Slides 支持幻灯片相关的方法（GetSlides、GetSections）
+	Slides bool
+
+	// Records 内容由独立的记录组成，每条记录是一行（CSV 的行、.jsonl 的每一行）
+	Records bool
+
+	// AltText 能够输出图片和形状的替代文字（IncludeAltText）
+	AltText bool
+
+	// Images 文档中可以嵌入图片（DOCX/PPTX 的媒体部件、PDF 的图像），只含图片的页面提取不到文本
+	Images bool
+
+	// FormFields 能够提取表单字段（GetFormFields）
+	FormFields bool
+
+	// MetadataDates 元数据包含文档自身记录的创建和修改时间，而不只是文件系统的修改时间
+	MetadataDates bool
+}
+
+// CapabilityReporter 由能够报告自身功能的读取器实现，所有内置读取器都实现了该接口
+type CapabilityReporter interface {
+	Capabilities() ReaderCapabilities
+}
+
+// GetCapabilities 返回读取器支持的功能；读取器没有实现 CapabilityReporter 时返回零值（不支持任何可选功能）
+func GetCapabilities(reader DocumentReader) ReaderCapabilities {
+	if reporter, ok := reader.(CapabilityReporter); ok {
+		return reporter.Capabilities()
+	}
+	return ReaderCapabilities{}
+}
+
 // Selector 统一的选择器，用于选择页码或行号
 type Selector struct {
 	// Indexes 离散的索引列表（从0开始）
@@ -47,16 +109,200 @@ type PageConfig struct {
 	LineSelector Selector
 }
 
+// 读取 zip 格式文档（DOCX/PPTX/XLSX）时的默认资源限制
+// 没有配置参数的方法（如 ReadText、GetMetadata）始终使用这些默认值
+const (
+	// DefaultMaxDecompressedSize 单个 zip 部件解压后的默认最大字节数（256MB）
+	DefaultMaxDecompressedSize int64 = 256 << 20
+
+	// DefaultMaxParts 默认最多处理的幻灯片/工作表数量
+	DefaultMaxParts = 10000
+)
+
+// SheetNameMatchMode 工作表名称匹配模式
+type SheetNameMatchMode int
+
+const (
+	// SheetNameExact 精确匹配（默认）
+	SheetNameExact SheetNameMatchMode = iota
+
+	// SheetNameCaseInsensitive 忽略大小写匹配
+	SheetNameCaseInsensitive
+
+	// SheetNameTrimmed 忽略空白匹配（包括首尾和名称内部的空白，如 "Sheet1" 匹配 "Sheet 1"），大小写仍需一致
+	SheetNameTrimmed
+
+	// SheetNameTrimmedCaseInsensitive 同时忽略空白和大小写匹配
+	SheetNameTrimmedCaseInsensitive
+)
+
+// TableMode DOCX 表格内容的输出方式
+type TableMode int
+
+const (
+	// TableInclude 同时输出段落和表格（默认）
+	TableInclude TableMode = iota
+
+	// TableExclude 只输出段落，忽略表格
+	TableExclude
+
+	// TableOnly 只输出表格，忽略表格之外的段落
+	TableOnly
+)
+
+// TrackChangesMode DOCX 修订标记（w:ins/w:del）的处理方式
+type TrackChangesMode int
+
+const (
+	// TrackChangesRaw 不处理修订标记（默认）：只读取段落中直接的 run，插入和删除修订中的文本都不输出
+	TrackChangesRaw TrackChangesMode = iota
+
+	// TrackChangesFinal 接受所有修订：输出插入的文本，忽略删除的文本
+	TrackChangesFinal
+
+	// TrackChangesOriginal 拒绝所有修订：输出删除的文本，忽略插入的文本
+	TrackChangesOriginal
+)
+
+// SplitMode TXT/Markdown 内容划分为 PageContent.Lines 的方式
+type SplitMode int
+
+const (
+	// SplitLines 按物理行划分，每行一个条目（默认）
+	SplitLines SplitMode = iota
+
+	// SplitParagraphs 按段落划分：连续的非空行以换行符合并为一个条目，空行（只含空白的行）作为分隔并被丢弃
+	SplitParagraphs
+)
+
+// PdfPageErrorMode PDF 页面读取失败（页面对象缺失或无法提取文本）时的处理方式
+type PdfPageErrorMode int
+
+const (
+	// PdfPageSkip 跳过该页并在 Warnings 中记录（默认）
+	PdfPageSkip PdfPageErrorMode = iota
+
+	// PdfPageError 立即返回包装了 ErrFileParse 的错误
+	PdfPageError
+
+	// PdfPagePlaceholder 用一行 "[page N unreadable]" 作为该页内容，使 Pages 与页码保持对应，同时在 Warnings 中记录
+	PdfPagePlaceholder
+)
+
 // ReadConfig 读取配置
 type ReadConfig struct {
 	// PageSelector 页面选择器，指定要读取哪些页
 	// 如果为空（Indexes和Ranges都为nil），则读取所有页
 	PageSelector Selector
 
+	// PageLabels 仅用于 PDF，按印刷页码（/PageLabels 定义的页码标签，如 "iv"、"12"）选择要读取的页
+	// 标签在读取前解析为页索引，与 PageSelector 选中的页合并；有标签未匹配到任何页时返回 ErrPageNotFound。
+	// 可通过 PdfReader.GetPageLabels 查看文档的页码标签，没有定义页码标签的文档使用从1开始的页码
+	PageLabels []string
+
 	// LineSelector 全局行选择器，应用到所有选中的页
 	// 如果为空，则读取页面的所有行
 	LineSelector Selector
 
+	// ColumnSelector 列选择器，仅用于 CSV/XLSX，在拼接单元格前筛选每一行的列
+	// 如果为空，则读取所有列；超出行长度的列索引会被忽略
+	ColumnSelector Selector
+
+	// RawCells 仅用于 CSV/XLSX，为 true 时每行只输出单元格内容，不添加 "Row N: " 前缀
+	RawCells bool
+
+	// CellSeparator 仅用于 CSV/XLSX，拼接同一行单元格时使用的分隔符
+	// 为空时使用默认的 " | "
+	CellSeparator string
+
+	// MaxRowsPerSheet 仅用于 XLSX，每个工作表最多读取的行数（按工作表中的行号计算，包括中间的空行）
+	// 达到上限后停止读取该工作表，并在元数据中记录 truncated；小于等于 0 表示不限制
+	MaxRowsPerSheet int
+
+	// ShowFormulas 仅用于 XLSX，为 true 时包含公式的单元格输出以 "=" 开头的公式（如 "=SUM(A1:A10)"），
+	// 而不是缓存的计算结果；没有公式的单元格仍输出单元格的值
+	ShowFormulas bool
+
+	// XlsxRawValues 仅用于 XLSX，为 true 时输出单元格存储的原始值，不应用数字格式，
+	// 例如货币单元格输出 "1234.5" 而不是 "$1,234.50"；日期单元格会输出 Excel 序列号（如 "45292"）
+	XlsxRawValues bool
+
+	// LineGroupPattern 仅用于 TXT，按正则表达式将物理行分组为逻辑记录
+	// 匹配该模式的行开始一个新分组，不匹配的行追加到当前分组（以换行符连接），
+	// 适合将日志中的多行堆栈合并为一条记录。为空时不分组
+	LineGroupPattern string
+
+	// SplitMode 仅用于 TXT/Markdown，内容划分为行的方式，默认按物理行划分
+	// 为 SplitParagraphs 时每个段落是一个条目，LineSelector 等按行的选项都作用于段落；
+	// TXT 设置了 LineGroupPattern 时以分组模式为准，忽略该选项
+	SplitMode SplitMode
+
+	// TableMode 仅用于 DOCX，控制是否输出表格（w:tbl）内容，默认同时输出段落和表格
+	TableMode TableMode
+
+	// TrackChangesMode 仅用于 DOCX，控制修订标记的处理方式，默认不处理
+	TrackChangesMode TrackChangesMode
+
+	// PdfPageErrorMode 仅用于 PDF，页面读取失败时的处理方式，默认跳过该页
+	// 同时设置了 FailOnPartialError 时，PdfPageSkip 和 PdfPagePlaceholder 也会返回错误
+	PdfPageErrorMode PdfPageErrorMode
+
+	// InlineHyperlinks 仅用于 RTF，为 true 时在超链接的显示文本之后追加 " (URL)"，如 "官网 (https://example.com)"
+	// 显示文本与 URL 相同时不追加
+	InlineHyperlinks bool
+
+	// IncludeMasterText 仅用于 PPTX，为 true 时合并版式（slideLayout）和母版（slideMaster）中的文本：
+	// 幻灯片中为空的日期、页脚、页眉、编号占位符使用版式或母版中同类型占位符的文本，
+	// 母版和版式中的非占位符形状（如保密声明横幅）追加在幻灯片文本之后。默认为 false，避免每张幻灯片重复相同的模板文字
+	IncludeMasterText bool
+
+	// IncludeAltText 仅用于 PPTX，为 true 时在每张幻灯片的文本行之后追加形状和图片的替代文字（cNvPr 的 title/descr）
+	IncludeAltText bool
+
+	// MaxFileSize 允许读取的最大文件大小（字节），在读取前通过 os.Stat 检查
+	// 超过时 ReadDocumentWithConfig 返回 ErrFileTooLarge；小于等于 0 表示不限制
+	MaxFileSize int64
+
+	// MaxDecompressedSize 读取 DOCX/PPTX/XLSX 时单个 zip 部件解压后的最大字节数，读取 .zip 压缩包时为所有文件解压后的总字节数
+	// 超过时返回 ErrFileTooLarge；为 0 时使用 DefaultMaxDecompressedSize，小于 0 表示不限制
+	MaxDecompressedSize int64
+
+	// MaxParts 读取 PPTX/XLSX 时最多处理的幻灯片/工作表数量
+	// 超过时返回 ErrFileTooLarge；为 0 时使用 DefaultMaxParts，小于 0 表示不限制
+	MaxParts int
+
+	// TrimLines 为 true 时去除每一行首尾的空白
+	// DropEmptyLines 为 true 时移除空行（只包含空白的行也视为空行）
+	// 两者都在行选择之后执行，LineSelector 和 PageConfigs 使用的始终是原始行索引
+	TrimLines      bool
+	DropEmptyLines bool
+
+	// DropConsecutiveDuplicateLines 为 true 时移除与同一页中上一行完全相同的行，
+	// 用于清理某些 PDF 生成器因文本层重叠而重复输出的行。在 TrimLines 和 DropEmptyLines 之后执行，
+	// 不跨页比较，也不受 TextCleaner 的空白处理影响（只有完全相同的行才会被移除）
+	DropConsecutiveDuplicateLines bool
+
+	// SkipEmptyPages 为 true 时从结果中移除筛选后所有行都为空白的页面/幻灯片/工作表
+	// TotalPages 仍为文档的总页数，跳过的页数记录在元数据 skipped_empty_pages 中
+	SkipEmptyPages bool
+
+	// FailOnPartialError 为 true 时，任何一页/幻灯片/工作表读取失败都会使 ReadWithConfig 返回包装了 ErrFileParse 的错误（包含页码、幻灯片编号或工作表名称）
+	// 默认为 false：跳过失败的部分继续读取，并记录在 DocumentResult.Warnings 中
+	FailOnPartialError bool
+
+	// MetadataOnly 为 true 时只填充 Metadata 和 TotalPages，不提取文本：Content 为空，Pages 为 nil
+	// PDF 只读取文档信息字典和页数（元数据中没有需要提取文本的 has_text_layer），PPTX 不解析幻灯片；
+	// DOCX 的页数来自正文中的分页符，仍需解析正文。页面选择等其他选项会被忽略
+	MetadataOnly bool
+
+	// SkipContentString 为 true 时不生成 DocumentResult.Content，只填充结构化的 Pages
+	// 对于大文档可以避免同时保存行和拼接后的完整文本，需要时可调用 DocumentResult.BuildContent
+	SkipContentString bool
+
+	// DropRepeatedHeaders 仅用于 ReadCombined，为 true 时合并多个 CSV/XLSX 文件只保留第一个文件的表头：
+	// 之后的 CSV/XLSX 文件中，首行与第一个表格文件的首行相同的页面/工作表会去掉这一行，适合合并表头相同的多个文件
+	DropRepeatedHeaders bool
+
 	// PageConfigs 页面级配置，为特定页面指定不同的行选择器
 	// 如果某页在 PageConfigs 中有配置，则使用该配置，否则使用全局 LineSelector
 	PageConfigs []PageConfig
@@ -64,6 +310,22 @@ type ReadConfig struct {
 	// SheetNames 对于XLSX文件，指定要读取的工作表名称
 	// 如果为nil，则读取所有工作表
 	SheetNames []string
+
+	// SheetNameMatch 工作表名称的匹配模式，默认精确匹配
+	SheetNameMatch SheetNameMatchMode
+
+	// StrictSheetNames 严格模式：SheetNames 中有名称未匹配到任何工作表时返回 ErrSheetNotFound
+	// 同时设置了 PageSelector 或 PageConfigs 时也会检查，即使工作表按索引选择
+	StrictSheetNames bool
+
+	// ProgressFunc 进度回调，每处理完一页/幻灯片/工作表后调用
+	// current 为已处理的数量（单调递增），total 为需要处理的总数
+	// 为 nil 时不进行回调
+	ProgressFunc func(current, total int)
+
+	// PreserveLineNumbers 是否在结果中保留每一行的原始行号
+	// 开启后 PageContent.LineNumbers 与 Lines 一一对应，记录筛选前的行索引（从0开始）
+	PreserveLineNumbers bool
 }
 
 // PageContent 表示单页/单工作表/单幻灯片的内容
@@ -77,10 +339,23 @@ type PageContent struct {
 	// Lines 该页的所有行内容
 	Lines []string
 
+	// LineNumbers 每一行在筛选前的原始行号（从0开始），与 Lines 一一对应
+	// 仅在 ReadConfig.PreserveLineNumbers 为 true 时填充
+	LineNumbers []int
+
 	// TotalLines 该页的总行数
 	TotalLines int
 }
 
+// Table 从文档中提取的表格
+type Table struct {
+	// Header 表头单元格
+	Header []string
+
+	// Rows 数据行，每行的单元格数与表头相同
+	Rows [][]string
+}
+
 // DocumentResult 结构化的文档读取结果
 type DocumentResult struct {
 	// FilePath 文件路径
@@ -100,6 +375,244 @@ type DocumentResult struct {
 
 	// Content 完整的文本内容（所有页面拼接）
 	Content string
+
+	// Warnings 读取过程中被跳过或只读取了一部分的页/幻灯片/工作表，例如 "page 7: failed to extract text"
+	// 非空时表示结果不完整；PDF 页码从 1 开始，工作表使用名称
+	Warnings []string
+
+	// layout 将页面拼接为完整内容时使用的格式
+	layout contentLayout
+}
+
+// BuildContent 按照文档格式将 Pages 拼接为完整文本
+// 与读取时生成的 Content 格式一致，适合在使用 SkipContentString 读取后按需生成
+func (r *DocumentResult) BuildContent() string {
+	return buildContent(r.Pages, r.layout)
+}
+
+// ToDocument 将结构化结果转换为 Document，以便使用 Document 上的清理、语言检测等方法
+// 使用 SkipContentString 读取时 Content 为空，会先按 Pages 生成完整文本；Metadata 会被复制，修改返回值不影响原结果
+func (r *DocumentResult) ToDocument() *Document {
+	content := r.Content
+	if content == "" {
+		content = r.BuildContent()
+	}
+
+	metadata := maps.Clone(r.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+
+	return &Document{
+		FilePath: r.FilePath,
+		Content:  content,
+		Metadata: metadata,
+	}
+}
+
+// Filter 对已读取的结果应用 config 的页面和行选择（PageSelector、LineSelector、PageConfigs）以及 TrimLines 等行清理选项，
+// 返回新的结果，原结果不会被修改。页面按 PageContent.PageNumber 匹配，行索引是该页当前 Lines 中的位置；
+// 原结果保留了 LineNumbers 时，新结果的 LineNumbers 仍为文件中的原始行号。只能在已有的页面和行中选择，
+// SheetNames、PageLabels 等需要读取文件的选项会被忽略；config 为 nil 时返回完整的副本
+func (r *DocumentResult) Filter(config *ReadConfig) *DocumentResult {
+	filtered := &DocumentResult{
+		FilePath:   r.FilePath,
+		TotalPages: r.TotalPages,
+		Pages:      make([]PageContent, 0),
+		Metadata:   maps.Clone(r.Metadata),
+		Warnings:   slices.Clone(r.Warnings),
+		layout:     r.layout,
+	}
+
+	// 总是记录筛选后每一行在原页面中的位置，以便换算原始行号
+	local := ReadConfig{}
+	if config != nil {
+		local = *config
+	}
+	local.PreserveLineNumbers = true
+
+	pageLineMap := buildPageLineMap(config, r.TotalPages)
+	for _, page := range r.Pages {
+		lineFilter, ok := pageLineMap[page.PageNumber]
+		if !ok {
+			continue
+		}
+
+		content := newPageContent(page.PageNumber, slices.Clone(page.Lines), lineFilter, &local)
+		content.PageName = page.PageName
+		switch {
+		case page.LineNumbers != nil && len(page.LineNumbers) == len(page.Lines):
+			for i, index := range content.LineNumbers {
+				content.LineNumbers[i] = page.LineNumbers[index]
+			}
+		case config == nil || !config.PreserveLineNumbers:
+			content.LineNumbers = nil
+		}
+
+		filtered.Pages = append(filtered.Pages, content)
+		filtered.TotalLines += content.TotalLines
+	}
+
+	filtered.finish(config)
+	return filtered
+}
+
+// StreamJSON 将 Pages 以 JSON 数组的形式逐页写入 w，每个元素是一个 PageContent 对象（字段名与 json.Marshal 相同），
+// 每页一行。与对整个结果调用 json.Marshal 不同，同一时间只在内存中编码一页，适合将数千页的结果写入 HTTP 响应或文件；
+// 没有页面时写入 "[]"。写入出错时立即停止并返回该错误
+func (r *DocumentResult) StreamJSON(w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	for i := range r.Pages {
+		data, err := json.Marshal(&r.Pages[i])
+		if err != nil {
+			return err
+		}
+
+		separator := "\n"
+		if i > 0 {
+			separator = ",\n"
+		}
+		if _, err := io.WriteString(w, separator); err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	closing := "]\n"
+	if len(r.Pages) > 0 {
+		closing = "\n]\n"
+	}
+	_, err := io.WriteString(w, closing)
+	return err
+}
+
+// FlatLine 展开后的一行，包含所在页面的信息
+type FlatLine struct {
+	// Page 页码/工作表索引/幻灯片编号（从0开始），与 PageContent.PageNumber 相同
+	Page int
+
+	// PageName 页面名称（对于XLSX是工作表名称，其他格式为空）
+	PageName string
+
+	// LineIndex 该行在 PageContent.Lines 中的索引（从0开始）
+	// 需要筛选前的原始行号时使用 ReadConfig.PreserveLineNumbers 和 LineNumber
+	LineIndex int
+
+	// LineNumber 该行在筛选前的原始行号（从0开始），仅在 PageContent.LineNumbers 已填充时有效，否则为 -1
+	LineNumber int
+
+	// Text 行内容
+	Text string
+}
+
+// FlatLines 按页面顺序将所有页的行展开为一个切片，便于写入数据库或建立索引
+func (r *DocumentResult) FlatLines() []FlatLine {
+	lines := make([]FlatLine, 0, r.TotalLines)
+	for _, page := range r.Pages {
+		hasNumbers := len(page.LineNumbers) == len(page.Lines)
+		for i, text := range page.Lines {
+			lineNumber := -1
+			if hasNumbers {
+				lineNumber = page.LineNumbers[i]
+			}
+			lines = append(lines, FlatLine{
+				Page:       page.PageNumber,
+				PageName:   page.PageName,
+				LineIndex:  i,
+				LineNumber: lineNumber,
+				Text:       text,
+			})
+		}
+	}
+	return lines
+}
+
+// partialFailure 处理某一页/工作表读取失败：配置了 FailOnPartialError 时返回包装了 ErrFileParse 的错误，
+// 否则记录一条警告并返回 nil，由调用方继续读取
+func (r *DocumentResult) partialFailure(config *ReadConfig, op, format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+	if config != nil && config.FailOnPartialError {
+		return WrapError(op, r.FilePath, fmt.Errorf("%s: %w", msg, ErrFileParse))
+	}
+	r.Warnings = append(r.Warnings, msg)
+	return nil
+}
+
+// finish 读取完成后的统一处理：按配置移除空页，然后生成 Content（配置了 SkipContentString 时保持为空）
+func (r *DocumentResult) finish(config *ReadConfig) {
+	if config != nil && config.SkipEmptyPages {
+		r.skipEmptyPages()
+	}
+
+	if config != nil && config.SkipContentString {
+		return
+	}
+	r.Content = r.BuildContent()
+}
+
+// skipEmptyPages 移除所有行去除首尾空白后都为空的页面，重新计算 TotalLines
+// TotalPages 保持为文档的总页数，跳过的页数记录在元数据 skipped_empty_pages 中
+func (r *DocumentResult) skipEmptyPages() {
+	pages := r.Pages[:0]
+	skipped := 0
+	totalLines := 0
+	for _, page := range r.Pages {
+		if isEmptyPage(page) {
+			skipped++
+			continue
+		}
+		pages = append(pages, page)
+		totalLines += page.TotalLines
+	}
+
+	r.Pages = pages
+	r.TotalLines = totalLines
+	if r.Metadata == nil {
+		r.Metadata = make(map[string]string)
+	}
+	r.Metadata["skipped_empty_pages"] = strconv.Itoa(skipped)
+}
+
+// isEmptyPage 判断页面的所有行是否都只包含空白
+func isEmptyPage(page PageContent) bool {
+	for _, line := range page.Lines {
+		if strings.TrimSpace(line) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// filterLines 只保留满足条件的行，同时保持 LineNumbers 同步并更新 TotalLines
+func (p *PageContent) filterLines(keep func(line string) bool) {
+	hasLineNumbers := p.LineNumbers != nil && len(p.LineNumbers) == len(p.Lines)
+
+	lines := make([]string, 0, len(p.Lines))
+	var lineNumbers []int
+	if hasLineNumbers {
+		lineNumbers = make([]int, 0, len(p.LineNumbers))
+	}
+
+	for i, line := range p.Lines {
+		if !keep(line) {
+			continue
+		}
+		lines = append(lines, line)
+		if hasLineNumbers {
+			lineNumbers = append(lineNumbers, p.LineNumbers[i])
+		}
+	}
+
+	p.Lines = lines
+	if hasLineNumbers {
+		p.LineNumbers = lineNumbers
+	}
+	p.TotalLines = len(lines)
 }
 
 // Document 表示一个文档及其内容
@@ -129,54 +642,189 @@ func (d *Document) CleanContentAggressive() {
 	d.Content = CleanTextAggressive(d.Content)
 }
 
+// CleanContent 使用默认配置清理结构化结果
+// 按页清理 Lines，重新计算 TotalLines 并重新生成 Content
+func (r *DocumentResult) CleanContent() {
+	DefaultTextCleaner().CleanResult(r)
+}
+
+// CleanContentWith 使用自定义清理器清理结构化结果
+func (r *DocumentResult) CleanContentWith(cleaner *TextCleaner) {
+	cleaner.CleanResult(r)
+}
+
+// CleanContentMinimal 使用最小配置清理结构化结果
+func (r *DocumentResult) CleanContentMinimal() {
+	minimalTextCleaner().CleanResult(r)
+}
+
+// CleanContentAggressive 使用激进配置清理结构化结果
+func (r *DocumentResult) CleanContentAggressive() {
+	aggressiveTextCleaner().CleanResult(r)
+}
+
 // GetSupportedFormats 返回当前支持的文档格式列表
+// 设置了 OCR 引擎（SetOCREngine）时包含图片格式
 func GetSupportedFormats() []string {
 	formats := make([]string, len(supportedFormats))
 	copy(formats, supportedFormats)
+	if currentOCREngine() != nil {
+		formats = append(formats, imageFormats...)
+	}
 	return formats
 }
 
 // IsFormatSupported 检查指定的文件格式是否被支持
 func IsFormatSupported(ext string) bool {
+	return newFormatReader(ext) != nil
+}
+
+// normalizeExt 将扩展名转换为小写并补全前导点，例如 "DOCX" 转换为 ".docx"
+func normalizeExt(ext string) string {
 	ext = strings.ToLower(ext)
 	if !strings.HasPrefix(ext, ".") {
 		ext = "." + ext
 	}
-	return slices.Contains(supportedFormats, ext)
+	return ext
 }
 
-// ReadDocument 根据文件扩展名自动选择合适的读取器
-func ReadDocument(filePath string) (*Document, error) {
-	// 检查文件是否存在
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, WrapError("ReadDocument", filePath, ErrFileNotFound)
+// NewReader 返回扩展名对应的读取器，扩展名不区分大小写，可以省略前导点
+// 不支持的格式返回 ErrUnsupportedFormat。返回值可以断言为具体类型（如 *PptxReader）以调用格式特有的方法
+func NewReader(ext string) (DocumentReader, error) {
+	reader := newFormatReader(ext)
+	if reader == nil {
+		return nil, WrapError("NewReader", ext, ErrUnsupportedFormat)
 	}
+	return reader, nil
+}
 
-	ext := strings.ToLower(filepath.Ext(filePath))
-
-	var reader DocumentReader
+// NewConfigurableReader 返回扩展名对应的可配置读取器，不支持的格式返回 ErrUnsupportedFormat
+func NewConfigurableReader(ext string) (ConfigurableReader, error) {
+	reader := newFormatReader(ext)
+	if reader == nil {
+		return nil, WrapError("NewConfigurableReader", ext, ErrUnsupportedFormat)
+	}
+	return reader, nil
+}
 
-	switch ext {
+// newFormatReader 创建扩展名对应的读取器，是扩展名到读取器的唯一映射，不支持的格式返回 nil
+// 所有读取器都实现了 ConfigurableReader 和 textStreamer
+func newFormatReader(ext string) ConfigurableReader {
+	switch normalizeExt(ext) {
 	case ".docx":
-		reader = &DocxReader{}
+		return &DocxReader{}
 	case ".pdf":
-		reader = &PdfReader{}
+		return &PdfReader{}
 	case ".xlsx":
-		reader = &XlsxReader{}
+		return &XlsxReader{}
 	case ".pptx":
-		reader = &PptxReader{}
+		return &PptxReader{}
 	case ".txt":
-		reader = &TxtReader{}
+		return &TxtReader{}
 	case ".csv":
-		reader = &CsvReader{}
+		return &CsvReader{}
+	case ".tsv":
+		return &CsvReader{Comma: '\t'}
 	case ".md", ".markdown":
-		reader = &MdReader{}
+		return &MdReader{}
 	case ".rtf":
-		reader = &RtfReader{}
+		return &RtfReader{}
+	case ".json", ".jsonl":
+		return &JsonReader{}
+	case ".xml":
+		return &XmlReader{}
+	case ".pages":
+		return &PagesReader{}
+	case ".numbers":
+		return &NumbersReader{}
+	case ".key":
+		return &KeynoteReader{}
+	case ".zip":
+		return &ZipReader{}
 	default:
+		// 图片只在设置了 OCR 引擎时才被支持
+		if isImageFormat(normalizeExt(ext)) && currentOCREngine() != nil {
+			return &ImageReader{}
+		}
+		return nil
+	}
+}
+
+// 全局默认读取配置，由 SetDefaultReadConfig 设置
+var (
+	defaultConfigMu sync.RWMutex
+	defaultConfig   *ReadConfig
+)
+
+// SetDefaultReadConfig 设置全局默认读取配置，传入 nil 时清除
+// 设置后 ReadDocument 和 ReadDocumentWithConfig(filePath, nil) 都使用该配置；直接调用读取器的方法和 SearchDocument 不受影响。
+// 保存的是 config 的副本，之后修改 config 的字段不会生效（切片字段与调用方共享，不应再修改）。
+// 可以在多个 goroutine 中安全调用，但通常应在程序初始化时设置一次
+func SetDefaultReadConfig(config *ReadConfig) {
+	var stored *ReadConfig
+	if config != nil {
+		copied := *config
+		stored = &copied
+	}
+
+	defaultConfigMu.Lock()
+	defaultConfig = stored
+	defaultConfigMu.Unlock()
+}
+
+// DefaultReadConfig 返回全局默认读取配置的副本，未设置时返回 nil
+func DefaultReadConfig() *ReadConfig {
+	config := defaultReadConfig()
+	if config == nil {
+		return nil
+	}
+	copied := *config
+	return &copied
+}
+
+// defaultReadConfig 返回全局默认读取配置，调用方不得修改返回值
+func defaultReadConfig() *ReadConfig {
+	defaultConfigMu.RLock()
+	defer defaultConfigMu.RUnlock()
+	return defaultConfig
+}
+
+// ReadDocument 根据文件扩展名自动选择合适的读取器
+// 设置了全局默认配置（SetDefaultReadConfig）时，使用该配置通过 ReadDocumentWithConfig 读取，
+// 此时 Content 与 DocumentResult.Content 的格式一致
+func ReadDocument(filePath string) (*Document, error) {
+	// 检查文件是否存在
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, WrapError("ReadDocument", filePath, ErrFileNotFound)
+	}
+
+	ext := strings.ToLower(filepath.Ext(filePath))
+
+	reader := newFormatReader(ext)
+	if reader == nil {
 		return nil, WrapError("ReadDocument", filePath, ErrUnsupportedFormat)
 	}
 
+	config := defaultReadConfig()
+	if config != nil {
+		if err := checkFileSize("ReadDocument", filePath, config.MaxFileSize); err != nil {
+			return nil, err
+		}
+	}
+	return readDocument(reader, filePath, config)
+}
+
+// readDocument 使用 reader 读取文档：config 不为 nil 时通过 ReadWithConfig 读取，
+// 否则通过 ReadText 和 GetMetadata 读取，元数据读取失败时使用空的元数据
+func readDocument(reader ConfigurableReader, filePath string, config *ReadConfig) (*Document, error) {
+	if config != nil {
+		result, err := reader.ReadWithConfig(filePath, config)
+		if err != nil {
+			return nil, err
+		}
+		return result.ToDocument(), nil
+	}
+
 	content, err := reader.ReadText(filePath)
 	if err != nil {
 		return nil, err
@@ -194,6 +842,27 @@ func ReadDocument(filePath string) (*Document, error) {
 	}, nil
 }
 
+// ReadDocumentLimited 读取文档，文件大小超过 maxBytes 字节时返回 ErrFileTooLarge
+// 大小在读取前通过 os.Stat 检查，适合处理不可信的上传文件；maxBytes <= 0 表示不限制
+func ReadDocumentLimited(filePath string, maxBytes int64) (*Document, error) {
+	if err := checkFileSize("ReadDocumentLimited", filePath, maxBytes); err != nil {
+		return nil, err
+	}
+	return ReadDocument(filePath)
+}
+
+// checkFileSize 检查文件是否存在以及大小是否超过 maxBytes，maxBytes <= 0 时只检查是否存在
+func checkFileSize(op, filePath string, maxBytes int64) error {
+	info, err := os.Stat(filePath)
+	if os.IsNotExist(err) {
+		return WrapError(op, filePath, ErrFileNotFound)
+	}
+	if err == nil && maxBytes > 0 && info.Size() > maxBytes {
+		return WrapError(op, filePath, ErrFileTooLarge)
+	}
+	return nil
+}
+
 // ReadDocumentWithClean 读取文档并自动应用默认清理
 func ReadDocumentWithClean(filePath string) (*Document, error) {
 	doc, err := ReadDocument(filePath)
@@ -214,41 +883,186 @@ func ReadDocumentWithCleanConfig(filePath string, cleaner *TextCleaner) (*Docume
 	return doc, nil
 }
 
-// ReadDocumentWithConfig 根据配置读取文档，返回结构化结果
-func ReadDocumentWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
+// ReadDocumentWithLanguage 读取文档并检测语言，结果保存在元数据的 language 键中
+// 无法识别语言时 language 为空字符串，不视为错误
+func ReadDocumentWithLanguage(filePath string) (*Document, error) {
+	doc, err := ReadDocument(filePath)
+	if err != nil {
+		return nil, err
+	}
+	language, _ := doc.DetectLanguage()
+	doc.Metadata["language"] = language
+	return doc, nil
+}
+
+// ReadDocumentTimeout 读取文档，超过 d 仍未完成时返回包装了 context.DeadlineExceeded 的错误
+// 读取在单独的 goroutine 中进行。读取器（如 PDF 库）不支持取消，超时后该 goroutine 仍会继续运行直到读取结束，
+// 结果被丢弃；调用方不再被阻塞，但占用的 CPU 和内存要等读取结束才会释放。d <= 0 时不限制时间
+func ReadDocumentTimeout(filePath string, d time.Duration) (*Document, error) {
+	if d <= 0 {
+		return ReadDocument(filePath)
+	}
+
+	type readResult struct {
+		doc *Document
+		err error
+	}
+	// 带缓冲，超时后 goroutine 仍然可以写入并退出
+	done := make(chan readResult, 1)
+	go func() {
+		doc, err := ReadDocument(filePath)
+		done <- readResult{doc, err}
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		return result.doc, result.err
+	case <-timer.C:
+		return nil, WrapError("ReadDocumentTimeout", filePath, context.DeadlineExceeded)
+	}
+}
+
+// ReadDocumentWithHash 读取文档并计算哈希值，用于去重
+// 元数据的 sha256 为文件字节的 SHA-256，content_sha256 为提取文本（Content）的 SHA-256，均为小写十六进制；
+// 重新保存的文件字节不同，但提取的文本相同时 content_sha256 仍然相同
+func ReadDocumentWithHash(filePath string) (*Document, error) {
+	doc, err := ReadDocument(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, WrapError("ReadDocumentWithHash", filePath, ErrFileOpen)
+	}
+	defer file.Close()
+
+	fileHash := sha256.New()
+	if _, err := io.Copy(fileHash, file); err != nil {
+		return nil, WrapError("ReadDocumentWithHash", filePath, ErrFileRead)
+	}
+	contentHash := sha256.Sum256([]byte(doc.Content))
+
+	doc.Metadata["sha256"] = hex.EncodeToString(fileHash.Sum(nil))
+	doc.Metadata["content_sha256"] = hex.EncodeToString(contentHash[:])
+	return doc, nil
+}
+
+// ReadDocumentPreview 读取文档开头最多 maxRunes 个字符的文本，用于快速预览
+// 读取器以流的方式输出文本，达到字符上限后立即停止解析，不会读取整个文档
+// 返回内容与 ReadDocument 得到的 Content 前 maxRunes 个字符一致；maxRunes <= 0 时返回空字符串
+func ReadDocumentPreview(filePath string, maxRunes int) (string, error) {
 	// 检查文件是否存在
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, WrapError("ReadDocumentWithConfig", filePath, ErrFileNotFound)
+		return "", WrapError("ReadDocumentPreview", filePath, ErrFileNotFound)
 	}
 
 	ext := strings.ToLower(filepath.Ext(filePath))
 
-	var reader ConfigurableReader
+	reader, ok := newFormatReader(ext).(textStreamer)
+	if !ok {
+		return "", WrapError("ReadDocumentPreview", filePath, ErrUnsupportedFormat)
+	}
 
-	switch ext {
-	case ".docx":
-		reader = &DocxReader{}
-	case ".pdf":
-		reader = &PdfReader{}
-	case ".xlsx":
-		reader = &XlsxReader{}
-	case ".pptx":
-		reader = &PptxReader{}
-	case ".txt":
-		reader = &TxtReader{}
-	case ".csv":
-		reader = &CsvReader{}
-	case ".md", ".markdown":
-		reader = &MdReader{}
-	case ".rtf":
-		reader = &RtfReader{}
-	default:
+	if maxRunes <= 0 {
+		return "", nil
+	}
+
+	w := &runeLimitWriter{remaining: maxRunes}
+	if err := reader.writeText(w, filePath); err != nil && !errors.Is(err, errLimitReached) {
+		return "", err
+	}
+
+	return w.builder.String(), nil
+}
+
+// WriteDocumentText 将文档文本以流的方式写入 w，返回写入的字节数
+// 写入的内容与 ReadDocument 得到的 Content 一致，但读取器边处理页/幻灯片/行边写入，不会在内存中拼接完整文本，
+// 适合直接输出到文件、HTTP 响应或 gzip.Writer。w 返回的错误会原样返回，已写入的字节数仍然有效
+func WriteDocumentText(w io.Writer, filePath string) (int64, error) {
+	// 检查文件是否存在
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return 0, WrapError("WriteDocumentText", filePath, ErrFileNotFound)
+	}
+
+	ext := strings.ToLower(filepath.Ext(filePath))
+
+	reader, ok := newFormatReader(ext).(textStreamer)
+	if !ok {
+		return 0, WrapError("WriteDocumentText", filePath, ErrUnsupportedFormat)
+	}
+
+	cw := &countingWriter{w: w}
+	err := reader.writeText(cw, filePath)
+	return cw.n, err
+}
+
+// ConvertToText 读取文档文本，并返回建议的输出文件名（输入文件名去掉目录，扩展名替换为 .txt）
+// 例如 "/data/report.docx" 的建议文件名为 "report.txt"；文本与 ReadDocument 的 Content 一致
+func ConvertToText(filePath string) (text string, suggestedName string, err error) {
+	doc, err := ReadDocument(filePath)
+	if err != nil {
+		return "", "", err
+	}
+
+	base := filepath.Base(filePath)
+	suggestedName = strings.TrimSuffix(base, filepath.Ext(base)) + ".txt"
+	return doc.Content, suggestedName, nil
+}
+
+// ReadDocumentWithConfig 根据配置读取文档，返回结构化结果
+// config 为 nil 时使用全局默认配置（SetDefaultReadConfig），未设置默认配置时读取全部内容
+func ReadDocumentWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
+	// 未传入配置时使用全局默认配置
+	if config == nil {
+		config = defaultReadConfig()
+	}
+
+	// 检查文件是否存在以及大小限制
+	var maxBytes int64
+	if config != nil {
+		maxBytes = config.MaxFileSize
+	}
+	if err := checkFileSize("ReadDocumentWithConfig", filePath, maxBytes); err != nil {
+		return nil, err
+	}
+
+	ext := strings.ToLower(filepath.Ext(filePath))
+
+	reader := newFormatReader(ext)
+	if reader == nil {
 		return nil, WrapError("ReadDocumentWithConfig", filePath, ErrUnsupportedFormat)
 	}
 
 	return reader.ReadWithConfig(filePath, config)
 }
 
+// ReadFirstSection 只读取文档的第一部分：PDF 的第一页、PPTX 的第一张幻灯片、XLSX 的第一个工作表、
+// DOCX 第一个分页符之前的内容，TXT 等单页格式返回全部行。PDF、PPTX、XLSX 不会解析其余的页、幻灯片或工作表，适合快速生成缩略信息；
+// 不受全局默认配置影响。文档没有任何部分时返回 ErrEmptyFile
+func ReadFirstSection(filePath string) (*PageContent, error) {
+	if err := checkFileSize("ReadFirstSection", filePath, 0); err != nil {
+		return nil, err
+	}
+
+	reader := newFormatReader(filepath.Ext(filePath))
+	if reader == nil {
+		return nil, WrapError("ReadFirstSection", filePath, ErrUnsupportedFormat)
+	}
+
+	result, err := reader.ReadWithConfig(filePath, NewReadConfig().WithPages(0).WithSkipContentString(true))
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Pages) == 0 {
+		return nil, WrapError("ReadFirstSection", filePath, ErrEmptyFile)
+	}
+	return &result.Pages[0], nil
+}
+
 // NewReadConfig 创建一个新的读取配置
 func NewReadConfig() *ReadConfig {
 	return &ReadConfig{}
@@ -266,6 +1080,12 @@ func (c *ReadConfig) WithPageRange(start, end int) *ReadConfig {
 	return c
 }
 
+// WithPageLabels 设置要读取的页码标签（仅用于PDF）
+func (c *ReadConfig) WithPageLabels(labels ...string) *ReadConfig {
+	c.PageLabels = labels
+	return c
+}
+
 // WithLines 设置要读取的行号（离散索引，应用到所有页）
 func (c *ReadConfig) WithLines(lines ...int) *ReadConfig {
 	c.LineSelector.Indexes = lines
@@ -278,12 +1098,192 @@ func (c *ReadConfig) WithLineRange(start, end int) *ReadConfig {
 	return c
 }
 
+// WithColumns 设置要读取的列（离散索引，仅用于CSV/XLSX）
+func (c *ReadConfig) WithColumns(columns ...int) *ReadConfig {
+	c.ColumnSelector.Indexes = columns
+	return c
+}
+
+// WithColumnRange 设置要读取的列范围 [start, end]（仅用于CSV/XLSX）
+func (c *ReadConfig) WithColumnRange(start, end int) *ReadConfig {
+	c.ColumnSelector.Ranges = append(c.ColumnSelector.Ranges, [2]int{start, end})
+	return c
+}
+
+// WithRawCells 设置是否只输出单元格内容，不添加行号前缀（仅用于CSV/XLSX）
+func (c *ReadConfig) WithRawCells(raw bool) *ReadConfig {
+	c.RawCells = raw
+	return c
+}
+
+// WithCellSeparator 设置单元格之间的分隔符（仅用于CSV/XLSX）
+func (c *ReadConfig) WithCellSeparator(sep string) *ReadConfig {
+	c.CellSeparator = sep
+	return c
+}
+
+// WithMaxRowsPerSheet 设置每个工作表最多读取的行数（仅用于XLSX）
+func (c *ReadConfig) WithMaxRowsPerSheet(maxRows int) *ReadConfig {
+	c.MaxRowsPerSheet = maxRows
+	return c
+}
+
+// WithShowFormulas 设置是否输出单元格的公式而不是计算结果（仅用于XLSX）
+func (c *ReadConfig) WithShowFormulas(show bool) *ReadConfig {
+	c.ShowFormulas = show
+	return c
+}
+
+// WithXlsxRawValues 设置是否输出单元格的原始值而不是格式化后的显示值（仅用于XLSX）
+func (c *ReadConfig) WithXlsxRawValues(raw bool) *ReadConfig {
+	c.XlsxRawValues = raw
+	return c
+}
+
+// WithLineGroupPattern 设置行分组的正则表达式（仅用于TXT）
+func (c *ReadConfig) WithLineGroupPattern(pattern string) *ReadConfig {
+	c.LineGroupPattern = pattern
+	return c
+}
+
+// WithSplitMode 设置内容划分为行的方式（仅用于TXT/Markdown）
+func (c *ReadConfig) WithSplitMode(mode SplitMode) *ReadConfig {
+	c.SplitMode = mode
+	return c
+}
+
+// WithTableMode 设置表格内容的输出方式（仅用于DOCX）
+func (c *ReadConfig) WithTableMode(mode TableMode) *ReadConfig {
+	c.TableMode = mode
+	return c
+}
+
+// WithTrackChangesMode 设置修订标记的处理方式（仅用于DOCX）
+func (c *ReadConfig) WithTrackChangesMode(mode TrackChangesMode) *ReadConfig {
+	c.TrackChangesMode = mode
+	return c
+}
+
+// WithPdfPageErrorMode 设置页面读取失败时的处理方式（仅用于PDF）
+func (c *ReadConfig) WithPdfPageErrorMode(mode PdfPageErrorMode) *ReadConfig {
+	c.PdfPageErrorMode = mode
+	return c
+}
+
+// WithInlineHyperlinks 设置是否在超链接的显示文本之后追加 URL（仅用于RTF）
+func (c *ReadConfig) WithInlineHyperlinks(inline bool) *ReadConfig {
+	c.InlineHyperlinks = inline
+	return c
+}
+
+// WithIncludeMasterText 设置是否合并版式和母版中的文本（仅用于PPTX）
+func (c *ReadConfig) WithIncludeMasterText(include bool) *ReadConfig {
+	c.IncludeMasterText = include
+	return c
+}
+
+// WithIncludeAltText 设置是否输出形状和图片的替代文字（仅用于PPTX）
+func (c *ReadConfig) WithIncludeAltText(include bool) *ReadConfig {
+	c.IncludeAltText = include
+	return c
+}
+
+// WithMaxFileSize 设置允许读取的最大文件大小（字节）
+func (c *ReadConfig) WithMaxFileSize(maxBytes int64) *ReadConfig {
+	c.MaxFileSize = maxBytes
+	return c
+}
+
+// WithMaxDecompressedSize 设置单个 zip 部件解压后的最大字节数（仅用于DOCX/PPTX/XLSX）
+func (c *ReadConfig) WithMaxDecompressedSize(maxBytes int64) *ReadConfig {
+	c.MaxDecompressedSize = maxBytes
+	return c
+}
+
+// WithMaxParts 设置最多处理的幻灯片/工作表数量（仅用于PPTX/XLSX）
+func (c *ReadConfig) WithMaxParts(maxParts int) *ReadConfig {
+	c.MaxParts = maxParts
+	return c
+}
+
+// WithTrimLines 设置是否去除每一行首尾的空白
+func (c *ReadConfig) WithTrimLines(trim bool) *ReadConfig {
+	c.TrimLines = trim
+	return c
+}
+
+// WithDropEmptyLines 设置是否移除空行
+func (c *ReadConfig) WithDropEmptyLines(drop bool) *ReadConfig {
+	c.DropEmptyLines = drop
+	return c
+}
+
+// WithDropConsecutiveDuplicateLines 设置是否移除与上一行完全相同的行
+func (c *ReadConfig) WithDropConsecutiveDuplicateLines(drop bool) *ReadConfig {
+	c.DropConsecutiveDuplicateLines = drop
+	return c
+}
+
+// WithSkipEmptyPages 设置是否跳过没有内容的页面
+func (c *ReadConfig) WithSkipEmptyPages(skip bool) *ReadConfig {
+	c.SkipEmptyPages = skip
+	return c
+}
+
+// WithFailOnPartialError 设置任何一页/工作表读取失败时是否返回错误，而不是跳过并记录警告
+func (c *ReadConfig) WithFailOnPartialError(fail bool) *ReadConfig {
+	c.FailOnPartialError = fail
+	return c
+}
+
+// WithSkipContentString 设置是否跳过生成完整文本 Content
+func (c *ReadConfig) WithSkipContentString(skip bool) *ReadConfig {
+	c.SkipContentString = skip
+	return c
+}
+
+// WithMetadataOnly 设置是否只读取元数据和总页数，不提取文本
+func (c *ReadConfig) WithMetadataOnly(only bool) *ReadConfig {
+	c.MetadataOnly = only
+	return c
+}
+
+// WithDropRepeatedHeaders 设置合并多个文件时是否去掉重复的表头（仅用于ReadCombined中的CSV/XLSX）
+func (c *ReadConfig) WithDropRepeatedHeaders(drop bool) *ReadConfig {
+	c.DropRepeatedHeaders = drop
+	return c
+}
+
 // WithSheetNames 设置要读取的工作表名称（仅用于XLSX）
 func (c *ReadConfig) WithSheetNames(names ...string) *ReadConfig {
 	c.SheetNames = names
 	return c
 }
 
+// WithSheetNameMatch 设置工作表名称的匹配模式（仅用于XLSX）
+func (c *ReadConfig) WithSheetNameMatch(mode SheetNameMatchMode) *ReadConfig {
+	c.SheetNameMatch = mode
+	return c
+}
+
+// WithStrictSheetNames 设置工作表名称未匹配时是否返回错误（仅用于XLSX）
+func (c *ReadConfig) WithStrictSheetNames(strict bool) *ReadConfig {
+	c.StrictSheetNames = strict
+	return c
+}
+
+// WithProgress 设置进度回调函数
+func (c *ReadConfig) WithProgress(fn func(current, total int)) *ReadConfig {
+	c.ProgressFunc = fn
+	return c
+}
+
+// WithLineNumbers 设置是否保留原始行号
+func (c *ReadConfig) WithLineNumbers(preserve bool) *ReadConfig {
+	c.PreserveLineNumbers = preserve
+	return c
+}
+
 // AddPageConfig 为指定页面添加特定的行选择器
 // pageIndex: 页码索引（从0开始）
 // lineIndexes: 该页要读取的行号（离散索引）
@@ -1,12 +1,29 @@
 package docreader
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"image"
+	"image/png"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
+
+	"github.com/xuri/excelize/v2"
 )
 
 // TestReadDocument 测试统一文档读取接口
@@ -240,7 +257,7 @@ func TestGetSupportedFormats(t *testing.T) {
 		t.Fatal("支持的格式列表不应为空")
 	}
 
-	expectedFormats := []string{".docx", ".pdf", ".xlsx", ".pptx", ".txt", ".csv", ".md", ".rtf"}
+	expectedFormats := []string{".docx", ".pdf", ".xlsx", ".pptx", ".txt", ".csv", ".tsv", ".md", ".rtf"}
 	for _, expected := range expectedFormats {
 		found := false
 		for _, format := range formats {
@@ -277,9 +294,13 @@ func TestIsFormatSupported(t *testing.T) {
 		{".pptx", true},
 		{".txt", true},
 		{".csv", true},
+		{".tsv", true},
 		{".md", true},
 		{".markdown", true},
 		{".rtf", true},
+		{".json", true},
+		{".jsonl", true},
+		{".xml", true},
 		{".doc", false},
 		{".xls", false},
 		{".ppt", false},
@@ -363,3 +384,4448 @@ func BenchmarkReadDocument(b *testing.B) {
 		})
 	}
 }
+
+// TestReadWithConfigProgress 测试进度回调
+func TestReadWithConfigProgress(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "progress.txt")
+	if err := os.WriteFile(testFile, []byte("line1\nline2"), 0644); err != nil {
+		t.Fatalf("创建临时文件失败: %v", err)
+	}
+
+	var calls [][2]int
+	config := NewReadConfig().WithProgress(func(current, total int) {
+		calls = append(calls, [2]int{current, total})
+	})
+
+	if _, err := ReadDocumentWithConfig(testFile, config); err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(calls) != 1 || calls[0] != [2]int{1, 1} {
+		t.Errorf("期望回调 [[1 1]]，实际: %v", calls)
+	}
+
+	// 未设置回调时不应出错
+	if _, err := ReadDocumentWithConfig(testFile, NewReadConfig()); err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+}
+
+// writeZipFile 在指定路径创建包含给定文件的 zip 包（用于构造 OOXML 测试文件）
+func writeZipFile(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("创建临时文件失败: %v", err)
+	}
+	defer f.Close()
+
+	// 按名称顺序写入，保证条目顺序稳定
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(f)
+	for _, name := range names {
+		content := files[name]
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("写入 zip 条目失败: %v", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("写入 zip 条目失败: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("关闭 zip 失败: %v", err)
+	}
+}
+
+// pptxSlideXML 构造包含给定段落的幻灯片 XML
+func pptxSlideXML(paragraphs ...string) string {
+	var builder strings.Builder
+	builder.WriteString(`<p:sld xmlns:p="p" xmlns:a="a"><p:cSld><p:spTree><p:sp><p:txBody>`)
+	for _, para := range paragraphs {
+		builder.WriteString(`<a:p><a:r><a:t>` + para + `</a:t></a:r></a:p>`)
+	}
+	builder.WriteString(`</p:txBody></p:sp></p:spTree></p:cSld></p:sld>`)
+	return builder.String()
+}
+
+// TestOpenPptx 测试复用已打开的 PPTX 文件
+func TestOpenPptx(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "test.pptx")
+	writeZipFile(t, testFile, map[string]string{
+		"docProps/core.xml":     `<cp:coreProperties xmlns:cp="cp" xmlns:dc="dc"><dc:title>Deck</dc:title></cp:coreProperties>`,
+		"ppt/slides/slide1.xml": pptxSlideXML("Hello", "World"),
+	})
+
+	opened, err := OpenPptx(testFile)
+	if err != nil {
+		t.Fatalf("打开失败: %v", err)
+	}
+	defer opened.Close()
+
+	text, err := opened.ReadText()
+	if err != nil {
+		t.Fatalf("读取文本失败: %v", err)
+	}
+	if !strings.Contains(text, "Hello\nWorld") {
+		t.Errorf("文本内容不符合预期: %q", text)
+	}
+
+	metadata, err := opened.GetMetadata()
+	if err != nil {
+		t.Fatalf("获取元数据失败: %v", err)
+	}
+	if metadata["title"] != "Deck" || metadata["slide_count"] != "1" {
+		t.Errorf("元数据不符合预期: %v", metadata)
+	}
+
+	slides, err := opened.GetSlides()
+	if err != nil || len(slides) != 1 {
+		t.Fatalf("期望 1 张幻灯片，实际: %d, err: %v", len(slides), err)
+	}
+
+	result, err := opened.ReadWithConfig(nil)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if result.TotalPages != 1 || result.TotalLines != 2 {
+		t.Errorf("结构化结果不符合预期: pages=%d lines=%d", result.TotalPages, result.TotalLines)
+	}
+}
+
+// TestPreserveLineNumbers 测试保留原始行号
+func TestPreserveLineNumbers(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "lines.txt")
+	if err := os.WriteFile(testFile, []byte("a\nb\nc\nd\ne"), 0644); err != nil {
+		t.Fatalf("创建临时文件失败: %v", err)
+	}
+
+	config := NewReadConfig().WithLines(1, 3).WithLineNumbers(true)
+	result, err := ReadDocumentWithConfig(testFile, config)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+
+	page := result.Pages[0]
+	if strings.Join(page.Lines, ",") != "b,d" {
+		t.Errorf("期望行 [b d]，实际: %v", page.Lines)
+	}
+	if len(page.LineNumbers) != 2 || page.LineNumbers[0] != 1 || page.LineNumbers[1] != 3 {
+		t.Errorf("期望行号 [1 3]，实际: %v", page.LineNumbers)
+	}
+
+	// 未开启时不填充行号
+	result, err = ReadDocumentWithConfig(testFile, NewReadConfig().WithLines(1))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if result.Pages[0].LineNumbers != nil {
+		t.Errorf("未开启时不应填充行号: %v", result.Pages[0].LineNumbers)
+	}
+}
+
+// writeXlsxFile 创建包含给定工作表数据的 XLSX 测试文件
+func writeXlsxFile(t *testing.T, path string, sheets map[string][][]any) {
+	t.Helper()
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for name, rows := range sheets {
+		if _, err := f.NewSheet(name); err != nil {
+			t.Fatalf("创建工作表失败: %v", err)
+		}
+		for rowIndex, row := range rows {
+			cell, _ := excelize.CoordinatesToCellName(1, rowIndex+1)
+			if err := f.SetSheetRow(name, cell, &row); err != nil {
+				t.Fatalf("写入行失败: %v", err)
+			}
+		}
+	}
+	if _, ok := sheets["Sheet1"]; !ok {
+		_ = f.DeleteSheet("Sheet1")
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("保存 XLSX 失败: %v", err)
+	}
+}
+
+// TestSheetNameMatch 测试工作表名称匹配模式
+func TestSheetNameMatch(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "sheets.xlsx")
+	writeXlsxFile(t, testFile, map[string][][]any{
+		"Sales":   {{"a", 1}},
+		"Summary": {{"b", 2}},
+		"Q1 Plan": {{"c", 3}},
+	})
+
+	tests := []struct {
+		name     string
+		config   *ReadConfig
+		expected []string
+		wantErr  bool
+	}{
+		{"精确匹配", NewReadConfig().WithSheetNames("Sales"), []string{"Sales"}, false},
+		{"精确匹配大小写不同", NewReadConfig().WithSheetNames("sales"), nil, false},
+		{"忽略大小写", NewReadConfig().WithSheetNames("sales").WithSheetNameMatch(SheetNameCaseInsensitive), []string{"Sales"}, false},
+		{"忽略空白", NewReadConfig().WithSheetNames(" Summary ").WithSheetNameMatch(SheetNameTrimmed), []string{"Summary"}, false},
+		{"忽略空白但区分大小写", NewReadConfig().WithSheetNames(" summary ").WithSheetNameMatch(SheetNameTrimmed), nil, false},
+		{"忽略空白和大小写", NewReadConfig().WithSheetNames(" summary ").WithSheetNameMatch(SheetNameTrimmedCaseInsensitive), []string{"Summary"}, false},
+		{"忽略内部空白", NewReadConfig().WithSheetNames("Q1Plan").WithSheetNameMatch(SheetNameTrimmed), []string{"Q1 Plan"}, false},
+		{"忽略内部空白和大小写", NewReadConfig().WithSheetNames("q1  plan").WithSheetNameMatch(SheetNameTrimmedCaseInsensitive), []string{"Q1 Plan"}, false},
+		{"精确匹配不忽略内部空白", NewReadConfig().WithSheetNames("Q1Plan"), nil, false},
+		{"严格模式", NewReadConfig().WithSheetNames("Sales", "Missing").WithStrictSheetNames(true), nil, true},
+		{"严格模式与页选择器", NewReadConfig().WithSheetNames("Missing").WithStrictSheetNames(true).WithPages(0), nil, true},
+		{"严格模式与页配置", NewReadConfig().WithSheetNames("Missing").WithStrictSheetNames(true).AddPageConfig(0, nil, nil), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ReadDocumentWithConfig(testFile, tt.config)
+			if tt.wantErr {
+				if !errors.Is(err, ErrSheetNotFound) {
+					t.Fatalf("期望 ErrSheetNotFound，实际: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("读取失败: %v", err)
+			}
+
+			var names []string
+			for _, page := range result.Pages {
+				names = append(names, page.PageName)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("期望工作表 %v，实际: %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestReadDocumentPreview(t *testing.T) {
+	dir := t.TempDir()
+
+	txtFile := filepath.Join(dir, "preview.txt")
+	if err := os.WriteFile(txtFile, []byte("你好世界\nhello world\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	csvFile := filepath.Join(dir, "preview.csv")
+	if err := os.WriteFile(csvFile, []byte("a,b\nc,d\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	pptxFile := filepath.Join(dir, "preview.pptx")
+	writeZipFile(t, pptxFile, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("第一页"),
+		"ppt/slides/slide2.xml": pptxSlideXML("第二页"),
+	})
+
+	tests := []struct {
+		name     string
+		file     string
+		maxRunes int
+		expected string
+	}{
+		{"按字符截断", txtFile, 3, "你好世"},
+		{"跨行截断", txtFile, 7, "你好世界\nhe"},
+		{"超过文档长度", txtFile, 100, "你好世界\nhello world\n"},
+		{"零长度", txtFile, 0, ""},
+		{"CSV 格式化输出", csvFile, 12, "Row 1: a | b"},
+		{"PPTX 幻灯片", pptxFile, 14, "\n=== 幻灯片 1 ==="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview, err := ReadDocumentPreview(tt.file, tt.maxRunes)
+			if err != nil {
+				t.Fatalf("预览失败: %v", err)
+			}
+			if preview != tt.expected {
+				t.Errorf("期望 %q，实际: %q", tt.expected, preview)
+			}
+		})
+	}
+
+	// 预览内容应与完整读取结果的前缀一致
+	doc, err := ReadDocument(pptxFile)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	preview, err := ReadDocumentPreview(pptxFile, 1000)
+	if err != nil {
+		t.Fatalf("预览失败: %v", err)
+	}
+	if preview != doc.Content {
+		t.Errorf("预览内容与完整内容不一致: %q vs %q", preview, doc.Content)
+	}
+
+	if _, err := ReadDocumentPreview(filepath.Join(dir, "missing.txt"), 10); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound，实际: %v", err)
+	}
+}
+
+func TestTsvFormat(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "data.tsv")
+	if err := os.WriteFile(testFile, []byte("name\tcity\n张三\tNew York, NY\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	doc, err := ReadDocument(testFile)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	expected := "Row 1: name | city\nRow 2: 张三 | New York, NY\n"
+	if doc.Content != expected {
+		t.Errorf("期望 %q，实际: %q", expected, doc.Content)
+	}
+	if doc.Metadata["columns"] != "2" {
+		t.Errorf("期望 2 列，实际: %s", doc.Metadata["columns"])
+	}
+
+	records, err := (&CsvReader{Comma: '\t'}).GetRecords(testFile)
+	if err != nil {
+		t.Fatalf("获取记录失败: %v", err)
+	}
+	if len(records) != 2 || records[1][1] != "New York, NY" {
+		t.Errorf("TSV 应按制表符分隔，实际: %v", records)
+	}
+}
+
+func TestJsonReader(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonFile := filepath.Join(dir, "config.json")
+	jsonData := `{"name": "张三", "age": 30, "tags": ["a", "b"], "address": {"city": "北京", "zip": null}, "extra": {}}`
+	if err := os.WriteFile(jsonFile, []byte(jsonData), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	doc, err := ReadDocument(jsonFile)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	expected := "name: 张三\nage: 30\ntags[0]: a\ntags[1]: b\naddress.city: 北京\naddress.zip: null\nextra: {}\n"
+	if doc.Content != expected {
+		t.Errorf("期望 %q，实际: %q", expected, doc.Content)
+	}
+	if doc.Metadata["type"] != "object" || doc.Metadata["keys"] != "5" {
+		t.Errorf("元数据不正确: %v", doc.Metadata)
+	}
+
+	jsonlFile := filepath.Join(dir, "app.jsonl")
+	jsonlData := "{\"level\": \"info\", \"msg\": \"started\"}\n\n{\"level\": \"error\", \"code\": 500}\n"
+	if err := os.WriteFile(jsonlFile, []byte(jsonlData), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	result, err := ReadDocumentWithConfig(jsonlFile, NewReadConfig().WithLines(1))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(result.Pages) != 1 || len(result.Pages[0].Lines) != 1 {
+		t.Fatalf("期望 1 行，实际: %+v", result.Pages)
+	}
+	if line := result.Pages[0].Lines[0]; line != "Record 2: level: error | code: 500" {
+		t.Errorf("记录内容不正确: %q", line)
+	}
+	if result.Metadata["records"] != "2" {
+		t.Errorf("期望 2 条记录，实际: %s", result.Metadata["records"])
+	}
+
+	invalidFile := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalidFile, []byte(`{"a": 1} {"b": 2}`), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	if _, err := ReadDocument(invalidFile); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("期望 ErrInvalidFormat，实际: %v", err)
+	}
+}
+
+func TestXmlReader(t *testing.T) {
+	dir := t.TempDir()
+
+	xmlFile := filepath.Join(dir, "catalog.xml")
+	xmlData := `<?xml version="1.0" encoding="UTF-8"?>
+<catalog xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <book id="1">
+    <dc:title>Go 语言</dc:title>
+    <price>59.00</price>
+    <empty/>
+  </book>
+  <book id="2"><dc:title>Rust</dc:title></book>
+</catalog>`
+	if err := os.WriteFile(xmlFile, []byte(xmlData), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	doc, err := ReadDocument(xmlFile)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	expected := "catalog/book/title: Go 语言\ncatalog/book/price: 59.00\ncatalog/book/title: Rust\n"
+	if doc.Content != expected {
+		t.Errorf("期望 %q，实际: %q", expected, doc.Content)
+	}
+	if doc.Metadata["root"] != "catalog" || doc.Metadata["elements"] != "7" {
+		t.Errorf("元数据不正确: %v", doc.Metadata)
+	}
+
+	invalidFile := filepath.Join(dir, "invalid.xml")
+	if err := os.WriteFile(invalidFile, []byte("<a><b></a>"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	if _, err := ReadDocument(invalidFile); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("期望 ErrInvalidFormat，实际: %v", err)
+	}
+}
+
+// corruptZipEntry 覆盖 zip 包中指定条目的压缩数据，使其存在但无法读取
+func corruptZipEntry(t *testing.T, path, name string) {
+	t.Helper()
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("打开 zip 失败: %v", err)
+	}
+	var offset, size int64
+	for _, file := range zr.File {
+		if file.Name == name {
+			offset, err = file.DataOffset()
+			if err != nil {
+				t.Fatalf("获取数据偏移失败: %v", err)
+			}
+			size = int64(file.CompressedSize64)
+		}
+	}
+	zr.Close()
+	if size == 0 {
+		t.Fatalf("zip 中不存在条目 %s", name)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("打开文件失败: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteAt([]byte(strings.Repeat("\xff", int(size))), offset); err != nil {
+		t.Fatalf("写入损坏数据失败: %v", err)
+	}
+}
+
+func TestCorruptedZipParts(t *testing.T) {
+	dir := t.TempDir()
+	documentXML := `<w:document xmlns:w="w"><w:body><w:p><w:r><w:t>Hello</w:t></w:r></w:p></w:body></w:document>`
+	coreXML := `<cp:coreProperties xmlns:cp="cp" xmlns:dc="dc"><dc:title>标题</dc:title></cp:coreProperties>`
+
+	corruptedDoc := filepath.Join(dir, "corrupted.docx")
+	writeZipFile(t, corruptedDoc, map[string]string{"word/document.xml": documentXML})
+	corruptZipEntry(t, corruptedDoc, "word/document.xml")
+
+	missingDoc := filepath.Join(dir, "missing.docx")
+	writeZipFile(t, missingDoc, map[string]string{"docProps/core.xml": coreXML})
+
+	corruptedCore := filepath.Join(dir, "core.docx")
+	writeZipFile(t, corruptedCore, map[string]string{"word/document.xml": documentXML, "docProps/core.xml": coreXML})
+	corruptZipEntry(t, corruptedCore, "docProps/core.xml")
+
+	corruptedSlide := filepath.Join(dir, "corrupted.pptx")
+	writeZipFile(t, corruptedSlide, map[string]string{"ppt/slides/slide1.xml": pptxSlideXML("第一页")})
+	corruptZipEntry(t, corruptedSlide, "ppt/slides/slide1.xml")
+
+	tests := []struct {
+		name     string
+		read     func() error
+		expected error
+	}{
+		{"DOCX 正文损坏", func() error { _, err := ReadDocument(corruptedDoc); return err }, ErrFileRead},
+		{"DOCX 正文损坏（配置读取）", func() error { _, err := ReadDocumentWithConfig(corruptedDoc, nil); return err }, ErrFileRead},
+		{"DOCX 缺少正文", func() error { _, err := ReadDocument(missingDoc); return err }, ErrInvalidFormat},
+		{"DOCX 核心属性损坏", func() error { _, err := (&DocxReader{}).GetMetadata(corruptedCore); return err }, ErrFileRead},
+		{"PPTX 幻灯片损坏", func() error { _, err := ReadDocument(corruptedSlide); return err }, ErrFileRead},
+		{"PPTX 幻灯片损坏（严格模式）", func() error {
+			_, err := ReadDocumentWithConfig(corruptedSlide, NewReadConfig().WithFailOnPartialError(true))
+			return err
+		}, ErrFileParse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.read(); !errors.Is(err, tt.expected) {
+				t.Errorf("期望 %v，实际: %v", tt.expected, err)
+			}
+		})
+	}
+
+	// 缺少可选的核心属性时不应报错
+	if _, err := (&DocxReader{}).GetMetadata(corruptedDoc); err != nil {
+		t.Errorf("读取元数据失败: %v", err)
+	}
+
+	// 核心属性损坏时正文仍可读取，元数据的问题记录为警告
+	result, err := ReadDocumentWithConfig(corruptedCore, nil)
+	if err != nil {
+		t.Fatalf("核心属性损坏时读取正文失败: %v", err)
+	}
+	if result.Content != "Hello" || len(result.Warnings) != 1 || !strings.HasPrefix(result.Warnings[0], "metadata: ") {
+		t.Errorf("期望正文 Hello 和一条元数据警告，实际 %q, %v", result.Content, result.Warnings)
+	}
+	if result.Metadata["section_count"] != "1" {
+		t.Errorf("期望 section_count 为 1，实际 %q", result.Metadata["section_count"])
+	}
+	if _, err := ReadDocumentWithConfig(corruptedCore, NewReadConfig().WithFailOnPartialError(true)); !errors.Is(err, ErrFileParse) {
+		t.Errorf("期望 ErrFileParse，实际 %v", err)
+	}
+
+	// PPTX 的元数据部件损坏时同样只记录警告
+	corruptedPptxCore := filepath.Join(dir, "core.pptx")
+	writeZipFile(t, corruptedPptxCore, map[string]string{"ppt/slides/slide1.xml": pptxSlideXML("第一页"), "docProps/core.xml": coreXML})
+	corruptZipEntry(t, corruptedPptxCore, "docProps/core.xml")
+	result, err = ReadDocumentWithConfig(corruptedPptxCore, nil)
+	if err != nil {
+		t.Fatalf("核心属性损坏时读取幻灯片失败: %v", err)
+	}
+	if !strings.Contains(result.Content, "第一页") || len(result.Warnings) != 1 || !strings.HasPrefix(result.Warnings[0], "metadata: ") {
+		t.Errorf("期望幻灯片正文和一条元数据警告，实际 %q, %v", result.Content, result.Warnings)
+	}
+	if result.Metadata["slide_count"] != "1" {
+		t.Errorf("期望 slide_count 为 1，实际 %q", result.Metadata["slide_count"])
+	}
+	if _, err := ReadDocumentWithConfig(corruptedPptxCore, NewReadConfig().WithFailOnPartialError(true)); !errors.Is(err, ErrFileParse) {
+		t.Errorf("期望 ErrFileParse，实际 %v", err)
+	}
+	if _, err := (&PptxReader{}).GetMetadata(corruptedPptxCore); !errors.Is(err, ErrFileRead) {
+		t.Errorf("期望 ErrFileRead，实际 %v", err)
+	}
+}
+
+func TestColumnSelector(t *testing.T) {
+	dir := t.TempDir()
+
+	csvFile := filepath.Join(dir, "wide.csv")
+	if err := os.WriteFile(csvFile, []byte("a,b,c,d,e,f\n1,2,3,4,5,6\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	xlsxFile := filepath.Join(dir, "wide.xlsx")
+	writeXlsxFile(t, xlsxFile, map[string][][]any{
+		"Sheet1": {{"a", "b", "c", "d", "e", "f"}, {1, 2, 3, 4}},
+	})
+
+	tests := []struct {
+		name     string
+		file     string
+		config   *ReadConfig
+		expected []string
+	}{
+		{"CSV 离散列", csvFile, NewReadConfig().WithColumns(0, 3, 5), []string{"Row 1: a | d | f", "Row 2: 1 | 4 | 6"}},
+		{"CSV 越界列", csvFile, NewReadConfig().WithColumns(1, 9), []string{"Row 1: b", "Row 2: 2"}},
+		{"CSV 列范围", csvFile, NewReadConfig().WithColumnRange(1, 2), []string{"Row 1: b | c", "Row 2: 2 | 3"}},
+		{"CSV 列与行组合", csvFile, NewReadConfig().WithColumns(5).WithLines(0), []string{"Row 1: f"}},
+		{"XLSX 离散列", xlsxFile, NewReadConfig().WithColumns(0, 3, 5), []string{"Row 0: a | d | f", "Row 1: 1 | 4"}},
+		{"CSV 无行号前缀", csvFile, NewReadConfig().WithColumns(0, 1).WithRawCells(true), []string{"a | b", "1 | 2"}},
+		{"XLSX 无行号前缀", xlsxFile, NewReadConfig().WithColumns(0, 1).WithRawCells(true), []string{"a | b", "1 | 2"}},
+		{"CSV 自定义分隔符", csvFile, NewReadConfig().WithColumns(0, 1).WithRawCells(true).WithCellSeparator("\t"), []string{"a\tb", "1\t2"}},
+		{"XLSX 自定义分隔符", xlsxFile, NewReadConfig().WithColumns(0, 1).WithCellSeparator(","), []string{"Row 0: a,b", "Row 1: 1,2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ReadDocumentWithConfig(tt.file, tt.config)
+			if err != nil {
+				t.Fatalf("读取失败: %v", err)
+			}
+			if len(result.Pages) != 1 {
+				t.Fatalf("期望 1 页，实际: %d", len(result.Pages))
+			}
+			if got := strings.Join(result.Pages[0].Lines, "\n"); got != strings.Join(tt.expected, "\n") {
+				t.Errorf("期望 %q，实际: %q", tt.expected, result.Pages[0].Lines)
+			}
+		})
+	}
+}
+
+func TestReaderCellSeparator(t *testing.T) {
+	dir := t.TempDir()
+
+	csvFile := filepath.Join(dir, "data.csv")
+	if err := os.WriteFile(csvFile, []byte("a,b\n1,2\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	text, err := (&CsvReader{CellSeparator: ","}).ReadText(csvFile)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if expected := "Row 1: a,b\nRow 2: 1,2\n"; text != expected {
+		t.Errorf("期望 %q，实际: %q", expected, text)
+	}
+
+	xlsxFile := filepath.Join(dir, "data.xlsx")
+	writeXlsxFile(t, xlsxFile, map[string][][]any{"Sheet1": {{"a", "b"}}})
+	text, err = (&XlsxReader{CellSeparator: "\t"}).ReadText(xlsxFile)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if !strings.Contains(text, "第 1 行: a\tb\n") {
+		t.Errorf("期望使用制表符分隔，实际: %q", text)
+	}
+}
+
+func TestLineGroupPattern(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "app.txt")
+	logData := "启动中\n" +
+		"2024-01-01 10:00:00 INFO started\n" +
+		"2024-01-01 10:00:01 ERROR failed\n" +
+		"java.lang.NullPointerException\n" +
+		"\tat com.example.Main.run(Main.java:10)\n" +
+		"2024-01-01 10:00:02 INFO recovered"
+	if err := os.WriteFile(testFile, []byte(logData), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	result, err := ReadDocumentWithConfig(testFile, NewReadConfig().WithLineGroupPattern(`^\d{4}-\d{2}-\d{2} `))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+
+	expected := []string{
+		"启动中",
+		"2024-01-01 10:00:00 INFO started",
+		"2024-01-01 10:00:01 ERROR failed\njava.lang.NullPointerException\n\tat com.example.Main.run(Main.java:10)",
+		"2024-01-01 10:00:02 INFO recovered",
+	}
+	lines := result.Pages[0].Lines
+	if len(lines) != len(expected) {
+		t.Fatalf("期望 %d 条记录，实际: %d (%q)", len(expected), len(lines), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("记录 %d: 期望 %q，实际: %q", i, expected[i], lines[i])
+		}
+	}
+	if result.Content != logData {
+		t.Errorf("分组后的完整内容应与原文一致")
+	}
+
+	// 行选择器作用于分组后的记录
+	result, err = ReadDocumentWithConfig(testFile, NewReadConfig().WithLineGroupPattern(`^\d{4}-`).WithLines(2))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(result.Pages[0].Lines) != 1 || result.Pages[0].Lines[0] != expected[2] {
+		t.Errorf("期望选中堆栈记录，实际: %q", result.Pages[0].Lines)
+	}
+
+	if _, err := ReadDocumentWithConfig(testFile, NewReadConfig().WithLineGroupPattern(`(`)); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("期望 ErrInvalidConfig，实际: %v", err)
+	}
+}
+
+// writePdfFile 按顺序写入对象并生成交叉引用表，第 1 个对象必须是文档目录
+// trailer 为追加到 trailer 字典中的额外条目（例如 "/Info 5 0 R"）
+func writePdfFile(t *testing.T, path, trailer string, objects ...string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R %s >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, trailer, xref)
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("创建 PDF 文件失败: %v", err)
+	}
+}
+
+// TestPdfPageDimensionsParentCycle 测试 /Parent 循环引用时查找可继承属性不会死循环
+func TestPdfPageDimensionsParentCycle(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "cycle.pdf")
+	writePdfFile(t, testFile, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 4 0 R >>",
+		"<< /Type /Pages /Parent 4 0 R >>",
+	)
+
+	done := make(chan []PageSize, 1)
+	go func() {
+		sizes, _ := (&PdfReader{}).GetPageDimensions(testFile)
+		done <- sizes
+	}()
+
+	select {
+	case sizes := <-done:
+		if len(sizes) != 1 || sizes[0] != (PageSize{}) {
+			t.Errorf("期望 1 个空尺寸，实际 %+v", sizes)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("查找可继承属性时陷入循环")
+	}
+}
+
+func TestPdfPageDimensions(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "pages.pdf")
+	writePdfFile(t, testFile, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /MediaBox [0 0 595.28 841.89] >>",
+		"<< /Type /Page /Parent 2 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Rotate -90 >>",
+	)
+
+	reader := &PdfReader{}
+	sizes, err := reader.GetPageDimensions(testFile)
+	if err != nil {
+		t.Fatalf("获取页面尺寸失败: %v", err)
+	}
+
+	expected := []PageSize{
+		{Width: 595.28, Height: 841.89, Rotation: 0},
+		{Width: 612, Height: 792, Rotation: 270},
+	}
+	if len(sizes) != len(expected) {
+		t.Fatalf("期望 %d 页，实际: %d", len(expected), len(sizes))
+	}
+	for i := range expected {
+		if sizes[i] != expected[i] {
+			t.Errorf("第 %d 页: 期望 %+v，实际: %+v", i+1, expected[i], sizes[i])
+		}
+	}
+	if sizes[0].IsLandscape() || !sizes[1].IsLandscape() {
+		t.Errorf("页面方向判断错误: %+v", sizes)
+	}
+
+	metadata, err := reader.GetMetadata(testFile)
+	if err != nil {
+		t.Fatalf("获取元数据失败: %v", err)
+	}
+	if metadata["page_size"] != "595.28x841.89" || metadata["page_orientation"] != "portrait" {
+		t.Errorf("元数据不正确: %v", metadata)
+	}
+}
+
+func TestMaxFileSize(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(testFile, []byte("a,b\n1,2\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	if _, err := ReadDocumentLimited(testFile, 4); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("期望 ErrFileTooLarge，实际: %v", err)
+	}
+	if _, err := ReadDocumentLimited(testFile, 8); err != nil {
+		t.Errorf("文件大小等于上限时应允许读取: %v", err)
+	}
+	if _, err := ReadDocumentLimited(testFile, 0); err != nil {
+		t.Errorf("上限为 0 时不应限制: %v", err)
+	}
+	if _, err := ReadDocumentLimited(testFile+".missing", 8); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound，实际: %v", err)
+	}
+
+	if _, err := ReadDocumentWithConfig(testFile, NewReadConfig().WithMaxFileSize(4)); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("期望 ErrFileTooLarge，实际: %v", err)
+	}
+	if _, err := ReadDocumentWithConfig(testFile, NewReadConfig().WithMaxFileSize(1024)); err != nil {
+		t.Errorf("读取失败: %v", err)
+	}
+}
+
+func TestZipResourceLimits(t *testing.T) {
+	dir := t.TempDir()
+
+	// 高压缩比的正文：压缩后很小，解压后超过限制
+	docxFile := filepath.Join(dir, "bomb.docx")
+	writeZipFile(t, docxFile, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="w"><w:body><w:p><w:r><w:t>` + strings.Repeat("a", 64*1024) + `</w:t></w:r></w:p></w:body></w:document>`,
+	})
+
+	pptxFile := filepath.Join(dir, "slides.pptx")
+	writeZipFile(t, pptxFile, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("1"),
+		"ppt/slides/slide2.xml": pptxSlideXML("2"),
+		"ppt/slides/slide3.xml": pptxSlideXML("3"),
+	})
+
+	xlsxFile := filepath.Join(dir, "sheets.xlsx")
+	writeXlsxFile(t, xlsxFile, map[string][][]any{
+		"Sheet1": {{"a"}},
+		"Sheet2": {{"b"}},
+	})
+
+	tests := []struct {
+		name    string
+		file    string
+		config  *ReadConfig
+		wantErr bool
+	}{
+		{"DOCX 部件超过解压上限", docxFile, NewReadConfig().WithMaxDecompressedSize(1024), true},
+		{"DOCX 默认上限", docxFile, NewReadConfig(), false},
+		{"DOCX 不限制", docxFile, NewReadConfig().WithMaxDecompressedSize(-1), false},
+		{"PPTX 幻灯片数量超限", pptxFile, NewReadConfig().WithMaxParts(2), true},
+		{"PPTX 幻灯片数量未超限", pptxFile, NewReadConfig().WithMaxParts(3), false},
+		{"XLSX 工作表数量超限", xlsxFile, NewReadConfig().WithMaxParts(1), true},
+		{"XLSX 部件超过解压上限", xlsxFile, NewReadConfig().WithMaxDecompressedSize(16), true},
+		{"XLSX 未超限", xlsxFile, NewReadConfig().WithMaxParts(2), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadDocumentWithConfig(tt.file, tt.config)
+			if tt.wantErr {
+				if !errors.Is(err, ErrFileTooLarge) {
+					t.Errorf("期望 ErrFileTooLarge，实际: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("读取失败: %v", err)
+			}
+		})
+	}
+}
+
+func TestReadDocumentRaw(t *testing.T) {
+	dir := t.TempDir()
+
+	docxFile := filepath.Join(dir, "raw.docx")
+	writeZipFile(t, docxFile, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="w"><w:body>` +
+			`<w:p><w:r><w:t>Hello </w:t></w:r><w:r><w:t>World</w:t></w:r></w:p>` +
+			`<w:p></w:p>` +
+			`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>单元格</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+			`</w:body></w:document>`,
+	})
+
+	pptxFile := filepath.Join(dir, "raw.pptx")
+	writeZipFile(t, pptxFile, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("标题", "正文"),
+	})
+
+	csvFile := filepath.Join(dir, "raw.csv")
+	if err := os.WriteFile(csvFile, []byte("a,b\n1,\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	xlsxFile := filepath.Join(dir, "raw.xlsx")
+	writeXlsxFile(t, xlsxFile, map[string][][]any{"Sheet1": {{"a", "b"}, {1, 2}}})
+
+	jsonFile := filepath.Join(dir, "raw.json")
+	if err := os.WriteFile(jsonFile, []byte(`{"name": "张三", "tags": ["x", "y"]}`), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		expected string
+	}{
+		{"DOCX", docxFile, "Hello World\n单元格\n"},
+		{"PPTX", pptxFile, "标题\n正文\n"},
+		{"CSV", csvFile, "a b\n1\n"},
+		{"XLSX", xlsxFile, "a b\n1 2\n"},
+		{"JSON", jsonFile, "张三\nx\ny\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := ReadDocumentRaw(tt.file)
+			if err != nil {
+				t.Fatalf("读取失败: %v", err)
+			}
+			if text != tt.expected {
+				t.Errorf("期望 %q，实际: %q", tt.expected, text)
+			}
+		})
+	}
+
+	if _, err := ReadDocumentRaw(filepath.Join(dir, "missing.txt")); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound，实际: %v", err)
+	}
+}
+
+// writeBenchmarkFiles 生成用于对比 ReadDocument 和 ReadDocumentRaw 的大文件
+func writeBenchmarkFiles(b *testing.B) map[string]string {
+	b.Helper()
+	dir := b.TempDir()
+
+	var text, csvData, docBody strings.Builder
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(&text, "第 %d 行：The quick brown fox jumps over the lazy dog.\n", i)
+		fmt.Fprintf(&csvData, "%d,name-%d,value-%d,%d\n", i, i, i, i*3)
+		fmt.Fprintf(&docBody, "<w:p><w:r><w:t>段落 %d：The quick brown fox jumps over the lazy dog.</w:t></w:r></w:p>", i)
+	}
+
+	files := map[string]string{
+		"TXT":  filepath.Join(dir, "large.txt"),
+		"CSV":  filepath.Join(dir, "large.csv"),
+		"DOCX": filepath.Join(dir, "large.docx"),
+	}
+	if err := os.WriteFile(files["TXT"], []byte(text.String()), 0644); err != nil {
+		b.Fatalf("创建测试文件失败: %v", err)
+	}
+	if err := os.WriteFile(files["CSV"], []byte(csvData.String()), 0644); err != nil {
+		b.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	f, err := os.Create(files["DOCX"])
+	if err != nil {
+		b.Fatalf("创建测试文件失败: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	w, _ := zw.Create("word/document.xml")
+	fmt.Fprintf(w, `<w:document xmlns:w="w"><w:body>%s</w:body></w:document>`, docBody.String())
+	zw.Close()
+	f.Close()
+
+	return files
+}
+
+// BenchmarkReadDocumentRaw 对比 ReadDocumentRaw 与 ReadDocument 在大文件上的性能
+func BenchmarkReadDocumentRaw(b *testing.B) {
+	files := writeBenchmarkFiles(b)
+
+	for _, name := range []string{"TXT", "CSV", "DOCX"} {
+		testFile := files[name]
+
+		b.Run(name+"/Content", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = ReadDocument(testFile)
+			}
+		})
+
+		b.Run(name+"/Raw", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = ReadDocumentRaw(testFile)
+			}
+		})
+	}
+}
+
+func TestTxtStreamLines(t *testing.T) {
+	dir := t.TempDir()
+
+	inputs := []string{"", "a", "a\n", "a\r\nb\n\nc", "第一行\n第二行"}
+	for i, input := range inputs {
+		testFile := filepath.Join(dir, fmt.Sprintf("stream%d.txt", i))
+		if err := os.WriteFile(testFile, []byte(input), 0644); err != nil {
+			t.Fatalf("创建测试文件失败: %v", err)
+		}
+
+		var lines []string
+		err := (&TxtReader{}).StreamLines(testFile, func(line string) error {
+			lines = append(lines, line)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("流式读取失败: %v", err)
+		}
+		if expected := strings.Split(input, "\n"); strings.Join(lines, "|") != strings.Join(expected, "|") || len(lines) != len(expected) {
+			t.Errorf("输入 %q: 期望 %q，实际: %q", input, expected, lines)
+		}
+
+		// 流式读取与完整读取的结果应一致
+		for _, config := range []*ReadConfig{nil, NewReadConfig().WithLines(1, 2).WithLineNumbers(true)} {
+			full, err := (&TxtReader{}).ReadWithConfig(testFile, config)
+			if err != nil {
+				t.Fatalf("读取失败: %v", err)
+			}
+			streamed, err := (&TxtReader{StreamThreshold: -1}).ReadWithConfig(testFile, config)
+			if err != nil {
+				t.Fatalf("流式读取失败: %v", err)
+			}
+			if fmt.Sprint(full.Pages) != fmt.Sprint(streamed.Pages) || full.Content != streamed.Content {
+				t.Errorf("输入 %q: 流式结果 %+v 与完整结果 %+v 不一致", input, streamed.Pages, full.Pages)
+			}
+		}
+	}
+
+	longFile := filepath.Join(dir, "long.txt")
+	if err := os.WriteFile(longFile, []byte(strings.Repeat("x", 100)+"\nshort"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	err := (&TxtReader{MaxLineLength: 10}).StreamLines(longFile, func(string) error { return nil })
+	if !errors.Is(err, ErrFileRead) {
+		t.Errorf("期望 ErrFileRead，实际: %v", err)
+	}
+
+	// 回调返回的错误应原样返回
+	stop := errors.New("stop")
+	err = (&TxtReader{}).StreamLines(longFile, func(string) error { return stop })
+	if err != stop {
+		t.Errorf("期望回调错误，实际: %v", err)
+	}
+}
+
+func TestSkipContentString(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "slides.pptx")
+	writeZipFile(t, testFile, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("第一页"),
+		"ppt/slides/slide2.xml": pptxSlideXML("第二页"),
+	})
+
+	full, err := ReadDocumentWithConfig(testFile, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+
+	skipped, err := ReadDocumentWithConfig(testFile, NewReadConfig().WithSkipContentString(true))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if skipped.Content != "" {
+		t.Errorf("跳过时 Content 应为空，实际: %q", skipped.Content)
+	}
+	if len(skipped.Pages) != 2 {
+		t.Errorf("结构化页面不应受影响，实际: %d 页", len(skipped.Pages))
+	}
+	if built := skipped.BuildContent(); built != full.Content {
+		t.Errorf("BuildContent 应与读取时生成的内容一致: %q vs %q", built, full.Content)
+	}
+}
+
+// docxDocumentXML 构造包含给定 body 内容的 document.xml
+func docxDocumentXML(body string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		body + `</w:body></w:document>`
+}
+
+func TestDocxPageBreaks(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "breaks.docx")
+	writeZipFile(t, testFile, map[string]string{
+		"word/document.xml": docxDocumentXML(
+			`<w:p><w:r><w:t>第一页</w:t></w:r></w:p>` +
+				`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>A</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>B</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+				`<w:p><w:r><w:t>分页前</w:t></w:r><w:r><w:br w:type="page"/><w:t>分页后</w:t></w:r></w:p>` +
+				`<w:p><w:pPr><w:sectPr/></w:pPr><w:r><w:t>节末</w:t></w:r></w:p>` +
+				`<w:p><w:pPr><w:sectPr><w:type w:val="continuous"/></w:sectPr></w:pPr><w:r><w:t>连续节</w:t></w:r></w:p>` +
+				`<w:p><w:r><w:br/><w:t>第三页</w:t></w:r></w:p>` +
+				`<w:sectPr/>`),
+	})
+
+	result, err := ReadDocumentWithConfig(testFile, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if result.TotalPages != 3 {
+		t.Fatalf("期望 3 页，实际: %d", result.TotalPages)
+	}
+
+	expected := [][]string{
+		{"第一页", "分页前", "A \tB"},
+		{"分页后", "节末"},
+		{"连续节", "第三页"},
+	}
+	for i, page := range result.Pages {
+		if !reflect.DeepEqual(page.Lines, expected[i]) {
+			t.Errorf("第 %d 页期望 %q，实际 %q", i, expected[i], page.Lines)
+		}
+	}
+
+	// 页面选择应作用于 DOCX
+	result, err = ReadDocumentWithConfig(testFile, NewReadConfig().WithPages(1))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(result.Pages) != 1 || result.Pages[0].PageNumber != 1 || result.Content != "分页后\n节末" {
+		t.Errorf("页面选择结果不正确: %+v", result.Pages)
+	}
+
+	// 没有分页符的文档仍为单页
+	singleFile := filepath.Join(t.TempDir(), "single.docx")
+	writeZipFile(t, singleFile, map[string]string{
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>唯一</w:t></w:r></w:p>`),
+	})
+	result, err = ReadDocumentWithConfig(singleFile, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if result.TotalPages != 1 || result.Content != "唯一" {
+		t.Errorf("单页文档结果不正确: %d 页, %q", result.TotalPages, result.Content)
+	}
+}
+
+func TestDocxTableMode(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "tables.docx")
+	writeZipFile(t, testFile, map[string]string{
+		"word/document.xml": docxDocumentXML(
+			`<w:p><w:r><w:t>正文</w:t></w:r></w:p>` +
+				`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>单元格</w:t></w:r></w:p></w:tc></w:tr></w:tbl>`),
+	})
+
+	tests := []struct {
+		mode     TableMode
+		expected string
+	}{
+		{TableInclude, "正文\n单元格"},
+		{TableExclude, "正文"},
+		{TableOnly, "单元格"},
+	}
+
+	for _, tt := range tests {
+		result, err := ReadDocumentWithConfig(testFile, NewReadConfig().WithTableMode(tt.mode))
+		if err != nil {
+			t.Fatalf("读取失败: %v", err)
+		}
+		if result.Content != tt.expected {
+			t.Errorf("模式 %d: 期望 %q，实际 %q", tt.mode, tt.expected, result.Content)
+		}
+
+		text, err := (&DocxReader{TableMode: tt.mode}).ReadText(testFile)
+		if err != nil {
+			t.Fatalf("读取失败: %v", err)
+		}
+		hasParagraph := strings.Contains(text, "正文")
+		hasTable := strings.Contains(text, "单元格")
+		if hasParagraph != (tt.mode != TableOnly) || hasTable != (tt.mode != TableExclude) {
+			t.Errorf("模式 %d: ReadText 输出不正确: %q", tt.mode, text)
+		}
+	}
+}
+
+func TestDocxGetFormFields(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "form.docx")
+	writeZipFile(t, testFile, map[string]string{
+		"word/document.xml": docxDocumentXML(
+			`<w:sdt><w:sdtPr><w:alias w:val="姓名"/><w:tag w:val="name"/></w:sdtPr>` +
+				`<w:sdtContent><w:p><w:r><w:t>张三</w:t></w:r></w:p></w:sdtContent></w:sdt>` +
+				`<w:sdt><w:sdtPr><w:alias w:val="地址"/></w:sdtPr>` +
+				`<w:sdtContent><w:p><w:r><w:t>第一行</w:t></w:r></w:p><w:p><w:r><w:t>第二行</w:t></w:r></w:p></w:sdtContent></w:sdt>` +
+				`<w:sdt><w:sdtPr><w:tag w:val="date"/><w:showingPlcHdr/></w:sdtPr>` +
+				`<w:sdtContent><w:p><w:r><w:t>单击此处输入日期</w:t></w:r></w:p></w:sdtContent></w:sdt>` +
+				`<w:sdt><w:sdtContent><w:p><w:r><w:t>无名称</w:t></w:r></w:p></w:sdtContent></w:sdt>` +
+				`<w:p><w:r><w:fldChar w:fldCharType="begin"><w:ffData><w:name w:val="Phone"/></w:ffData></w:fldChar></w:r>` +
+				`<w:r><w:instrText> FORMTEXT </w:instrText></w:r><w:r><w:fldChar w:fldCharType="separate"/></w:r>` +
+				`<w:r><w:t>123</w:t></w:r><w:r><w:t>456</w:t></w:r><w:r><w:fldChar w:fldCharType="end"/></w:r></w:p>`),
+	})
+
+	fields, err := (&DocxReader{}).GetFormFields(testFile)
+	if err != nil {
+		t.Fatalf("读取表单失败: %v", err)
+	}
+
+	expected := map[string]string{
+		"name":  "张三",
+		"地址":    "第一行\n第二行",
+		"date":  "",
+		"Phone": "123456",
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("期望 %q，实际 %q", expected, fields)
+	}
+
+	if _, err := (&DocxReader{}).GetFormFields(testFile + ".missing"); !errors.Is(err, ErrFileOpen) {
+		t.Errorf("期望 ErrFileOpen，实际: %v", err)
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	// 每种支持的格式都应有对应的读取器
+	for _, ext := range GetSupportedFormats() {
+		reader, err := NewReader(ext)
+		if err != nil || reader == nil {
+			t.Errorf("%s: 期望返回读取器，实际错误: %v", ext, err)
+		}
+		if _, err := NewConfigurableReader(ext); err != nil {
+			t.Errorf("%s: 期望返回可配置读取器，实际错误: %v", ext, err)
+		}
+	}
+
+	// 扩展名不区分大小写，可以省略前导点
+	reader, err := NewReader("PPTX")
+	if err != nil {
+		t.Fatalf("创建读取器失败: %v", err)
+	}
+	if _, ok := reader.(*PptxReader); !ok {
+		t.Errorf("期望 *PptxReader，实际 %T", reader)
+	}
+
+	configurable, err := NewConfigurableReader(".tsv")
+	if err != nil {
+		t.Fatalf("创建读取器失败: %v", err)
+	}
+	if csvReader, ok := configurable.(*CsvReader); !ok || csvReader.Comma != '\t' {
+		t.Errorf("期望使用制表符的 *CsvReader，实际 %#v", configurable)
+	}
+
+	if _, err := NewReader(".unknown"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("期望 ErrUnsupportedFormat，实际: %v", err)
+	}
+	if _, err := NewConfigurableReader(""); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("期望 ErrUnsupportedFormat，实际: %v", err)
+	}
+}
+
+// failingWriter 写入指定字节数后返回错误
+type failingWriter struct {
+	remaining int
+	err       error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.remaining {
+		n := w.remaining
+		w.remaining = 0
+		return n, w.err
+	}
+	w.remaining -= len(p)
+	return len(p), nil
+}
+
+func TestWriteDocumentText(t *testing.T) {
+	dir := t.TempDir()
+	pptxFile := filepath.Join(dir, "slides.pptx")
+	writeZipFile(t, pptxFile, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("第一页", "内容"),
+		"ppt/slides/slide2.xml": pptxSlideXML("第二页"),
+	})
+	csvFile := filepath.Join(dir, "data.csv")
+	if err := os.WriteFile(csvFile, []byte("a,b\n1,2\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	for _, path := range []string{pptxFile, csvFile} {
+		doc, err := ReadDocument(path)
+		if err != nil {
+			t.Fatalf("读取失败: %v", err)
+		}
+
+		var buf bytes.Buffer
+		n, err := WriteDocumentText(&buf, path)
+		if err != nil {
+			t.Fatalf("写入失败: %v", err)
+		}
+		if buf.String() != doc.Content {
+			t.Errorf("%s: 写入内容应与 ReadDocument 一致: %q vs %q", path, buf.String(), doc.Content)
+		}
+		if n != int64(buf.Len()) {
+			t.Errorf("%s: 返回的字节数 %d 与实际写入 %d 不一致", path, n, buf.Len())
+		}
+	}
+
+	// 写入错误应原样返回，并报告已写入的字节数
+	writeErr := errors.New("disk full")
+	n, err := WriteDocumentText(&failingWriter{remaining: 5, err: writeErr}, pptxFile)
+	if err != writeErr {
+		t.Errorf("期望写入错误，实际: %v", err)
+	}
+	if n != 5 {
+		t.Errorf("期望已写入 5 字节，实际 %d", n)
+	}
+
+	unknownFile := filepath.Join(dir, "file.unknown")
+	if err := os.WriteFile(unknownFile, []byte("x"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	if _, err := WriteDocumentText(io.Discard, unknownFile); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("期望 ErrUnsupportedFormat，实际: %v", err)
+	}
+	if _, err := WriteDocumentText(io.Discard, filepath.Join(dir, "missing.txt")); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound，实际: %v", err)
+	}
+}
+
+func TestXlsxMaxRowsPerSheet(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "rows.xlsx")
+	writeXlsxFile(t, testFile, map[string][][]any{
+		"Long":  {{"r1"}, {"r2"}, {"r3"}, {"r4"}},
+		"Short": {{"s1"}, {"s2"}},
+	})
+
+	result, err := ReadDocumentWithConfig(testFile, NewReadConfig().WithMaxRowsPerSheet(2))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	for _, page := range result.Pages {
+		if len(page.Lines) != 2 {
+			t.Errorf("工作表 %s 期望 2 行，实际 %d 行", page.PageName, len(page.Lines))
+		}
+	}
+	if result.Metadata["truncated"] != "true" {
+		t.Errorf("期望 truncated 为 true，实际 %q", result.Metadata["truncated"])
+	}
+
+	// 所有工作表都未超过上限时不截断
+	result, err = ReadDocumentWithConfig(testFile, NewReadConfig().WithMaxRowsPerSheet(4))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if result.Metadata["truncated"] != "false" || result.TotalLines != 6 {
+		t.Errorf("期望完整读取 6 行且未截断，实际 %d 行，truncated=%q", result.TotalLines, result.Metadata["truncated"])
+	}
+
+	// 未设置上限时不记录 truncated
+	result, err = ReadDocumentWithConfig(testFile, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if _, ok := result.Metadata["truncated"]; ok {
+		t.Errorf("未设置上限时不应包含 truncated")
+	}
+
+	reader := &XlsxReader{}
+	rows, err := reader.GetSheetData(testFile, "Long", 3)
+	if err != nil {
+		t.Fatalf("读取工作表失败: %v", err)
+	}
+	if !reflect.DeepEqual(rows, [][]string{{"r1"}, {"r2"}, {"r3"}}) {
+		t.Errorf("期望前 3 行，实际 %q", rows)
+	}
+	rows, err = reader.GetSheetData(testFile, "Long")
+	if err != nil || len(rows) != 4 {
+		t.Errorf("不限制行数时期望 4 行，实际 %d 行，错误: %v", len(rows), err)
+	}
+}
+
+func TestDocxTrackChangesMode(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "review.docx")
+	writeZipFile(t, testFile, map[string]string{
+		"word/document.xml": docxDocumentXML(
+			`<w:p><w:r><w:t>价格为</w:t></w:r>` +
+				`<w:del w:author="A"><w:r><w:delText>100</w:delText></w:r></w:del>` +
+				`<w:ins w:author="A"><w:r><w:t>120</w:t></w:r></w:ins>` +
+				`<w:r><w:t>元</w:t></w:r></w:p>`),
+	})
+
+	tests := []struct {
+		mode     TrackChangesMode
+		expected string
+	}{
+		{TrackChangesRaw, "价格为元"},
+		{TrackChangesFinal, "价格为120元"},
+		{TrackChangesOriginal, "价格为100元"},
+	}
+
+	for _, tt := range tests {
+		result, err := ReadDocumentWithConfig(testFile, NewReadConfig().WithTrackChangesMode(tt.mode))
+		if err != nil {
+			t.Fatalf("读取失败: %v", err)
+		}
+		if result.Content != tt.expected {
+			t.Errorf("模式 %d: 期望 %q，实际 %q", tt.mode, tt.expected, result.Content)
+		}
+
+		text, err := (&DocxReader{TrackChangesMode: tt.mode}).ReadText(testFile)
+		if err != nil {
+			t.Fatalf("读取失败: %v", err)
+		}
+		if text != tt.expected+"\n" {
+			t.Errorf("模式 %d: ReadText 期望 %q，实际 %q", tt.mode, tt.expected+"\n", text)
+		}
+	}
+}
+
+func TestDocumentResultToDocument(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "slides.pptx")
+	writeZipFile(t, testFile, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("第一页"),
+	})
+
+	result, err := ReadDocumentWithConfig(testFile, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	doc := result.ToDocument()
+	if doc.FilePath != testFile || doc.Content != result.Content {
+		t.Errorf("转换结果不正确: %+v", doc)
+	}
+	doc.Metadata["extra"] = "x"
+	if _, ok := result.Metadata["extra"]; ok {
+		t.Errorf("修改 Document 的元数据不应影响原结果")
+	}
+
+	// 跳过 Content 时按页面生成
+	skipped, err := ReadDocumentWithConfig(testFile, NewReadConfig().WithSkipContentString(true))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if skipped.ToDocument().Content != result.Content {
+		t.Errorf("期望按页面生成内容 %q，实际 %q", result.Content, skipped.ToDocument().Content)
+	}
+}
+
+func TestSetDefaultReadConfig(t *testing.T) {
+	t.Cleanup(func() { SetDefaultReadConfig(nil) })
+
+	testFile := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(testFile, []byte("a,b\n1,2\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	config := NewReadConfig().WithRawCells(true).WithCellSeparator(",")
+	SetDefaultReadConfig(config)
+
+	// 设置后修改原配置不影响已保存的默认配置
+	config.CellSeparator = ";"
+	if DefaultReadConfig().CellSeparator != "," {
+		t.Errorf("默认配置应保存副本")
+	}
+
+	result, err := ReadDocumentWithConfig(testFile, nil)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if result.Content != "a,b\n1,2" {
+		t.Errorf("nil 配置应使用默认配置，实际 %q", result.Content)
+	}
+
+	doc, err := ReadDocument(testFile)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if doc.Content != "a,b\n1,2" {
+		t.Errorf("ReadDocument 应使用默认配置，实际 %q", doc.Content)
+	}
+
+	// 显式传入的配置优先于默认配置
+	result, err = ReadDocumentWithConfig(testFile, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if result.Content != "Row 1: a | b\nRow 2: 1 | 2" {
+		t.Errorf("显式配置应优先，实际 %q", result.Content)
+	}
+
+	// 清除后恢复原有行为
+	SetDefaultReadConfig(nil)
+	if DefaultReadConfig() != nil {
+		t.Errorf("清除后默认配置应为 nil")
+	}
+	doc, err = ReadDocument(testFile)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if !strings.Contains(doc.Content, "Row") {
+		t.Errorf("清除默认配置后应使用 ReadText，实际 %q", doc.Content)
+	}
+}
+
+func TestXlsxGetSheetInfo(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "info.xlsx")
+
+	f := excelize.NewFile()
+	_ = f.SetCellValue("Sheet1", "A1", "x")
+	_ = f.SetCellValue("Sheet1", "C4", "y")
+	if _, err := f.NewSheet("Hidden"); err != nil {
+		t.Fatalf("创建工作表失败: %v", err)
+	}
+	_ = f.SetCellValue("Hidden", "B2", "z")
+	if err := f.SetSheetVisible("Hidden", false); err != nil {
+		t.Fatalf("隐藏工作表失败: %v", err)
+	}
+	// excelize 保存时不会更新 dimension，这里按 Excel 的行为显式设置
+	_ = f.SetSheetDimension("Sheet1", "A1:C4")
+	_ = f.SetSheetDimension("Hidden", "B2")
+	if err := f.SaveAs(testFile); err != nil {
+		t.Fatalf("保存 XLSX 失败: %v", err)
+	}
+	f.Close()
+
+	infos, err := (&XlsxReader{}).GetSheetInfo(testFile)
+	if err != nil {
+		t.Fatalf("获取工作表信息失败: %v", err)
+	}
+
+	expected := []SheetInfo{
+		{Name: "Sheet1", Dimension: "A1:C4", Rows: 4, Cols: 3},
+		{Name: "Hidden", Dimension: "B2", Rows: 1, Cols: 1, Hidden: true},
+	}
+	if !reflect.DeepEqual(infos, expected) {
+		t.Errorf("期望 %+v，实际 %+v", expected, infos)
+	}
+
+	metadata, err := (&XlsxReader{}).GetMetadata(testFile)
+	if err != nil {
+		t.Fatalf("获取元数据失败: %v", err)
+	}
+	if metadata["sheet_Sheet1_dimension"] != "A1:C4" {
+		t.Errorf("期望 sheet_Sheet1_dimension 为 A1:C4，实际 %q", metadata["sheet_Sheet1_dimension"])
+	}
+}
+
+func TestPdfGetFonts(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "fonts.pdf")
+	writePdfFile(t, testFile, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /MediaBox [0 0 612 792] /Resources << /Font << /F1 5 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 5 0 R /F2 6 0 R /F3 7 0 R >> /XObject << /X1 8 0 R >> >> >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Type /Font /Subtype /TrueType /BaseFont /ABCDEF+SimSun >>",
+		"<< /Type /Font /Subtype /Type3 >>",
+		"<< /Type /XObject /Subtype /Form /Resources << /Font << /F9 9 0 R >> >> /Length 0 >>\nstream\n\nendstream",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>",
+	)
+
+	fonts, err := (&PdfReader{}).GetFonts(testFile)
+	if err != nil {
+		t.Fatalf("获取字体失败: %v", err)
+	}
+	expected := []string{"Helvetica", "ABCDEF+SimSun", "Courier"}
+	if !reflect.DeepEqual(fonts, expected) {
+		t.Errorf("期望 %q，实际 %q", expected, fonts)
+	}
+
+	if _, err := (&PdfReader{}).GetFonts(testFile + ".missing"); !errors.Is(err, ErrFileOpen) {
+		t.Errorf("期望 ErrFileOpen，实际: %v", err)
+	}
+}
+
+func TestPdfHasTextLayer(t *testing.T) {
+	dir := t.TempDir()
+	content := "BT /F1 12 Tf 72 700 Td (Quarterly revenue report for the board) Tj ET"
+
+	textFile := filepath.Join(dir, "digital.pdf")
+	writePdfFile(t, textFile, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	)
+
+	scanFile := filepath.Join(dir, "scan.pdf")
+	writePdfFile(t, scanFile, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>",
+	)
+
+	reader := &PdfReader{}
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{textFile, true},
+		{scanFile, false},
+	}
+	for _, tt := range tests {
+		hasText, err := reader.HasTextLayer(tt.path)
+		if err != nil {
+			t.Fatalf("检测文本层失败: %v", err)
+		}
+		if hasText != tt.expected {
+			t.Errorf("%s: 期望 %v，实际 %v", filepath.Base(tt.path), tt.expected, hasText)
+		}
+
+		metadata, err := reader.GetMetadata(tt.path)
+		if err != nil {
+			t.Fatalf("获取元数据失败: %v", err)
+		}
+		if metadata["has_text_layer"] != strconv.FormatBool(tt.expected) {
+			t.Errorf("%s: has_text_layer 期望 %v，实际 %q", filepath.Base(tt.path), tt.expected, metadata["has_text_layer"])
+		}
+
+		// ReadWithConfig 根据已提取的页面文本得到相同的结果
+		result, err := reader.ReadWithConfig(tt.path, nil)
+		if err != nil {
+			t.Fatalf("读取失败: %v", err)
+		}
+		if result.Metadata["has_text_layer"] != strconv.FormatBool(tt.expected) {
+			t.Errorf("%s: ReadWithConfig 的 has_text_layer 期望 %v，实际 %q", filepath.Base(tt.path), tt.expected, result.Metadata["has_text_layer"])
+		}
+	}
+}
+
+func TestSkipEmptyPages(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "slides.pptx")
+	writeZipFile(t, testFile, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("第一页"),
+		"ppt/slides/slide2.xml": pptxSlideXML("  ", ""),
+		"ppt/slides/slide3.xml": pptxSlideXML("第三页"),
+	})
+
+	result, err := ReadDocumentWithConfig(testFile, NewReadConfig().WithSkipEmptyPages(true))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(result.Pages) != 2 || result.Pages[0].PageNumber != 0 || result.Pages[1].PageNumber != 2 {
+		t.Fatalf("期望跳过第 2 张幻灯片，实际: %+v", result.Pages)
+	}
+	if result.TotalPages != 3 || result.Metadata["skipped_empty_pages"] != "1" {
+		t.Errorf("期望总页数 3 且跳过 1 页，实际 %d，%q", result.TotalPages, result.Metadata["skipped_empty_pages"])
+	}
+	if strings.Contains(result.Content, "幻灯片 1 ") {
+		t.Errorf("Content 不应包含空幻灯片: %q", result.Content)
+	}
+
+	// 默认保留空页
+	result, err = ReadDocumentWithConfig(testFile, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(result.Pages) != 3 {
+		t.Errorf("默认应保留空页，实际 %d 页", len(result.Pages))
+	}
+}
+
+func TestTrimAndDropEmptyLines(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "lines.txt")
+	if err := os.WriteFile(testFile, []byte("  first  \n\n   \n\tsecond\nthird"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	config := NewReadConfig().WithTrimLines(true).WithDropEmptyLines(true).WithLineNumbers(true)
+	result, err := ReadDocumentWithConfig(testFile, config)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	page := result.Pages[0]
+	if !reflect.DeepEqual(page.Lines, []string{"first", "second", "third"}) {
+		t.Errorf("清理结果不正确: %q", page.Lines)
+	}
+	if !reflect.DeepEqual(page.LineNumbers, []int{0, 3, 4}) {
+		t.Errorf("行号未同步: %v", page.LineNumbers)
+	}
+	if page.TotalLines != 3 || result.TotalLines != 3 {
+		t.Errorf("行数不正确: %d, %d", page.TotalLines, result.TotalLines)
+	}
+
+	// 行选择使用原始行索引，先选择后清理
+	result, err = ReadDocumentWithConfig(testFile, NewReadConfig().WithLineRange(1, 3).WithTrimLines(true).WithDropEmptyLines(true))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if !reflect.DeepEqual(result.Pages[0].Lines, []string{"second"}) {
+		t.Errorf("期望只保留 second，实际 %q", result.Pages[0].Lines)
+	}
+
+	// 只去除空白时保留空行
+	result, err = ReadDocumentWithConfig(testFile, NewReadConfig().WithTrimLines(true))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if result.TotalLines != 5 || result.Pages[0].Lines[2] != "" {
+		t.Errorf("只去除空白时应保留空行: %q", result.Pages[0].Lines)
+	}
+}
+
+func TestDocxParts(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "parts.docx")
+	writeZipFile(t, testFile, map[string]string{
+		"word/document.xml":   docxDocumentXML(`<w:p><w:r><w:t>正文</w:t></w:r></w:p>`),
+		"customXml/item1.xml": `<data><id>42</id></data>`,
+	})
+
+	reader := &DocxReader{}
+	parts, err := reader.ListParts(testFile)
+	if err != nil {
+		t.Fatalf("列出部件失败: %v", err)
+	}
+	if !reflect.DeepEqual(parts, []string{"customXml/item1.xml", "word/document.xml"}) {
+		t.Errorf("部件列表不正确: %q", parts)
+	}
+
+	data, err := reader.GetPart(testFile, "customXml/item1.xml")
+	if err != nil {
+		t.Fatalf("读取部件失败: %v", err)
+	}
+	if string(data) != `<data><id>42</id></data>` {
+		t.Errorf("部件内容不正确: %q", data)
+	}
+
+	if _, err := reader.GetPart(testFile, "docProps/app.xml"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("期望 ErrInvalidFormat，实际: %v", err)
+	}
+
+	corruptZipEntry(t, testFile, "customXml/item1.xml")
+	if _, err := reader.GetPart(testFile, "customXml/item1.xml"); !errors.Is(err, ErrFileRead) {
+		t.Errorf("期望 ErrFileRead，实际: %v", err)
+	}
+}
+
+func TestOOXMLAppProperties(t *testing.T) {
+	appXML := `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties">` +
+		`<Application>Microsoft Office Word</Application><AppVersion>16.0000</AppVersion>` +
+		`<Company>示例公司</Company><Pages>3</Pages><Words>1200</Words><Characters>6800</Characters>` +
+		`</Properties>`
+
+	dir := t.TempDir()
+	docxFile := filepath.Join(dir, "app.docx")
+	writeZipFile(t, docxFile, map[string]string{
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>正文</w:t></w:r></w:p>`),
+		"docProps/app.xml":  appXML,
+	})
+
+	metadata, err := (&DocxReader{}).GetMetadata(docxFile)
+	if err != nil {
+		t.Fatalf("获取元数据失败: %v", err)
+	}
+	expected := map[string]string{
+		"application":     "Microsoft Office Word",
+		"app_version":     "16.0000",
+		"company":         "示例公司",
+		"page_count":      "3",
+		"word_count":      "1200",
+		"character_count": "6800",
+	}
+	for key, value := range expected {
+		if metadata[key] != value {
+			t.Errorf("DOCX %s: 期望 %q，实际 %q", key, value, metadata[key])
+		}
+	}
+
+	pptxFile := filepath.Join(dir, "app.pptx")
+	writeZipFile(t, pptxFile, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("标题"),
+		"docProps/app.xml":      appXML,
+	})
+	metadata, err = (&PptxReader{}).GetMetadata(pptxFile)
+	if err != nil {
+		t.Fatalf("获取元数据失败: %v", err)
+	}
+	if metadata["company"] != "示例公司" || metadata["slide_count"] != "1" {
+		t.Errorf("PPTX 元数据不正确: %v", metadata)
+	}
+
+	// app.xml 损坏时返回 ErrFileRead
+	corruptZipEntry(t, docxFile, "docProps/app.xml")
+	if _, err := (&DocxReader{}).GetMetadata(docxFile); !errors.Is(err, ErrFileRead) {
+		t.Errorf("期望 ErrFileRead，实际: %v", err)
+	}
+}
+
+func TestSectionCountMetadata(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.txt":  "text",
+		"a.csv":  "a,b\n1,2\n",
+		"a.md":   "# title",
+		"a.json": `{"a": 1}`,
+		"a.xml":  "<root><a>1</a></root>",
+		"a.rtf":  `{\rtf1 hello}`,
+	}
+	expected := map[string]string{}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("创建测试文件失败: %v", err)
+		}
+		expected[name] = "1"
+	}
+
+	writeZipFile(t, filepath.Join(dir, "a.docx"), map[string]string{
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>一</w:t><w:br w:type="page"/><w:t>二</w:t></w:r></w:p>`),
+	})
+	expected["a.docx"] = "2"
+
+	writeZipFile(t, filepath.Join(dir, "a.pptx"), map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("一"),
+		"ppt/slides/slide2.xml": pptxSlideXML("二"),
+		"ppt/slides/slide3.xml": pptxSlideXML("三"),
+	})
+	expected["a.pptx"] = "3"
+
+	writeXlsxFile(t, filepath.Join(dir, "a.xlsx"), map[string][][]any{
+		"A": {{"1"}},
+		"B": {{"2"}},
+	})
+	expected["a.xlsx"] = "2"
+
+	writePdfFile(t, filepath.Join(dir, "a.pdf"), "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /MediaBox [0 0 612 792] >>",
+		"<< /Type /Page /Parent 2 0 R >>",
+		"<< /Type /Page /Parent 2 0 R >>",
+	)
+	expected["a.pdf"] = "2"
+
+	for name, count := range expected {
+		path := filepath.Join(dir, name)
+		reader, err := NewReader(filepath.Ext(name))
+		if err != nil {
+			t.Fatalf("创建读取器失败: %v", err)
+		}
+		metadata, err := reader.GetMetadata(path)
+		if err != nil {
+			t.Fatalf("%s: 获取元数据失败: %v", name, err)
+		}
+		if metadata["section_count"] != count {
+			t.Errorf("%s: section_count 期望 %s，实际 %q", name, count, metadata["section_count"])
+		}
+
+		result, err := ReadDocumentWithConfig(path, nil)
+		if err != nil {
+			t.Fatalf("%s: 读取失败: %v", name, err)
+		}
+		if result.Metadata["section_count"] != strconv.Itoa(result.TotalPages) {
+			t.Errorf("%s: section_count 应与 TotalPages 一致: %q vs %d", name, result.Metadata["section_count"], result.TotalPages)
+		}
+	}
+}
+
+func TestReadMetadata(t *testing.T) {
+	dir := t.TempDir()
+
+	docxPath := filepath.Join(dir, "a.docx")
+	writeZipFile(t, docxPath, map[string]string{
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>正文</w:t></w:r></w:p>`),
+		"docProps/core.xml": `<cp:coreProperties xmlns:cp="cp" xmlns:dc="dc"><dc:title>报告</dc:title></cp:coreProperties>`,
+	})
+	metadata, err := ReadMetadata(docxPath)
+	if err != nil {
+		t.Fatalf("读取 DOCX 元数据失败: %v", err)
+	}
+	if metadata["title"] != "报告" {
+		t.Errorf("title 期望 报告，实际 %q", metadata["title"])
+	}
+	if _, ok := metadata["section_count"]; ok {
+		t.Error("ReadMetadata 不解析 DOCX 正文，不应返回 section_count")
+	}
+
+	xlsxPath := filepath.Join(dir, "a.xlsx")
+	writeXlsxFile(t, xlsxPath, map[string][][]any{
+		"A": {{"1"}},
+		"B": {{"2"}},
+	})
+	metadata, err = ReadMetadata(xlsxPath)
+	if err != nil {
+		t.Fatalf("读取 XLSX 元数据失败: %v", err)
+	}
+	if metadata["sheet_count"] != "2" || metadata["section_count"] != "2" {
+		t.Errorf("sheet_count 期望 2，实际 %q", metadata["sheet_count"])
+	}
+
+	pdfPath := filepath.Join(dir, "a.pdf")
+	writePdfFile(t, pdfPath, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 /MediaBox [0 0 612 792] >>",
+		"<< /Type /Page /Parent 2 0 R >>",
+	)
+	metadata, err = ReadMetadata(pdfPath)
+	if err != nil {
+		t.Fatalf("读取 PDF 元数据失败: %v", err)
+	}
+	if metadata["pages"] != "1" {
+		t.Errorf("pages 期望 1，实际 %q", metadata["pages"])
+	}
+	if _, ok := metadata["has_text_layer"]; ok {
+		t.Error("ReadMetadata 不应提取 PDF 页面文本")
+	}
+
+	txtPath := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(txtPath, []byte("hello"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	metadata, err = ReadMetadata(txtPath)
+	if err != nil {
+		t.Fatalf("读取 TXT 元数据失败: %v", err)
+	}
+	if metadata["size"] != "5" {
+		t.Errorf("size 期望 5，实际 %q", metadata["size"])
+	}
+
+	if _, err := ReadMetadata(filepath.Join(dir, "missing.docx")); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound，实际 %v", err)
+	}
+	unknownPath := filepath.Join(dir, "a.xyz")
+	if err := os.WriteFile(unknownPath, []byte("x"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	if _, err := ReadMetadata(unknownPath); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("期望 ErrUnsupportedFormat，实际 %v", err)
+	}
+
+	// 批量读取：结果顺序与输入一致，单个失败不影响其他文件
+	paths := []string{docxPath, unknownPath, xlsxPath, pdfPath, txtPath}
+	for _, concurrency := range []int{0, 1, 3, 10} {
+		results := ReadMetadataBatch(paths, concurrency)
+		if len(results) != len(paths) {
+			t.Fatalf("并发 %d: 期望 %d 个结果，实际 %d", concurrency, len(paths), len(results))
+		}
+		for i, result := range results {
+			if result.FilePath != paths[i] {
+				t.Errorf("并发 %d: 第 %d 个结果路径期望 %s，实际 %s", concurrency, i, paths[i], result.FilePath)
+			}
+			if (result.Err != nil) != (paths[i] == unknownPath) {
+				t.Errorf("并发 %d: %s 的错误不符合预期: %v", concurrency, paths[i], result.Err)
+			}
+		}
+	}
+}
+
+func TestReadDocumentFS(t *testing.T) {
+	var docx bytes.Buffer
+	zipWriter := zip.NewWriter(&docx)
+	part, err := zipWriter.Create("word/document.xml")
+	if err != nil {
+		t.Fatalf("创建 zip 条目失败: %v", err)
+	}
+	part.Write([]byte(docxDocumentXML(`<w:p><w:r><w:t>嵌入文档</w:t></w:r></w:p>`)))
+	if err := zipWriter.Close(); err != nil {
+		t.Fatalf("写入 zip 失败: %v", err)
+	}
+
+	fsys := fstest.MapFS{
+		"docs/a.txt":  {Data: []byte("hello fs")},
+		"docs/b.docx": {Data: docx.Bytes()},
+		"docs/c.xyz":  {Data: []byte("x")},
+		"docs/d.csv":  {Data: []byte("a,\"b\n")},
+	}
+
+	doc, err := ReadDocumentFS(fsys, "docs/a.txt")
+	if err != nil {
+		t.Fatalf("读取 TXT 失败: %v", err)
+	}
+	if doc.Content != "hello fs" || doc.FilePath != "docs/a.txt" {
+		t.Errorf("TXT 结果不符合预期: %q %q", doc.FilePath, doc.Content)
+	}
+
+	doc, err = ReadDocumentFS(fsys, "docs/b.docx")
+	if err != nil {
+		t.Fatalf("读取 DOCX 失败: %v", err)
+	}
+	if !strings.Contains(doc.Content, "嵌入文档") {
+		t.Errorf("DOCX 内容不符合预期: %q", doc.Content)
+	}
+
+	if _, err := ReadDocumentFS(fsys, "docs/missing.txt"); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound，实际 %v", err)
+	}
+	if _, err := ReadDocumentFS(fsys, "docs/c.xyz"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("期望 ErrUnsupportedFormat，实际 %v", err)
+	}
+
+	// 读取失败时错误中的路径是 fs.FS 中的名称
+	_, err = ReadDocumentFS(fsys, "docs/d.csv")
+	var docErr *DocumentError
+	if !errors.As(err, &docErr) || docErr.FilePath != "docs/d.csv" {
+		t.Errorf("错误路径期望 docs/d.csv，实际 %v", err)
+	}
+}
+
+// TestPptxCorruptSlide 测试损坏的幻灯片被跳过并记录在 Warnings 中，其余幻灯片照常读取
+func TestPptxCorruptSlide(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partial.pptx")
+	writeZipFile(t, path, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("第一页"),
+		"ppt/slides/slide2.xml": "<p:sld",
+		"ppt/slides/slide3.xml": pptxSlideXML("第三页"),
+	})
+
+	result, err := ReadDocumentWithConfig(path, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	pages := make([]int, 0, len(result.Pages))
+	for _, page := range result.Pages {
+		pages = append(pages, page.PageNumber)
+	}
+	if !reflect.DeepEqual(pages, []int{0, 2}) || result.TotalPages != 3 {
+		t.Errorf("期望读取第 0 和第 2 张幻灯片, 得到 %v（共 %d 张）", pages, result.TotalPages)
+	}
+	if len(result.Warnings) != 1 || !strings.HasPrefix(result.Warnings[0], "slide 2: ") {
+		t.Errorf("期望记录第 2 张幻灯片的警告, 得到 %q", result.Warnings)
+	}
+
+	if _, err := ReadDocumentWithConfig(path, NewReadConfig().WithFailOnPartialError(true)); !errors.Is(err, ErrFileParse) {
+		t.Errorf("严格模式期望 ErrFileParse, 得到 %v", err)
+	}
+
+	text, err := (&PptxReader{}).ReadText(path)
+	if err != nil {
+		t.Fatalf("ReadText 失败: %v", err)
+	}
+	if !strings.Contains(text, "=== 幻灯片 3 ===") || strings.Contains(text, "=== 幻灯片 2 ===") {
+		t.Errorf("ReadText 应跳过损坏的幻灯片并保留编号, 得到 %q", text)
+	}
+
+	slides, err := (&PptxReader{}).GetSlides(path)
+	if err != nil || len(slides) != 3 || slides[1] != "" {
+		t.Errorf("GetSlides 期望损坏的幻灯片为空字符串, 得到 %q, %v", slides, err)
+	}
+}
+
+// TestReadDocumentFSWithoutTempFiles 测试从 fs.FS 读取 PDF、XLSX 和 iWork 文档时不写入临时文件
+func TestReadDocumentFSWithoutTempFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	pdfPath := filepath.Join(dir, "a.pdf")
+	content := "BT /F1 12 Tf 72 700 Td (fs pdf) Tj ET"
+	writePdfFile(t, pdfPath, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	)
+	pdfData, err := os.ReadFile(pdfPath)
+	if err != nil {
+		t.Fatalf("读取 PDF 失败: %v", err)
+	}
+
+	xlsxPath := filepath.Join(dir, "b.xlsx")
+	writeXlsxFile(t, xlsxPath, map[string][][]any{"Data": {{"fs", "xlsx"}}})
+	xlsxData, err := os.ReadFile(xlsxPath)
+	if err != nil {
+		t.Fatalf("读取 XLSX 失败: %v", err)
+	}
+
+	pagesPath := filepath.Join(dir, "c.pages")
+	writeZipFile(t, pagesPath, map[string]string{"preview.pdf": string(pdfData)})
+	pagesData, err := os.ReadFile(pagesPath)
+	if err != nil {
+		t.Fatalf("读取 Pages 文件失败: %v", err)
+	}
+
+	fsys := fstest.MapFS{
+		"a.pdf":   {Data: pdfData},
+		"b.xlsx":  {Data: xlsxData},
+		"c.pages": {Data: pagesData},
+	}
+
+	// 临时目录不存在时创建临时文件会失败
+	t.Setenv("TMPDIR", filepath.Join(dir, "missing"))
+
+	expected := map[string]string{
+		"a.pdf":   "fs pdf",
+		"b.xlsx":  "fs",
+		"c.pages": "fs pdf",
+	}
+	for name, text := range expected {
+		doc, err := ReadDocumentFS(fsys, name)
+		if err != nil {
+			t.Fatalf("读取 %s 失败: %v", name, err)
+		}
+		if !strings.Contains(doc.Content, text) || doc.FilePath != name {
+			t.Errorf("%s 结果不符合预期: %q %q", name, doc.FilePath, doc.Content)
+		}
+	}
+}
+
+func TestConvertToText(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.final.md")
+	if err := os.WriteFile(path, []byte("# 标题"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	text, name, err := ConvertToText(path)
+	if err != nil {
+		t.Fatalf("转换失败: %v", err)
+	}
+	if name != "report.final.txt" {
+		t.Errorf("建议文件名期望 report.final.txt，实际 %q", name)
+	}
+	if !strings.Contains(text, "标题") {
+		t.Errorf("文本不符合预期: %q", text)
+	}
+
+	if _, name, err := ConvertToText(filepath.Join(dir, "missing.docx")); !errors.Is(err, ErrFileNotFound) || name != "" {
+		t.Errorf("期望 ErrFileNotFound 且文件名为空，实际 %q %v", name, err)
+	}
+}
+
+func TestPptxGetSections(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sections.pptx")
+	writeZipFile(t, path, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("封面"),
+		"ppt/slides/slide2.xml": pptxSlideXML("背景"),
+		"ppt/slides/slide3.xml": pptxSlideXML("结论"),
+		"ppt/presentation.xml": `<p:presentation xmlns:p="p" xmlns:r="r" xmlns:p14="p14">` +
+			`<p:sldIdLst><p:sldId id="300" r:id="rId7"/><p:sldId id="256" r:id="rId2"/><p:sldId id="257" r:id="rId3"/></p:sldIdLst>` +
+			`<p:extLst><p:ext uri="{521415D9-36F7-43E2-AB2F-B90AF26B5E84}"><p14:sectionLst>` +
+			`<p14:section name="开场" id="{A}"><p14:sldIdLst><p14:sldId id="256"/></p14:sldIdLst></p14:section>` +
+			`<p14:section name="正文" id="{B}"><p14:sldIdLst><p14:sldId id="257"/><p14:sldId id="300"/></p14:sldIdLst></p14:section>` +
+			`<p14:section name="空节" id="{C}"><p14:sldIdLst/></p14:section>` +
+			`</p14:sectionLst></p:ext></p:extLst></p:presentation>`,
+		"ppt/_rels/presentation.xml.rels": `<Relationships xmlns="rels">` +
+			`<Relationship Id="rId2" Target="slides/slide1.xml"/>` +
+			`<Relationship Id="rId3" Target="slides/slide2.xml"/>` +
+			`<Relationship Id="rId7" Target="/ppt/slides/slide3.xml"/>` +
+			`</Relationships>`,
+	})
+
+	sections, err := (&PptxReader{}).GetSections(path)
+	if err != nil {
+		t.Fatalf("获取节失败: %v", err)
+	}
+	expected := []Section{
+		{Name: "开场", SlideIndices: []int{0}},
+		{Name: "正文", SlideIndices: []int{1, 2}},
+		{Name: "空节", SlideIndices: []int{}},
+	}
+	if !reflect.DeepEqual(sections, expected) {
+		t.Errorf("期望 %v，实际 %v", expected, sections)
+	}
+
+	// 没有定义节时返回空切片
+	plain := filepath.Join(dir, "plain.pptx")
+	writeZipFile(t, plain, map[string]string{
+		"ppt/slides/slide1.xml":           pptxSlideXML("一"),
+		"ppt/presentation.xml":            `<p:presentation xmlns:p="p" xmlns:r="r"><p:sldIdLst><p:sldId id="256" r:id="rId2"/></p:sldIdLst></p:presentation>`,
+		"ppt/_rels/presentation.xml.rels": `<Relationships xmlns="rels"><Relationship Id="rId2" Target="slides/slide1.xml"/></Relationships>`,
+	})
+	sections, err = (&PptxReader{}).GetSections(plain)
+	if err != nil {
+		t.Fatalf("获取节失败: %v", err)
+	}
+	if len(sections) != 0 {
+		t.Errorf("期望没有节，实际 %v", sections)
+	}
+
+	// 缺少 presentation.xml
+	missing := filepath.Join(dir, "missing.pptx")
+	writeZipFile(t, missing, map[string]string{"ppt/slides/slide1.xml": pptxSlideXML("一")})
+	if _, err := (&PptxReader{}).GetSections(missing); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("期望 ErrInvalidFormat，实际 %v", err)
+	}
+}
+
+func TestPptxAltText(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "alt.pptx")
+	writeZipFile(t, path, map[string]string{
+		"ppt/slides/slide1.xml": `<p:sld xmlns:p="p" xmlns:a="a"><p:cSld><p:spTree>` +
+			`<p:sp><p:nvSpPr><p:cNvPr id="2" name="Title" descr="标题框"/></p:nvSpPr><p:txBody><a:p><a:r><a:t>销售报告</a:t></a:r></a:p></p:txBody></p:sp>` +
+			`<p:pic><p:nvPicPr><p:cNvPr id="3" name="Chart" title="图表" descr="季度销售额柱状图"/></p:nvPicPr></p:pic>` +
+			`<p:pic><p:nvPicPr><p:cNvPr id="4" name="Logo"/></p:nvPicPr></p:pic>` +
+			`</p:spTree></p:cSld></p:sld>`,
+		"ppt/slides/slide2.xml": pptxSlideXML("谢谢"),
+	})
+
+	altTexts, err := (&PptxReader{}).GetAltTexts(path)
+	if err != nil {
+		t.Fatalf("获取替代文字失败: %v", err)
+	}
+	expected := [][]string{{"标题框", "图表: 季度销售额柱状图"}, {}}
+	if !reflect.DeepEqual(altTexts, expected) {
+		t.Errorf("期望 %v，实际 %v", expected, altTexts)
+	}
+
+	// 默认不输出替代文字
+	result, err := ReadDocumentWithConfig(path, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if !reflect.DeepEqual(result.Pages[0].Lines, []string{"销售报告"}) {
+		t.Errorf("默认行期望只有文本，实际 %v", result.Pages[0].Lines)
+	}
+
+	result, err = ReadDocumentWithConfig(path, NewReadConfig().WithIncludeAltText(true))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if !reflect.DeepEqual(result.Pages[0].Lines, []string{"销售报告", "标题框", "图表: 季度销售额柱状图"}) {
+		t.Errorf("包含替代文字的行不符合预期: %v", result.Pages[0].Lines)
+	}
+	if !reflect.DeepEqual(result.Pages[1].Lines, []string{"谢谢"}) {
+		t.Errorf("第二张幻灯片的行不符合预期: %v", result.Pages[1].Lines)
+	}
+}
+
+// plainReader 只实现 DocumentReader，用于测试没有报告功能的读取器
+type plainReader struct{}
+
+func (plainReader) ReadText(string) (string, error)               { return "", nil }
+func (plainReader) GetMetadata(string) (map[string]string, error) { return nil, nil }
+
+func TestReaderCapabilities(t *testing.T) {
+	for _, ext := range GetSupportedFormats() {
+		reader, err := NewReader(ext)
+		if err != nil {
+			t.Fatalf("%s: 创建读取器失败: %v", ext, err)
+		}
+		if _, ok := reader.(CapabilityReporter); !ok {
+			t.Errorf("%s: 读取器没有实现 CapabilityReporter", ext)
+		}
+	}
+
+	expected := map[string]ReaderCapabilities{
+		".docx": {Pagination: true, Tables: true, Images: true, FormFields: true, MetadataDates: true},
+		".pdf":  {Pagination: true, Images: true, MetadataDates: true},
+		".xlsx": {Pagination: true, Tables: true, Columns: true, Sheets: true, MetadataDates: true},
+		".pptx": {Pagination: true, Slides: true, AltText: true, Images: true, MetadataDates: true},
+		".csv":  {Tables: true, Columns: true, Records: true},
+		".txt":  {},
+	}
+	for ext, want := range expected {
+		reader, _ := NewReader(ext)
+		if got := GetCapabilities(reader); got != want {
+			t.Errorf("%s: 期望 %+v，实际 %+v", ext, want, got)
+		}
+	}
+
+	if got := GetCapabilities(plainReader{}); got != (ReaderCapabilities{}) {
+		t.Errorf("未实现 CapabilityReporter 的读取器期望零值，实际 %+v", got)
+	}
+}
+
+func TestXlsxGetAllSheetsDataOrdered(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ordered.xlsx")
+	f := excelize.NewFile()
+	if err := f.SetSheetName("Sheet1", "Zeta"); err != nil {
+		t.Fatalf("重命名工作表失败: %v", err)
+	}
+	f.SetCellValue("Zeta", "A1", "z")
+	for _, name := range []string{"Alpha", "Mid"} {
+		if _, err := f.NewSheet(name); err != nil {
+			t.Fatalf("创建工作表失败: %v", err)
+		}
+		f.SetCellValue(name, "A1", strings.ToLower(name[:1]))
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("保存 XLSX 失败: %v", err)
+	}
+	f.Close()
+
+	reader := &XlsxReader{}
+	sheets, err := reader.GetAllSheetsDataOrdered(path)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	expected := []SheetData{
+		{Name: "Zeta", Rows: [][]string{{"z"}}},
+		{Name: "Alpha", Rows: [][]string{{"a"}}},
+		{Name: "Mid", Rows: [][]string{{"m"}}},
+	}
+	if !reflect.DeepEqual(sheets, expected) {
+		t.Errorf("期望 %v，实际 %v", expected, sheets)
+	}
+
+	// map 版本保持兼容
+	all, err := reader.GetAllSheetsData(path)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(all) != 3 || !reflect.DeepEqual(all["Alpha"], [][]string{{"a"}}) {
+		t.Errorf("GetAllSheetsData 结果不符合预期: %v", all)
+	}
+}
+
+func TestCsvGetTypedRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "typed.csv")
+	content := "1,1.5,true,2024-03-01,a,extra\n" +
+		"x, 2 ,no,01/02/2024,b,\n" +
+		",,,,,\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	reader := &CsvReader{}
+	schema := []ColumnType{{Kind: ColumnInt}, {Kind: ColumnFloat}, {Kind: ColumnBool}, {Kind: ColumnDate}, {Kind: ColumnString}}
+	records, err := reader.GetTypedRecords(path, schema)
+
+	var cellErrors CellErrors
+	if !errors.As(err, &cellErrors) {
+		t.Fatalf("期望 CellErrors，实际 %v", err)
+	}
+	type position struct{ row, col int }
+	var positions []position
+	for _, cellErr := range cellErrors {
+		positions = append(positions, position{cellErr.Row, cellErr.Column})
+	}
+	if !reflect.DeepEqual(positions, []position{{1, 0}, {1, 2}, {1, 3}}) {
+		t.Errorf("错误位置不符合预期: %v", positions)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Error("期望可以通过 errors.Is 匹配到 strconv.ErrSyntax")
+	}
+
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	expected := [][]any{
+		{int64(1), 1.5, true, date, "a", "extra"},
+		{nil, 2.0, nil, nil, "b", ""},
+		{nil, nil, nil, nil, "", ""},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("期望 %v，实际 %v", expected, records)
+	}
+
+	// 自定义日期格式
+	records, err = reader.GetTypedRecords(path, []ColumnType{{}, {}, {}, {Kind: ColumnDate, DateLayout: "01/02/2006"}})
+	var dateErrors CellErrors
+	if !errors.As(err, &dateErrors) || len(dateErrors) != 1 || dateErrors[0].Row != 0 {
+		t.Errorf("自定义日期格式时期望只有第 0 行出错，实际 %v", err)
+	}
+	if records[1][3] != time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("自定义日期格式解析结果不符合预期: %v", records[1][3])
+	}
+
+	if _, err := reader.GetTypedRecords(filepath.Join(t.TempDir(), "missing.csv"), schema); !errors.Is(err, ErrFileOpen) {
+		t.Errorf("期望 ErrFileOpen，实际 %v", err)
+	}
+}
+
+func TestUTF8BOM(t *testing.T) {
+	csvPath := filepath.Join("testdata", "bom.csv")
+	txtPath := filepath.Join("testdata", "bom.txt")
+
+	records, err := (&CsvReader{}).GetRecords(csvPath)
+	if err != nil {
+		t.Fatalf("读取 CSV 失败: %v", err)
+	}
+	if records[0][0] != "name" {
+		t.Errorf("CSV 表头期望 name，实际 %q", records[0][0])
+	}
+	result, err := ReadDocumentWithConfig(csvPath, NewReadConfig().WithRawCells(true))
+	if err != nil {
+		t.Fatalf("读取 CSV 失败: %v", err)
+	}
+	if result.Pages[0].Lines[0] != "name | age" {
+		t.Errorf("CSV 第一行不符合预期: %q", result.Pages[0].Lines[0])
+	}
+
+	// TXT 和 Markdown 的所有读取方式都去掉 BOM
+	mdPath := filepath.Join(t.TempDir(), "bom.md")
+	data, err := os.ReadFile(txtPath)
+	if err != nil {
+		t.Fatalf("读取测试文件失败: %v", err)
+	}
+	if err := os.WriteFile(mdPath, data, 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	for _, path := range []string{txtPath, mdPath} {
+		doc, err := ReadDocument(path)
+		if err != nil {
+			t.Fatalf("%s: 读取失败: %v", path, err)
+		}
+		if !strings.HasPrefix(doc.Content, "first line") {
+			t.Errorf("%s: ReadDocument 内容不应以 BOM 开头: %q", path, doc.Content)
+		}
+
+		result, err := ReadDocumentWithConfig(path, nil)
+		if err != nil {
+			t.Fatalf("%s: 读取失败: %v", path, err)
+		}
+		if result.Pages[0].Lines[0] != "first line" {
+			t.Errorf("%s: 第一行期望 first line，实际 %q", path, result.Pages[0].Lines[0])
+		}
+
+		raw, err := ReadDocumentRaw(path)
+		if err != nil {
+			t.Fatalf("%s: 读取失败: %v", path, err)
+		}
+		if !strings.HasPrefix(raw, "first line") {
+			t.Errorf("%s: ReadDocumentRaw 内容不应以 BOM 开头: %q", path, raw)
+		}
+	}
+
+	var first string
+	err = (&TxtReader{}).StreamLines(txtPath, func(line string) error {
+		first = line
+		return errStopStreaming
+	})
+	if err != errStopStreaming || first != "first line" {
+		t.Errorf("StreamLines 第一行期望 first line，实际 %q（%v）", first, err)
+	}
+}
+
+// writeBrokenSheetXlsx 创建包含正常工作表 Sheet1 和损坏工作表 Broken 的 XLSX
+// Broken 的第一行行号无法解析，读取时会在该行停止
+func writeBrokenSheetXlsx(t *testing.T, path string) {
+	t.Helper()
+
+	f := excelize.NewFile()
+	f.SetCellValue("Sheet1", "A1", "ok")
+	if _, err := f.NewSheet("Broken"); err != nil {
+		t.Fatalf("创建工作表失败: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("保存 XLSX 失败: %v", err)
+	}
+	f.Close()
+	rewriteZipEntry(t, path, "xl/worksheets/sheet2.xml",
+		`<worksheet><sheetData><row r="x"><c r="A1"><v>1</v></c></row></sheetData></worksheet>`)
+}
+
+func TestSetLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.xlsx")
+	writeBrokenSheetXlsx(t, path)
+
+	type entry struct {
+		level, msg string
+		kv         []any
+	}
+	var entries []entry
+	var mu sync.Mutex
+	SetLogger(func(level, msg string, kv ...any) {
+		mu.Lock()
+		defer mu.Unlock()
+		entries = append(entries, entry{level, msg, kv})
+	})
+	defer SetLogger(nil)
+
+	if _, err := ReadDocumentWithConfig(path, nil); err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("期望 1 条日志，实际 %v", entries)
+	}
+	if entries[0].level != LogLevelWarn || entries[0].msg != "stopped reading sheet" {
+		t.Errorf("日志不符合预期: %+v", entries[0])
+	}
+	if len(entries[0].kv)%2 != 0 || !slices.Contains(entries[0].kv, any("Broken")) {
+		t.Errorf("日志键值不符合预期: %v", entries[0].kv)
+	}
+
+	// 恢复为空操作后不再记录
+	SetLogger(nil)
+	if _, err := ReadDocumentWithConfig(path, nil); err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("SetLogger(nil) 后不应再记录日志，实际 %d 条", len(entries))
+	}
+}
+
+// rewriteZipEntry 将 zip 包中名为 name 的条目内容替换为 content
+func rewriteZipEntry(t *testing.T, path, name, content string) {
+	t.Helper()
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("打开 zip 失败: %v", err)
+	}
+	entries := make(map[string]string)
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("读取条目失败: %v", err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("读取条目失败: %v", err)
+		}
+		entries[file.Name] = string(data)
+	}
+	zr.Close()
+
+	if _, ok := entries[name]; !ok {
+		t.Fatalf("zip 中不存在条目 %s", name)
+	}
+	entries[name] = content
+	writeZipFile(t, path, entries)
+}
+
+func TestDocumentResultWarnings(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "broken.xlsx")
+	writeBrokenSheetXlsx(t, path)
+
+	result, err := ReadDocumentWithConfig(path, nil)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(result.Warnings) != 1 || !strings.HasPrefix(result.Warnings[0], `sheet "Broken": stopped reading at row 1`) {
+		t.Errorf("警告不符合预期: %q", result.Warnings)
+	}
+	if len(result.Pages) != 2 || result.Pages[0].Lines[0] != "Row 0: ok" {
+		t.Errorf("应保留可以读取的内容，实际 %+v", result.Pages)
+	}
+
+	// 完整读取时没有警告
+	okPath := filepath.Join(dir, "ok.txt")
+	if err := os.WriteFile(okPath, []byte("fine"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	result, err = ReadDocumentWithConfig(okPath, nil)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if result.Warnings != nil {
+		t.Errorf("期望没有警告，实际 %q", result.Warnings)
+	}
+}
+
+func TestFailOnPartialError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.xlsx")
+	writeBrokenSheetXlsx(t, path)
+
+	// 默认宽松：跳过失败的部分并记录警告
+	result, err := ReadDocumentWithConfig(path, NewReadConfig())
+	if err != nil {
+		t.Fatalf("宽松模式不应返回错误: %v", err)
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("期望 1 条警告，实际 %q", result.Warnings)
+	}
+
+	// 严格模式：返回包含工作表名称的 ErrFileParse
+	_, err = ReadDocumentWithConfig(path, NewReadConfig().WithFailOnPartialError(true))
+	if !errors.Is(err, ErrFileParse) {
+		t.Fatalf("期望 ErrFileParse，实际 %v", err)
+	}
+	if !strings.Contains(err.Error(), `sheet "Broken"`) {
+		t.Errorf("错误信息应包含工作表名称: %v", err)
+	}
+
+	// 只读取正常的工作表时不受影响
+	result, err = ReadDocumentWithConfig(path, NewReadConfig().WithFailOnPartialError(true).WithSheetNames("Sheet1"))
+	if err != nil {
+		t.Fatalf("读取正常工作表失败: %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("期望没有警告，实际 %q", result.Warnings)
+	}
+}
+
+// TestConcurrentReads 在多个 goroutine 中并发读取同一个文件，保证读取器没有共享的可变状态
+// 使用 go test -race 运行时可以发现以后加入缓存等状态时引入的数据竞争
+func TestConcurrentReads(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{
+		filepath.Join(dir, "a.docx"),
+		filepath.Join(dir, "a.pptx"),
+		filepath.Join(dir, "a.xlsx"),
+		filepath.Join(dir, "a.csv"),
+		filepath.Join(dir, "a.json"),
+		filepath.Join("testdata", "bom.txt"),
+	}
+	writeZipFile(t, paths[0], map[string]string{
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>并发</w:t></w:r></w:p>`),
+	})
+	writeZipFile(t, paths[1], map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("一"),
+		"ppt/slides/slide2.xml": pptxSlideXML("二"),
+	})
+	writeXlsxFile(t, paths[2], map[string][][]any{"Sheet1": {{"a", 1}, {"b", 2}}})
+	if err := os.WriteFile(paths[3], []byte("a,b\n1,2\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	if err := os.WriteFile(paths[4], []byte(`{"k": [1, 2]}`), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	const goroutines = 16
+	for _, path := range paths {
+		expected, err := ReadDocument(path)
+		if err != nil {
+			t.Fatalf("%s: 读取失败: %v", path, err)
+		}
+		// 所有 goroutine 共享同一个读取器实例
+		shared, _ := NewConfigurableReader(filepath.Ext(path))
+		config := NewReadConfig().WithTrimLines(true)
+		expectedResult, err := shared.ReadWithConfig(path, config)
+		if err != nil {
+			t.Fatalf("%s: 读取失败: %v", path, err)
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, goroutines)
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				doc, err := ReadDocument(path)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if doc.Content != expected.Content {
+					errs <- fmt.Errorf("%s: 并发读取的内容不一致", path)
+					return
+				}
+				result, err := shared.ReadWithConfig(path, config)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if result.Content != expectedResult.Content {
+					errs <- fmt.Errorf("%s: 共享读取器的结果不一致", path)
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Error(err)
+		}
+	}
+}
+
+// TestMdGetTables 测试提取 Markdown 管道表格
+func TestMdGetTables(t *testing.T) {
+	content := strings.Join([]string{
+		"# 报告",
+		"",
+		"| 名称 | 说明 |",
+		"| :--- | ---: |",
+		"|  a  | x \\| y |",
+		"| b |",
+		"| c | 1 | 多余 |",
+		"",
+		"```markdown",
+		"| 代码 | 块 |",
+		"| --- | --- |",
+		"| 1 | 2 |",
+		"```",
+		"",
+		"~~~",
+		"k | v",
+		"--|--",
+		"~~~",
+		"",
+		"k | v",
+		"--|--",
+		"1 | 2",
+		"不是表格",
+		"",
+		"| 只有表头 |",
+		"| --- |",
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "tables.md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	tables, err := (&MdReader{}).GetTables(path)
+	if err != nil {
+		t.Fatalf("GetTables 失败: %v", err)
+	}
+
+	expected := []Table{
+		{
+			Header: []string{"名称", "说明"},
+			Rows:   [][]string{{"a", "x | y"}, {"b", ""}, {"c", "1"}},
+		},
+		{
+			Header: []string{"k", "v"},
+			Rows:   [][]string{{"1", "2"}},
+		},
+		{
+			Header: []string{"只有表头"},
+			Rows:   [][]string{},
+		},
+	}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("期望表格 %q, 得到 %q", expected, tables)
+	}
+
+	if !GetCapabilities(&MdReader{}).Tables {
+		t.Error("期望 Markdown 读取器支持表格")
+	}
+
+	if _, err := (&MdReader{}).GetTables(filepath.Join(t.TempDir(), "missing.md")); !errors.Is(err, ErrFileRead) {
+		t.Errorf("期望 ErrFileRead, 得到 %v", err)
+	}
+}
+
+// TestMdGetCodeBlocks 测试提取 Markdown 围栏代码块
+func TestMdGetCodeBlocks(t *testing.T) {
+	content := strings.Join([]string{
+		"# 示例",
+		"```go",
+		"package main",
+		"",
+		"func main() {}",
+		"```",
+		"正文",
+		"  ~~~sql {.numberLines}",
+		"  SELECT 1;",
+		"   ```",
+		"  ~~~",
+		"````",
+		"```",
+		"````",
+		"```",
+		"未闭合",
+		"",
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "code.md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	blocks, err := (&MdReader{}).GetCodeBlocks(path)
+	if err != nil {
+		t.Fatalf("GetCodeBlocks 失败: %v", err)
+	}
+
+	expected := []CodeBlock{
+		{Language: "go", Code: "package main\n\nfunc main() {}", Line: 2},
+		{Language: "sql", Code: "SELECT 1;\n ```", Line: 8},
+		{Language: "", Code: "```", Line: 12},
+		{Language: "", Code: "未闭合", Line: 15},
+	}
+	if !reflect.DeepEqual(blocks, expected) {
+		t.Errorf("期望代码块 %q, 得到 %q", expected, blocks)
+	}
+
+	if _, err := (&MdReader{}).GetCodeBlocks(filepath.Join(t.TempDir(), "missing.md")); !errors.Is(err, ErrFileRead) {
+		t.Errorf("期望 ErrFileRead, 得到 %v", err)
+	}
+}
+
+// TestXlsxShowFormulas 测试输出单元格公式而不是计算结果
+func TestXlsxShowFormulas(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "formulas.xlsx")
+
+	f := excelize.NewFile()
+	_ = f.SetSheetRow("Sheet1", "A1", &[]any{1, 2, 3})
+	_ = f.SetCellFormula("Sheet1", "C1", "SUM(A1:B1)")
+	_ = f.SetSheetRow("Sheet1", "A2", &[]any{"文本"})
+	// 第二个工作表中行末的公式单元格没有缓存结果，第 2 行只有公式
+	_, _ = f.NewSheet("Sheet2")
+	_ = f.SetCellValue("Sheet2", "A1", "文本")
+	_ = f.SetCellFormula("Sheet2", "B1", "LEN(A1)")
+	_ = f.SetCellFormula("Sheet2", "A2", "B1*2")
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("保存 XLSX 失败: %v", err)
+	}
+	f.Close()
+
+	config := NewReadConfig().WithRawCells(true).WithCellSeparator(",")
+	result, err := (&XlsxReader{}).ReadWithConfig(path, config)
+	if err != nil {
+		t.Fatalf("ReadWithConfig 失败: %v", err)
+	}
+	if !reflect.DeepEqual(result.Pages[0].Lines, []string{"1,2,3", "文本"}) {
+		t.Errorf("期望默认输出计算结果, 得到 %q", result.Pages[0].Lines)
+	}
+
+	result, err = (&XlsxReader{}).ReadWithConfig(path, config.WithShowFormulas(true))
+	if err != nil {
+		t.Fatalf("ReadWithConfig 失败: %v", err)
+	}
+	if !reflect.DeepEqual(result.Pages[0].Lines, []string{"1,2,=SUM(A1:B1)", "文本"}) {
+		t.Errorf("期望输出公式, 得到 %q", result.Pages[0].Lines)
+	}
+	if !reflect.DeepEqual(result.Pages[1].Lines, []string{"文本,=LEN(A1)", "=B1*2"}) {
+		t.Errorf("期望输出没有缓存结果的公式, 得到 %q", result.Pages[1].Lines)
+	}
+
+	text, err := (&XlsxReader{ShowFormulas: true}).ReadText(path)
+	if err != nil {
+		t.Fatalf("ReadText 失败: %v", err)
+	}
+	if !strings.Contains(text, "=SUM(A1:B1)") || !strings.Contains(text, "=LEN(A1)") || !strings.Contains(text, "=B1*2") {
+		t.Errorf("期望 ReadText 输出公式, 得到 %q", text)
+	}
+}
+
+// TestXlsxGetHyperlinks 测试获取单元格超链接
+func TestXlsxGetHyperlinks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.xlsx")
+
+	f := excelize.NewFile()
+	_ = f.SetSheetRow("Sheet1", "A1", &[]any{"官网", "普通文本", "跳转"})
+	_ = f.SetCellHyperLink("Sheet1", "A1", "https://example.com", "External")
+	_ = f.SetCellHyperLink("Sheet1", "C1", "Sheet1!A1", "Location")
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("保存 XLSX 失败: %v", err)
+	}
+	f.Close()
+
+	links, err := (&XlsxReader{}).GetHyperlinks(path, "Sheet1")
+	if err != nil {
+		t.Fatalf("GetHyperlinks 失败: %v", err)
+	}
+
+	expected := []CellLink{
+		{Cell: "A1", URL: "https://example.com", Display: "官网"},
+		{Cell: "C1", URL: "Sheet1!A1", Display: "跳转"},
+	}
+	if !reflect.DeepEqual(links, expected) {
+		t.Errorf("期望超链接 %v, 得到 %v", expected, links)
+	}
+
+	if _, err := (&XlsxReader{}).GetHyperlinks(path, "Missing"); !errors.Is(err, ErrSheetNotFound) {
+		t.Errorf("期望 ErrSheetNotFound, 得到 %v", err)
+	}
+}
+
+// TestXlsxGetNumericCells 测试获取数值单元格
+func TestXlsxGetNumericCells(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "numbers.xlsx")
+
+	f := excelize.NewFile()
+	_ = f.SetSheetRow("Sheet1", "A1", &[]any{"名称", 1.5, "42", true})
+	_ = f.SetSheetRow("Sheet1", "A3", &[]any{nil, -3, 0.25})
+	_ = f.SetCellFormula("Sheet1", "D3", "B1+B3")
+	percent, _ := f.NewStyle(&excelize.Style{NumFmt: 9})
+	_ = f.SetCellStyle("Sheet1", "C3", "C3", percent)
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("保存 XLSX 失败: %v", err)
+	}
+	f.Close()
+
+	cells, err := (&XlsxReader{}).GetNumericCells(path, "Sheet1")
+	if err != nil {
+		t.Fatalf("GetNumericCells 失败: %v", err)
+	}
+
+	expected := []NumericCell{
+		{Cell: "B1", Value: 1.5},
+		{Cell: "B3", Value: -3},
+		{Cell: "C3", Value: 0.25},
+	}
+	if !reflect.DeepEqual(cells, expected) {
+		t.Errorf("期望数值单元格 %v, 得到 %v", expected, cells)
+	}
+
+	if _, err := (&XlsxReader{}).GetNumericCells(path, "Missing"); !errors.Is(err, ErrSheetNotFound) {
+		t.Errorf("期望 ErrSheetNotFound, 得到 %v", err)
+	}
+}
+
+// TestReadGlob 测试按通配符模式读取多个文档
+func TestReadGlob(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"2024/a.txt":         "a",
+		"2024/b.md":          "b",
+		"2024/skip.bin":      "x",
+		"2024/q1/c.txt":      "c",
+		"2024/q1/deep/d.txt": "d",
+		"2023/e.txt":         "e",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("创建测试文件失败: %v", err)
+		}
+	}
+	// 损坏的 DOCX 读取失败，但不影响其他文件
+	if err := os.WriteFile(filepath.Join(dir, "2024", "broken.docx"), []byte("not a zip"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	keys := func(docs map[string]*Document) []string {
+		names := make([]string, 0, len(docs))
+		for path := range docs {
+			rel, _ := filepath.Rel(dir, path)
+			names = append(names, filepath.ToSlash(rel))
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	docs, err := ReadGlob(filepath.Join(dir, "2024", "*.txt"))
+	if err != nil {
+		t.Fatalf("ReadGlob 失败: %v", err)
+	}
+	if got := keys(docs); !reflect.DeepEqual(got, []string{"2024/a.txt"}) {
+		t.Errorf("期望只匹配 2024/a.txt, 得到 %v", got)
+	}
+	if docs[filepath.Join(dir, "2024", "a.txt")].Content != "a" {
+		t.Errorf("期望内容 %q, 得到 %q", "a", docs[filepath.Join(dir, "2024", "a.txt")].Content)
+	}
+
+	docs, err = ReadGlob(filepath.Join(dir, "2024", "*"))
+	if !errors.Is(err, ErrFileOpen) {
+		t.Errorf("期望合并的错误包含 ErrFileOpen, 得到 %v", err)
+	}
+	if got := keys(docs); !reflect.DeepEqual(got, []string{"2024/a.txt", "2024/b.md"}) {
+		t.Errorf("期望跳过不支持的格式和失败的文件, 得到 %v", got)
+	}
+
+	docs, err = ReadGlob(filepath.Join(dir, "**", "*.txt"))
+	if err != nil {
+		t.Fatalf("ReadGlob 失败: %v", err)
+	}
+	expected := []string{"2023/e.txt", "2024/a.txt", "2024/q1/c.txt", "2024/q1/deep/d.txt"}
+	if got := keys(docs); !reflect.DeepEqual(got, expected) {
+		t.Errorf("期望 %v, 得到 %v", expected, got)
+	}
+
+	docs, err = ReadGlob(filepath.Join(dir, "2024", "**", "c.txt"))
+	if err != nil {
+		t.Fatalf("ReadGlob 失败: %v", err)
+	}
+	if got := keys(docs); !reflect.DeepEqual(got, []string{"2024/q1/c.txt"}) {
+		t.Errorf("期望只匹配 2024/q1/c.txt, 得到 %v", got)
+	}
+
+	if _, err := ReadGlob(filepath.Join(dir, "[")); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("期望 ErrInvalidQuery, 得到 %v", err)
+	}
+	if _, err := ReadGlob(filepath.Join(dir, "**", "[")); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("期望 ErrInvalidQuery, 得到 %v", err)
+	}
+}
+
+// TestSplitParagraphs 测试 TXT/Markdown 按段落划分内容
+func TestSplitParagraphs(t *testing.T) {
+	dir := t.TempDir()
+	content := "第一段第一行\n第一段第二行\n\n   \n第二段\n\n\n第三段\n"
+
+	for _, name := range []string{"p.txt", "p.md"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("创建测试文件失败: %v", err)
+		}
+
+		result, err := ReadDocumentWithConfig(path, NewReadConfig().WithSplitMode(SplitParagraphs))
+		if err != nil {
+			t.Fatalf("%s: 读取失败: %v", name, err)
+		}
+		expected := []string{"第一段第一行\n第一段第二行", "第二段", "第三段"}
+		if !reflect.DeepEqual(result.Pages[0].Lines, expected) {
+			t.Errorf("%s: 期望段落 %q, 得到 %q", name, expected, result.Pages[0].Lines)
+		}
+
+		// LineSelector 选择的是段落
+		config := NewReadConfig().WithSplitMode(SplitParagraphs).WithLines(1)
+		result, err = ReadDocumentWithConfig(path, config)
+		if err != nil {
+			t.Fatalf("%s: 读取失败: %v", name, err)
+		}
+		if !reflect.DeepEqual(result.Pages[0].Lines, []string{"第二段"}) {
+			t.Errorf("%s: 期望选择第二段, 得到 %q", name, result.Pages[0].Lines)
+		}
+
+		// 默认仍按物理行划分
+		result, err = ReadDocumentWithConfig(path, NewReadConfig())
+		if err != nil {
+			t.Fatalf("%s: 读取失败: %v", name, err)
+		}
+		if len(result.Pages[0].Lines) != 9 {
+			t.Errorf("%s: 期望 9 行, 得到 %d", name, len(result.Pages[0].Lines))
+		}
+	}
+
+	// 始终流式读取的 TXT 读取器按段落划分时不使用流式读取
+	path := filepath.Join(dir, "p.txt")
+	result, err := (&TxtReader{StreamThreshold: -1}).ReadWithConfig(path, NewReadConfig().WithSplitMode(SplitParagraphs))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(result.Pages[0].Lines) != 3 {
+		t.Errorf("期望 3 个段落, 得到 %q", result.Pages[0].Lines)
+	}
+}
+
+// fakeOCREngine 测试用的 OCR 引擎，返回固定文本并记录收到的格式
+type fakeOCREngine struct {
+	text   string
+	err    error
+	format string
+}
+
+func (e *fakeOCREngine) Recognize(data []byte, format string) (string, error) {
+	e.format = format
+	return e.text, e.err
+}
+
+// TestImageReader 测试通过 OCR 引擎读取图片
+func TestImageReader(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scan.png")
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 40, 30))); err != nil {
+		t.Fatalf("生成图片失败: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	// 未设置 OCR 引擎时图片不是支持的格式
+	if _, err := ReadDocument(path); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("期望 ErrUnsupportedFormat, 得到 %v", err)
+	}
+	if IsFormatSupported(".png") {
+		t.Error("期望未设置 OCR 引擎时不支持 .png")
+	}
+	if _, err := (&ImageReader{}).ReadText(path); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("期望 ErrInvalidConfig, 得到 %v", err)
+	}
+
+	engine := &fakeOCREngine{text: "第一行\n第二行"}
+	SetOCREngine(engine)
+	defer SetOCREngine(nil)
+
+	if !IsFormatSupported("JPG") || !slices.Contains(GetSupportedFormats(), ".png") {
+		t.Error("期望设置 OCR 引擎后支持图片格式")
+	}
+
+	doc, err := ReadDocument(path)
+	if err != nil {
+		t.Fatalf("ReadDocument 失败: %v", err)
+	}
+	if doc.Content != "第一行\n第二行" {
+		t.Errorf("期望识别的文本, 得到 %q", doc.Content)
+	}
+	if engine.format != "png" {
+		t.Errorf("期望格式 png, 得到 %q", engine.format)
+	}
+	if doc.Metadata["width"] != "40" || doc.Metadata["height"] != "30" || doc.Metadata["format"] != "png" {
+		t.Errorf("期望图片尺寸和格式, 得到 %v", doc.Metadata)
+	}
+
+	result, err := ReadDocumentWithConfig(path, NewReadConfig().WithLines(1))
+	if err != nil {
+		t.Fatalf("ReadDocumentWithConfig 失败: %v", err)
+	}
+	if !reflect.DeepEqual(result.Pages[0].Lines, []string{"第二行"}) {
+		t.Errorf("期望第二行, 得到 %q", result.Pages[0].Lines)
+	}
+
+	raw, err := ReadDocumentRaw(path)
+	if err != nil || raw != "第一行\n第二行" {
+		t.Errorf("ReadDocumentRaw 期望识别的文本, 得到 %q, %v", raw, err)
+	}
+
+	// 压缩包中的图片也通过 OCR 读取
+	zipPath := filepath.Join(dir, "scans.zip")
+	writeZipFile(t, zipPath, map[string]string{"scan.png": buf.String()})
+	raw, err = ReadDocumentRaw(zipPath)
+	if err != nil || raw != "第一行\n第二行" {
+		t.Errorf("ReadDocumentRaw 期望压缩包中图片的文本, 得到 %q, %v", raw, err)
+	}
+
+	// 读取器上的引擎优先于包级引擎，识别失败时返回 ErrFileParse 并保留原始错误
+	engineErr := errors.New("engine unavailable")
+	_, err = (&ImageReader{Engine: &fakeOCREngine{err: engineErr}}).ReadText(path)
+	if !errors.Is(err, ErrFileParse) || !errors.Is(err, engineErr) {
+		t.Errorf("期望 ErrFileParse 和引擎错误, 得到 %v", err)
+	}
+}
+
+// TestDiffDocuments 测试按行比较两个文档
+func TestDiffDocuments(t *testing.T) {
+	dir := t.TempDir()
+	pathA := filepath.Join(dir, "v1.txt")
+	pathB := filepath.Join(dir, "v2.txt")
+	if err := os.WriteFile(pathA, []byte("甲方\n乙方\n金额：100\n签字"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	if err := os.WriteFile(pathB, []byte("甲方\n乙方\n金额：200\n签字\n附件"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	diff, err := DiffDocuments(pathA, pathB)
+	if err != nil {
+		t.Fatalf("DiffDocuments 失败: %v", err)
+	}
+
+	expected := []DiffLine{
+		{Op: DiffEqual, Text: "甲方"},
+		{Op: DiffEqual, Text: "乙方"},
+		{Op: DiffDelete, Text: "金额：100"},
+		{Op: DiffInsert, Text: "金额：200"},
+		{Op: DiffEqual, Text: "签字"},
+		{Op: DiffInsert, Text: "附件"},
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("期望差异 %v, 得到 %v", expected, diff)
+	}
+
+	if _, err := DiffDocuments(pathA, filepath.Join(dir, "missing.txt")); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound, 得到 %v", err)
+	}
+
+	// 差异应能还原两个文档，且编辑次数最少
+	cases := []struct {
+		a, b  []string
+		edits int
+	}{
+		{nil, nil, 0},
+		{[]string{"a"}, nil, 1},
+		{nil, []string{"a", "b"}, 2},
+		{strings.Split("abcabba", ""), strings.Split("cbabac", ""), 5},
+		{strings.Split("abc", ""), strings.Split("abc", ""), 0},
+		{strings.Split("abc", ""), strings.Split("xyz", ""), 6},
+	}
+	for _, c := range cases {
+		var gotA, gotB []string
+		edits := 0
+		for _, line := range diffLines(c.a, c.b) {
+			switch line.Op {
+			case DiffEqual:
+				gotA, gotB = append(gotA, line.Text), append(gotB, line.Text)
+			case DiffDelete:
+				gotA = append(gotA, line.Text)
+				edits++
+			case DiffInsert:
+				gotB = append(gotB, line.Text)
+				edits++
+			}
+		}
+		if !slices.Equal(gotA, c.a) || !slices.Equal(gotB, c.b) {
+			t.Errorf("%q -> %q: 差异无法还原文档, 得到 %q 和 %q", c.a, c.b, gotA, gotB)
+		}
+		if edits != c.edits {
+			t.Errorf("%q -> %q: 期望 %d 次编辑, 得到 %d", c.a, c.b, c.edits, edits)
+		}
+	}
+}
+
+// TestDiffLinesLargeInput 测试长文档中只有少量修改时，回溯记录的内存与编辑距离相关而不是与文档长度相关
+func TestDiffLinesLargeInput(t *testing.T) {
+	const lineCount = 50000
+	a := make([]string, lineCount)
+	for i := range a {
+		a[i] = strconv.Itoa(i)
+	}
+	b := slices.Clone(a)
+	for i := 0; i < 10; i++ {
+		b[i*lineCount/10] = "changed"
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	diff := diffLines(a, b)
+	runtime.ReadMemStats(&after)
+
+	edits := 0
+	for _, line := range diff {
+		if line.Op != DiffEqual {
+			edits++
+		}
+	}
+	if edits != 20 {
+		t.Errorf("期望 20 次编辑, 得到 %d", edits)
+	}
+
+	// 结果本身约 2.4MB；每一步保存整个 v 时回溯记录接近 40MB
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 8<<20 {
+		t.Errorf("分配了 %d 字节，回溯记录不应与文档长度成正比", allocated)
+	}
+}
+
+// TestPdfPageErrorMode 测试 PDF 页面读取失败时的处理方式
+func TestPdfPageErrorMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.pdf")
+	content := "BT /F1 12 Tf 72 700 Td (Hello) Tj ET"
+	// 页面树声明了 3 页，但只有 2 个页面对象，第 3 页无法读取
+	writePdfFile(t, path, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 3 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 5 0 R >> >> /Contents 6 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 5 0 R >> >> /Contents 6 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	)
+
+	// 默认跳过失败的页
+	result, err := ReadDocumentWithConfig(path, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(result.Pages) != 2 || len(result.Warnings) != 1 {
+		t.Errorf("期望 2 页和 1 条警告, 得到 %d 页, 警告 %q", len(result.Pages), result.Warnings)
+	}
+
+	// 占位模式保持页码对应
+	result, err = ReadDocumentWithConfig(path, NewReadConfig().WithPdfPageErrorMode(PdfPagePlaceholder).WithLines(5))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(result.Pages) != 3 || result.Pages[2].PageNumber != 2 {
+		t.Fatalf("期望 3 页, 得到 %+v", result.Pages)
+	}
+	if !reflect.DeepEqual(result.Pages[2].Lines, []string{"[page 3 unreadable]"}) {
+		t.Errorf("期望占位行, 得到 %q", result.Pages[2].Lines)
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("期望占位页也记录警告, 得到 %q", result.Warnings)
+	}
+
+	// 错误模式立即返回错误
+	_, err = ReadDocumentWithConfig(path, NewReadConfig().WithPdfPageErrorMode(PdfPageError))
+	if !errors.Is(err, ErrFileParse) || !strings.Contains(err.Error(), "page 3") {
+		t.Errorf("期望包含页码的 ErrFileParse, 得到 %v", err)
+	}
+}
+
+// TestPdfGetTextElements 测试获取 PDF 文本片段的坐标
+func TestPdfGetTextElements(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "elements.pdf")
+	content := "BT /F1 12 Tf 72 700 Td (Hi) Tj ET"
+	writePdfFile(t, path, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	)
+
+	reader := &PdfReader{}
+	elements, err := reader.GetTextElements(path, 0)
+	if err != nil {
+		t.Fatalf("GetTextElements 失败: %v", err)
+	}
+
+	var text strings.Builder
+	for _, element := range elements {
+		text.WriteString(element.Text)
+		if element.Y != 700 || element.H != 12 {
+			t.Errorf("期望基线 Y=700、高度 12, 得到 %+v", element)
+		}
+	}
+	if text.String() != "Hi" {
+		t.Errorf("期望文本 %q, 得到 %q", "Hi", text.String())
+	}
+	if len(elements) == 0 || elements[0].X != 72 {
+		t.Errorf("期望第一个片段从 X=72 开始, 得到 %+v", elements)
+	}
+
+	for _, page := range []int{-1, 1} {
+		if _, err := reader.GetTextElements(path, page); !errors.Is(err, ErrPageNotFound) {
+			t.Errorf("页码 %d: 期望 ErrPageNotFound, 得到 %v", page, err)
+		}
+	}
+}
+
+// TestFlatLines 测试将结构化结果展开为行
+func TestFlatLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flat.xlsx")
+	// Sheet1 始终是第一个工作表，避免依赖 map 的遍历顺序
+	writeXlsxFile(t, path, map[string][][]any{
+		"Sheet1": {{"a1"}, {"a2"}},
+		"Sheet2": {{"b1"}},
+	})
+
+	config := NewReadConfig().WithRawCells(true).WithLines(1).WithLineNumbers(true)
+	result, err := ReadDocumentWithConfig(path, config)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+
+	// 行选择器选择每个工作表的第 2 行，Sheet2 只有 1 行
+	expected := []FlatLine{
+		{Page: 0, PageName: "Sheet1", LineIndex: 0, LineNumber: 1, Text: "a2"},
+	}
+	if got := result.FlatLines(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("期望 %+v, 得到 %+v", expected, got)
+	}
+
+	result, err = ReadDocumentWithConfig(path, NewReadConfig().WithRawCells(true))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	got := result.FlatLines()
+	if len(got) != 3 || got[1].LineIndex != 1 || got[1].LineNumber != -1 || got[2].PageName != "Sheet2" || got[2].Text != "b1" {
+		t.Errorf("展开的行不符合预期: %+v", got)
+	}
+}
+
+// TestDocxGetComments 测试获取带回复结构的批注
+func TestDocxGetComments(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "comments.docx")
+	comment := func(id, author, paraID, text string) string {
+		return `<w:comment w:id="` + id + `" w:author="` + author + `" w:initials="` + author[:1] + `" w:date="2024-01-0` + id + `T00:00:00Z">` +
+			`<w:p w14:paraId="` + paraID + `"><w:r><w:annotationRef/></w:r><w:r><w:t>` + text + `</w:t></w:r></w:p></w:comment>`
+	}
+	writeZipFile(t, path, map[string]string{
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>正文</w:t></w:r></w:p>`),
+		"word/comments.xml": `<w:comments xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml">` +
+			comment("1", "Alice", "00000001", "请核对金额") +
+			comment("2", "Bob", "00000002", "已核对") +
+			comment("3", "Alice", "00000003", "谢谢") +
+			comment("4", "Carol", "00000004", "另一个问题") +
+			`</w:comments>`,
+		"word/commentsExtended.xml": `<w15:commentsEx xmlns:w15="http://schemas.microsoft.com/office/word/2012/wordml">` +
+			`<w15:commentEx w15:paraId="00000001" w15:done="1"/>` +
+			`<w15:commentEx w15:paraId="00000002" w15:paraIdParent="00000001" w15:done="0"/>` +
+			`<w15:commentEx w15:paraId="00000003" w15:paraIdParent="00000001" w15:done="0"/>` +
+			`<w15:commentEx w15:paraId="00000004" w15:done="0"/>` +
+			`</w15:commentsEx>`,
+	})
+
+	reader := &DocxReader{}
+	comments, err := reader.GetComments(path)
+	if err != nil {
+		t.Fatalf("GetComments 失败: %v", err)
+	}
+
+	expected := []Comment{
+		{ID: "1", Author: "Alice", Initials: "A", Date: "2024-01-01T00:00:00Z", Text: "请核对金额", Done: true, Replies: []Comment{
+			{ID: "2", Author: "Bob", Initials: "B", Date: "2024-01-02T00:00:00Z", Text: "已核对"},
+			{ID: "3", Author: "Alice", Initials: "A", Date: "2024-01-03T00:00:00Z", Text: "谢谢"},
+		}},
+		{ID: "4", Author: "Carol", Initials: "C", Date: "2024-01-04T00:00:00Z", Text: "另一个问题"},
+	}
+	if !reflect.DeepEqual(comments, expected) {
+		t.Errorf("期望批注 %+v, 得到 %+v", expected, comments)
+	}
+
+	// 没有 commentsExtended.xml 时所有批注都是顶层批注
+	rewriteZipEntry(t, path, "word/commentsExtended.xml", `<w15:commentsEx xmlns:w15="http://schemas.microsoft.com/office/word/2012/wordml"/>`)
+	comments, err = reader.GetComments(path)
+	if err != nil {
+		t.Fatalf("GetComments 失败: %v", err)
+	}
+	if len(comments) != 4 || comments[0].Replies != nil {
+		t.Errorf("期望 4 条顶层批注, 得到 %+v", comments)
+	}
+
+	// 没有批注的文档返回空切片
+	plain := filepath.Join(dir, "plain.docx")
+	writeZipFile(t, plain, map[string]string{
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>正文</w:t></w:r></w:p>`),
+	})
+	comments, err = reader.GetComments(plain)
+	if err != nil || comments == nil || len(comments) != 0 {
+		t.Errorf("期望空切片, 得到 %v, %v", comments, err)
+	}
+}
+
+// TestReadDocumentWithHash 测试文件和提取文本的哈希值
+func TestReadDocumentWithHash(t *testing.T) {
+	dir := t.TempDir()
+	pathA := filepath.Join(dir, "a.txt")
+	pathB := filepath.Join(dir, "b.txt")
+	if err := os.WriteFile(pathA, []byte("hello"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	// 带 BOM 的文件字节不同，但提取的文本相同
+	if err := os.WriteFile(pathB, []byte("\xEF\xBB\xBFhello"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	docA, err := ReadDocumentWithHash(pathA)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	docB, err := ReadDocumentWithHash(pathB)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+
+	const helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if docA.Metadata["sha256"] != helloSHA256 || docA.Metadata["content_sha256"] != helloSHA256 {
+		t.Errorf("哈希值不符合预期: %v", docA.Metadata)
+	}
+	if docB.Metadata["sha256"] == helloSHA256 {
+		t.Error("期望带 BOM 的文件哈希不同")
+	}
+	if docB.Metadata["content_sha256"] != helloSHA256 {
+		t.Errorf("期望提取文本的哈希相同, 得到 %s", docB.Metadata["content_sha256"])
+	}
+
+	if _, err := ReadDocumentWithHash(filepath.Join(dir, "missing.txt")); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound, 得到 %v", err)
+	}
+}
+
+// TestXlsxRawValues 测试输出单元格的原始值
+func TestXlsxRawValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "formatted.xlsx")
+
+	f := excelize.NewFile()
+	_ = f.SetSheetRow("Sheet1", "A1", &[]any{1234.5, 0.25})
+	currency, _ := f.NewStyle(&excelize.Style{NumFmt: 4})
+	percent, _ := f.NewStyle(&excelize.Style{NumFmt: 10})
+	_ = f.SetCellStyle("Sheet1", "A1", "A1", currency)
+	_ = f.SetCellStyle("Sheet1", "B1", "B1", percent)
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("保存 XLSX 失败: %v", err)
+	}
+	f.Close()
+
+	config := NewReadConfig().WithRawCells(true).WithCellSeparator(",")
+	result, err := ReadDocumentWithConfig(path, config)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if got := result.Pages[0].Lines[0]; got != "1,234.50,25.00%" {
+		t.Errorf("期望格式化后的值, 得到 %q", got)
+	}
+
+	result, err = ReadDocumentWithConfig(path, config.WithXlsxRawValues(true))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if got := result.Pages[0].Lines[0]; got != "1234.5,0.25" {
+		t.Errorf("期望原始值, 得到 %q", got)
+	}
+
+	text, err := (&XlsxReader{RawValues: true}).ReadText(path)
+	if err != nil {
+		t.Fatalf("ReadText 失败: %v", err)
+	}
+	if !strings.Contains(text, "1234.5 | 0.25") {
+		t.Errorf("期望 ReadText 输出原始值, 得到 %q", text)
+	}
+}
+
+// TestGetEmbeddedObjects 测试提取并读取嵌入的对象
+func TestGetEmbeddedObjects(t *testing.T) {
+	dir := t.TempDir()
+	sheetPath := filepath.Join(dir, "sheet.xlsx")
+	writeXlsxFile(t, sheetPath, map[string][][]any{"Sheet1": {{"嵌入的数据"}}})
+	sheetData, err := os.ReadFile(sheetPath)
+	if err != nil {
+		t.Fatalf("读取测试文件失败: %v", err)
+	}
+
+	path := filepath.Join(dir, "host.docx")
+	writeZipFile(t, path, map[string]string{
+		"[Content_Types].xml": `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="bin" ContentType="application/vnd.openxmlformats-officedocument.oleObject"/>` +
+			`<Override PartName="/word/embeddings/Microsoft_Excel_Worksheet.xlsx" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"/>` +
+			`</Types>`,
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>正文</w:t></w:r></w:p>`),
+		"word/embeddings/Microsoft_Excel_Worksheet.xlsx": string(sheetData),
+		"word/embeddings/oleObject1.bin":                 "ole",
+		"word/media/image1.png":                          "png",
+	})
+
+	objects, err := GetEmbeddedObjects(path)
+	if err != nil {
+		t.Fatalf("GetEmbeddedObjects 失败: %v", err)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("期望 2 个嵌入对象, 得到 %d", len(objects))
+	}
+	if objects[0].Name != "word/embeddings/Microsoft_Excel_Worksheet.xlsx" ||
+		objects[0].ContentType != "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet" ||
+		!bytes.Equal(objects[0].Data, sheetData) {
+		t.Errorf("第一个嵌入对象不符合预期: %s %s", objects[0].Name, objects[0].ContentType)
+	}
+	if objects[1].ContentType != "application/vnd.openxmlformats-officedocument.oleObject" || string(objects[1].Data) != "ole" {
+		t.Errorf("第二个嵌入对象不符合预期: %+v", objects[1])
+	}
+
+	// 嵌入的工作簿可以继续读取
+	doc, err := objects[0].Read()
+	if err != nil {
+		t.Fatalf("读取嵌入对象失败: %v", err)
+	}
+	if !strings.Contains(doc.Content, "嵌入的数据") || doc.FilePath != objects[0].Name {
+		t.Errorf("嵌入对象的内容不符合预期: %+v", doc)
+	}
+	if _, err := objects[1].Read(); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("期望 ErrUnsupportedFormat, 得到 %v", err)
+	}
+
+	if _, err := GetEmbeddedObjects(filepath.Join(dir, "a.txt")); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("期望 ErrUnsupportedFormat, 得到 %v", err)
+	}
+}
+
+// TestReadDocumentTimeout 测试带超时的读取
+func TestReadDocumentTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	doc, err := ReadDocumentTimeout(path, time.Minute)
+	if err != nil || doc.Content != "hello" {
+		t.Fatalf("期望读取成功, 得到 %v, %v", doc, err)
+	}
+
+	doc, err = ReadDocumentTimeout(path, 0)
+	if err != nil || doc.Content != "hello" {
+		t.Fatalf("期望不限制时间时读取成功, 得到 %v, %v", doc, err)
+	}
+
+	if _, err := ReadDocumentTimeout(filepath.Join(t.TempDir(), "missing.txt"), time.Minute); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound, 得到 %v", err)
+	}
+
+	// 阻塞的 OCR 引擎用于模拟耗时的读取
+	release := make(chan struct{})
+	SetOCREngine(&blockingOCREngine{release: release})
+	defer SetOCREngine(nil)
+	defer close(release)
+
+	imagePath := filepath.Join(t.TempDir(), "slow.png")
+	if err := os.WriteFile(imagePath, []byte("png"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	start := time.Now()
+	_, err = ReadDocumentTimeout(imagePath, 50*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("期望 context.DeadlineExceeded, 得到 %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("超时后应立即返回, 实际耗时 %v", elapsed)
+	}
+}
+
+// blockingOCREngine 在 release 关闭之前一直阻塞的 OCR 引擎
+type blockingOCREngine struct {
+	release chan struct{}
+}
+
+func (e *blockingOCREngine) Recognize(data []byte, format string) (string, error) {
+	<-e.release
+	return "", nil
+}
+
+// TestReadFirstSection 测试只读取文档的第一部分
+func TestReadFirstSection(t *testing.T) {
+	dir := t.TempDir()
+
+	// 第二张幻灯片已损坏，只读取第一张时不应解析它
+	pptxFile := filepath.Join(dir, "slides.pptx")
+	writeZipFile(t, pptxFile, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("封面", "副标题"),
+		"ppt/slides/slide2.xml": "<p:sld",
+	})
+	page, err := ReadFirstSection(pptxFile)
+	if err != nil {
+		t.Fatalf("读取第一张幻灯片失败: %v", err)
+	}
+	if page.PageNumber != 0 || !reflect.DeepEqual(page.Lines, []string{"封面", "副标题"}) {
+		t.Errorf("第一张幻灯片不符合预期: %+v", page)
+	}
+	if _, err := ReadDocumentWithConfig(pptxFile, NewReadConfig().WithFailOnPartialError(true)); !errors.Is(err, ErrFileParse) {
+		t.Errorf("严格模式读取全部幻灯片时期望 ErrFileParse, 得到 %v", err)
+	}
+
+	xlsxFile := filepath.Join(dir, "book.xlsx")
+	writeXlsxFile(t, xlsxFile, map[string][][]any{
+		"Sheet1": {{"a", 1}},
+		"Sheet2": {{"b", 2}},
+	})
+	page, err = ReadFirstSection(xlsxFile)
+	if err != nil {
+		t.Fatalf("读取第一个工作表失败: %v", err)
+	}
+	if page.PageName != "Sheet1" || len(page.Lines) != 1 || !strings.Contains(page.Lines[0], "a") {
+		t.Errorf("第一个工作表不符合预期: %+v", page)
+	}
+
+	// DOCX 的第一部分在第一个分页符处结束
+	docxFile := filepath.Join(dir, "report.docx")
+	writeZipFile(t, docxFile, map[string]string{
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>第一页</w:t><w:br w:type="page"/><w:t>第二页</w:t></w:r></w:p>`),
+	})
+	page, err = ReadFirstSection(docxFile)
+	if err != nil {
+		t.Fatalf("读取 DOCX 第一页失败: %v", err)
+	}
+	if page.PageNumber != 0 || !reflect.DeepEqual(page.Lines, []string{"第一页"}) {
+		t.Errorf("DOCX 第一页不符合预期: %+v", page)
+	}
+
+	txtFile := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(txtFile, []byte("一\n二\n三"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	page, err = ReadFirstSection(txtFile)
+	if err != nil || len(page.Lines) != 3 {
+		t.Errorf("期望返回单页文档的全部行, 得到 %+v, %v", page, err)
+	}
+
+	if _, err := ReadFirstSection(filepath.Join(dir, "missing.pdf")); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound, 得到 %v", err)
+	}
+}
+
+// TestReadCombinedDropRepeatedHeaders 测试合并多个表头相同的 CSV/XLSX 文件
+func TestReadCombinedDropRepeatedHeaders(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "a.csv")
+	second := filepath.Join(dir, "b.csv")
+	if err := os.WriteFile(first, []byte("name,age\n张三,30\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	if err := os.WriteFile(second, []byte("name,age\n李四,25\n"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	xlsxFile := filepath.Join(dir, "c.xlsx")
+	writeXlsxFile(t, xlsxFile, map[string][][]any{
+		"Sheet1": {{"name", "age"}, {"王五", 40}},
+	})
+
+	config := NewReadConfig().WithRawCells(true).WithCellSeparator(",")
+	result, err := ReadCombined([]string{first, second}, config)
+	if err != nil {
+		t.Fatalf("合并读取失败: %v", err)
+	}
+	if result.Content != "name,age\n张三,30\nname,age\n李四,25" {
+		t.Errorf("默认应保留所有表头, 得到 %q", result.Content)
+	}
+
+	result, err = ReadCombined([]string{first, second, xlsxFile}, config.WithDropRepeatedHeaders(true))
+	if err != nil {
+		t.Fatalf("合并读取失败: %v", err)
+	}
+	lines := make([]string, 0)
+	for _, line := range result.FlatLines() {
+		lines = append(lines, line.Text)
+	}
+	if want := []string{"name,age", "张三,30", "李四,25", "王五,40"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("期望只保留第一个文件的表头 %v, 得到 %v", want, lines)
+	}
+	if result.TotalLines != 4 || result.Metadata["file_count"] != "3" {
+		t.Errorf("合并结果的统计不符合预期: TotalLines=%d, Metadata=%v", result.TotalLines, result.Metadata)
+	}
+
+	if _, err := ReadCombined([]string{first, filepath.Join(dir, "missing.csv")}, nil); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound, 得到 %v", err)
+	}
+}
+
+// TestRtfSkipsPicturesAndObjects 测试 RTF 中的图片和嵌入对象不会出现在文本中
+func TestRtfSkipsPicturesAndObjects(t *testing.T) {
+	const blob = "89504e470d0a1a0a0000000d49484452"
+	rtf := `{\rtf1\ansi\ansicpg936\deff0{\fonttbl{\f0\fnil\fcharset134 SimSun;}}` + "\n" +
+		`{\colortbl;\red0\green0\blue0;}` + "\n" +
+		`\pard 第一段 \'c4\'e3\'ba\'c3\par` + "\n" +
+		`{\*\shppict{\pict\pngblip\picw16\pich16 ` + blob + "\n" + blob + `}}` +
+		`{\nonshppict{\pict\wmetafile8 ` + blob + `}}` +
+		`{\object\objemb{\*\objclass Excel.Sheet.12}{\*\objdata 01050000` + blob + `}{\result 结果}}` +
+		`{\pict\bin4 }{}\}}` + "\n" +
+		`\pard caf\u233?\uc2\u20320??\par` + "\n" +
+		`\pard Line \{braces\}\par}`
+
+	path := filepath.Join(t.TempDir(), "image.rtf")
+	if err := os.WriteFile(path, []byte(rtf), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	text, err := (&RtfReader{}).ReadText(path)
+	if err != nil {
+		t.Fatalf("读取 RTF 失败: %v", err)
+	}
+	if strings.Contains(text, blob) || strings.Contains(text, "0105") || strings.Contains(text, "SimSun") {
+		t.Errorf("结果中不应包含图片、对象或字体表的数据: %q", text)
+	}
+	if want := "第一段 你好\ncafé你\nLine {braces}"; text != want {
+		t.Errorf("期望 %q, 得到 %q", want, text)
+	}
+
+	result, err := ReadDocumentWithConfig(path, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取 RTF 失败: %v", err)
+	}
+	if result.TotalLines != 3 {
+		t.Errorf("期望每个段落一行共 3 行, 得到 %d: %v", result.TotalLines, result.Pages[0].Lines)
+	}
+}
+
+// TestRtfGetHyperlinks 测试提取 RTF 中的超链接
+func TestRtfGetHyperlinks(t *testing.T) {
+	rtf := `{\rtf1\ansi\ansicpg936` + "\n" +
+		`\pard 访问{\field{\*\fldinst{HYPERLINK "https://example.com/docs" \\o "提示"}}{\fldrslt{\ul \'b9\'d9\'cd\'f8}}}了解更多\par` + "\n" +
+		`\pard {\field{\*\fldinst HYPERLINK \\l "intro"}{\fldrslt 简介}}\par` + "\n" +
+		`\pard {\field{\*\fldinst PAGE}{\fldrslt 3}} {\field{\*\fldinst HYPERLINK "https://a.b"}{\fldrslt https://a.b}}\par` + "\n" +
+		`{\header {\field{\*\fldinst HYPERLINK "https://header"}{\fldrslt 页眉}}}}`
+
+	path := filepath.Join(t.TempDir(), "links.rtf")
+	if err := os.WriteFile(path, []byte(rtf), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	reader := &RtfReader{}
+	links, err := reader.GetHyperlinks(path)
+	if err != nil {
+		t.Fatalf("获取超链接失败: %v", err)
+	}
+	want := []Hyperlink{
+		{URL: "https://example.com/docs", Text: "官网"},
+		{URL: "#intro", Text: "简介"},
+		{URL: "https://a.b", Text: "https://a.b"},
+	}
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("期望 %+v, 得到 %+v", want, links)
+	}
+
+	text, err := reader.ReadText(path)
+	if err != nil {
+		t.Fatalf("读取 RTF 失败: %v", err)
+	}
+	if want := "访问官网了解更多\n简介\n3 https://a.b"; text != want {
+		t.Errorf("默认只输出显示文本, 期望 %q, 得到 %q", want, text)
+	}
+
+	result, err := ReadDocumentWithConfig(path, NewReadConfig().WithInlineHyperlinks(true))
+	if err != nil {
+		t.Fatalf("读取 RTF 失败: %v", err)
+	}
+	if want := "访问官网 (https://example.com/docs)了解更多\n简介 (#intro)\n3 https://a.b"; result.Content != want {
+		t.Errorf("期望内联 URL %q, 得到 %q", want, result.Content)
+	}
+
+	if _, err := reader.GetHyperlinks(filepath.Join(t.TempDir(), "missing.rtf")); !errors.Is(err, ErrFileRead) {
+		t.Errorf("期望 ErrFileRead, 得到 %v", err)
+	}
+}
+
+// TestMergeDocuments 测试合并多个文档
+func TestMergeDocuments(t *testing.T) {
+	merged := MergeDocuments(
+		&Document{FilePath: "a/report.pdf", Content: "第一份", Metadata: map[string]string{"author": "张三", "pages": "2"}},
+		nil,
+		&Document{FilePath: "b/notes.txt", Content: "第二份", Metadata: map[string]string{"author": "李四", "pages": "2"}},
+		&Document{FilePath: "c/notes.txt", Content: "第三份", Metadata: map[string]string{"title": "笔记"}},
+	)
+
+	if want := "第一份" + MergeSeparator + "第二份" + MergeSeparator + "第三份"; merged.Content != want {
+		t.Errorf("期望内容 %q, 得到 %q", want, merged.Content)
+	}
+	want := map[string]string{
+		"report.pdf:author":  "张三",
+		"b/notes.txt:author": "李四",
+		"pages":              "2",
+		"title":              "笔记",
+		"sources":            "a/report.pdf\nb/notes.txt\nc/notes.txt",
+	}
+	if !reflect.DeepEqual(merged.Metadata, want) {
+		t.Errorf("期望元数据 %v, 得到 %v", want, merged.Metadata)
+	}
+
+	dir := t.TempDir()
+	first := filepath.Join(dir, "a.txt")
+	second := filepath.Join(dir, "b.md")
+	if err := os.WriteFile(first, []byte("hello"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	if err := os.WriteFile(second, []byte("# world"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+	doc, err := ReadAndMerge(first, second)
+	if err != nil {
+		t.Fatalf("读取并合并失败: %v", err)
+	}
+	if doc.Content != "hello"+MergeSeparator+"# world" || doc.Metadata["sources"] != first+"\n"+second {
+		t.Errorf("合并结果不符合预期: %q, %v", doc.Content, doc.Metadata)
+	}
+	if _, ok := doc.Metadata["a.txt:size"]; !ok {
+		t.Errorf("冲突的 size 应以文件名为前缀: %v", doc.Metadata)
+	}
+
+	if _, err := ReadAndMerge(first, filepath.Join(dir, "missing.txt")); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("期望 ErrFileNotFound, 得到 %v", err)
+	}
+}
+
+// TestGetRawXML 测试读取 DOCX 正文和 PPTX 幻灯片的原始 XML
+func TestGetRawXML(t *testing.T) {
+	dir := t.TempDir()
+
+	docxFile := filepath.Join(dir, "a.docx")
+	documentXML := docxDocumentXML(`<w:p><w:r><w:t>正文</w:t></w:r></w:p>`)
+	writeZipFile(t, docxFile, map[string]string{"word/document.xml": documentXML})
+	data, err := (&DocxReader{}).GetDocumentXML(docxFile)
+	if err != nil || string(data) != documentXML {
+		t.Errorf("期望返回 document.xml 的原始内容, 得到 %q, %v", data, err)
+	}
+
+	emptyDocx := filepath.Join(dir, "empty.docx")
+	writeZipFile(t, emptyDocx, map[string]string{"docProps/core.xml": "<x/>"})
+	if _, err := (&DocxReader{}).GetDocumentXML(emptyDocx); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("期望 ErrInvalidFormat, 得到 %v", err)
+	}
+
+	pptxFile := filepath.Join(dir, "a.pptx")
+	writeZipFile(t, pptxFile, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("第一页"),
+		"ppt/slides/slide2.xml": pptxSlideXML("第二页"),
+	})
+	reader := &PptxReader{}
+	data, err = reader.GetSlideXML(pptxFile, 1)
+	if err != nil || string(data) != pptxSlideXML("第二页") {
+		t.Errorf("期望返回第二张幻灯片的原始内容, 得到 %q, %v", data, err)
+	}
+	for _, index := range []int{-1, 2} {
+		if _, err := reader.GetSlideXML(pptxFile, index); !errors.Is(err, ErrPageNotFound) {
+			t.Errorf("索引 %d 期望 ErrPageNotFound, 得到 %v", index, err)
+		}
+	}
+	if _, err := reader.GetSlideXML(filepath.Join(dir, "missing.pptx"), 0); !errors.Is(err, ErrFileOpen) {
+		t.Errorf("期望 ErrFileOpen, 得到 %v", err)
+	}
+}
+
+// TestEmptyResultContract 固定结构化数据方法的约定：没有内容时返回非 nil 的空切片，指定的目标不存在时返回错误
+func TestEmptyResultContract(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("CSV 空文件", func(t *testing.T) {
+		path := filepath.Join(dir, "empty.csv")
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatalf("创建测试文件失败: %v", err)
+		}
+		records, err := (&CsvReader{}).GetRecords(path)
+		if err != nil || records == nil || len(records) != 0 {
+			t.Errorf("期望非 nil 的空切片和 nil 错误, 得到 %#v, %v", records, err)
+		}
+	})
+
+	t.Run("PPTX 没有幻灯片", func(t *testing.T) {
+		path := filepath.Join(dir, "empty.pptx")
+		writeZipFile(t, path, map[string]string{"ppt/presentation.xml": `<p:presentation xmlns:p="p"/>`})
+		reader := &PptxReader{}
+		slides, err := reader.GetSlides(path)
+		if err != nil || slides == nil || len(slides) != 0 {
+			t.Errorf("期望非 nil 的空切片和 nil 错误, 得到 %#v, %v", slides, err)
+		}
+		altTexts, err := reader.GetAltTexts(path)
+		if err != nil || altTexts == nil || len(altTexts) != 0 {
+			t.Errorf("期望非 nil 的空切片和 nil 错误, 得到 %#v, %v", altTexts, err)
+		}
+		if _, err := reader.GetSlideXML(path, 0); !errors.Is(err, ErrPageNotFound) {
+			t.Errorf("期望 ErrPageNotFound, 得到 %v", err)
+		}
+	})
+
+	t.Run("XLSX 空工作表和不存在的工作表", func(t *testing.T) {
+		path := filepath.Join(dir, "empty.xlsx")
+		writeXlsxFile(t, path, map[string][][]any{"Sheet1": {}})
+		reader := &XlsxReader{}
+		rows, err := reader.GetSheetData(path, "Sheet1")
+		if err != nil || rows == nil || len(rows) != 0 {
+			t.Errorf("期望非 nil 的空切片和 nil 错误, 得到 %#v, %v", rows, err)
+		}
+		rows, err = reader.GetSheetData(path, "Missing")
+		if !errors.Is(err, ErrSheetNotFound) || rows != nil {
+			t.Errorf("期望 ErrSheetNotFound, 得到 %#v, %v", rows, err)
+		}
+	})
+}
+
+// TestIworkReaders 测试通过预览 PDF 读取 iWork 文档
+func TestIworkReaders(t *testing.T) {
+	dir := t.TempDir()
+
+	pdfPath := filepath.Join(dir, "preview.pdf")
+	content := "BT /F1 12 Tf 72 700 Td (Quarterly plan) Tj ET"
+	writePdfFile(t, pdfPath, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	)
+	preview, err := os.ReadFile(pdfPath)
+	if err != nil {
+		t.Fatalf("读取 PDF 失败: %v", err)
+	}
+
+	files := map[string]string{
+		"plan.pages":   "preview.pdf",
+		"plan.numbers": "QuickLook/Preview.pdf",
+		"plan.key":     "preview.pdf",
+	}
+	for name, previewName := range files {
+		path := filepath.Join(dir, name)
+		writeZipFile(t, path, map[string]string{
+			"Index/Document.iwa": "\x00binary",
+			previewName:          string(preview),
+		})
+
+		doc, err := ReadDocument(path)
+		if err != nil {
+			t.Fatalf("%s: 读取失败: %v", name, err)
+		}
+		if !strings.Contains(doc.Content, "Quarterly plan") {
+			t.Errorf("%s: 期望包含预览 PDF 的文本, 得到 %q", name, doc.Content)
+		}
+		info, _ := os.Stat(path)
+		if doc.Metadata["size"] != strconv.FormatInt(info.Size(), 10) || doc.Metadata["pages"] != "1" {
+			t.Errorf("%s: 元数据不符合预期: %v", name, doc.Metadata)
+		}
+
+		raw, err := ReadDocumentRaw(path)
+		if err != nil || !strings.Contains(raw, "Quarterly plan") {
+			t.Errorf("%s: ReadDocumentRaw 期望包含预览文本, 得到 %q, %v", name, raw, err)
+		}
+
+		// ReadMetadata 读取预览 PDF 的文档信息，与 GetMetadata 一致
+		catalog, err := ReadMetadata(path)
+		if err != nil {
+			t.Fatalf("%s: 读取元数据失败: %v", name, err)
+		}
+		for _, key := range []string{"pages", "section_count", "size", "modified"} {
+			if catalog[key] != doc.Metadata[key] {
+				t.Errorf("%s: ReadMetadata 的 %s 期望 %q，实际 %q", name, key, doc.Metadata[key], catalog[key])
+			}
+		}
+
+		// 预览 PDF 的大小受配置的解压上限限制
+		if _, err := ReadDocumentWithConfig(path, NewReadConfig().WithMaxDecompressedSize(16)); !errors.Is(err, ErrFileTooLarge) {
+			t.Errorf("%s: 期望 ErrFileTooLarge, 得到 %v", name, err)
+		}
+	}
+
+	noPreview := filepath.Join(dir, "photo.pages")
+	writeZipFile(t, noPreview, map[string]string{"preview.jpg": "jpeg"})
+	_, err = ReadDocument(noPreview)
+	if !errors.Is(err, ErrInvalidFormat) || !strings.Contains(err.Error(), noPreview) {
+		t.Errorf("没有预览 PDF 时期望 ErrInvalidFormat, 得到 %v", err)
+	}
+
+	brokenPreview := filepath.Join(dir, "broken.key")
+	writeZipFile(t, brokenPreview, map[string]string{"preview.pdf": "not a pdf"})
+	_, err = (&KeynoteReader{}).ReadWithConfig(brokenPreview, nil)
+	var docErr *DocumentError
+	if err == nil || !errors.As(err, &docErr) || docErr.FilePath != brokenPreview {
+		t.Errorf("错误中的路径应为原始文件, 得到 %v", err)
+	}
+}
+
+func TestPdfPageLabels(t *testing.T) {
+	dir := t.TempDir()
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R /PageLabels << /Kids [9 0 R << /Nums [4 << /P (A-) /S /D /St 7 >>] >>] >> >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R 5 0 R 6 0 R 7 0 R] /Count 5 /MediaBox [0 0 612 792] /Resources << /Font << /F1 8 0 R >> >> >>",
+	}
+	for i := 0; i < 5; i++ {
+		objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /Contents %d 0 R >>", 10+i))
+	}
+	objects = append(objects,
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Nums [0 << /S /r >> 2 << /S /D >>] >>",
+	)
+	for i := 0; i < 5; i++ {
+		content := fmt.Sprintf("BT /F1 12 Tf 72 700 Td (physical %d) Tj ET", i)
+		objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+	testFile := filepath.Join(dir, "labels.pdf")
+	writePdfFile(t, testFile, "", objects...)
+
+	reader := &PdfReader{}
+	labels, err := reader.GetPageLabels(testFile)
+	if err != nil {
+		t.Fatalf("获取页码标签失败: %v", err)
+	}
+	expected := []string{"i", "ii", "1", "2", "A-7"}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Errorf("期望 %q，实际 %q", expected, labels)
+	}
+
+	result, err := reader.ReadWithConfig(testFile, NewReadConfig().WithPageLabels("2", "ii").WithPages(4))
+	if err != nil {
+		t.Fatalf("按页码标签读取失败: %v", err)
+	}
+	pages := make([]int, 0)
+	for _, page := range result.Pages {
+		pages = append(pages, page.PageNumber)
+	}
+	if !reflect.DeepEqual(pages, []int{1, 3, 4}) {
+		t.Errorf("期望读取第 [1 3 4] 页，实际 %v", pages)
+	}
+	if len(result.Pages) == 3 && !strings.Contains(strings.Join(result.Pages[0].Lines, ""), "physical 1") {
+		t.Errorf("标签 ii 期望对应第二页，实际 %q", result.Pages[0].Lines)
+	}
+
+	if _, err := reader.ReadWithConfig(testFile, NewReadConfig().WithPageLabels("iv")); !errors.Is(err, ErrPageNotFound) {
+		t.Errorf("未匹配的标签期望 ErrPageNotFound，实际: %v", err)
+	}
+
+	plainFile := filepath.Join(dir, "plain.pdf")
+	writePdfFile(t, plainFile, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /MediaBox [0 0 612 792] >>",
+		"<< /Type /Page /Parent 2 0 R >>",
+		"<< /Type /Page /Parent 2 0 R >>",
+	)
+	labels, err = reader.GetPageLabels(plainFile)
+	if err != nil || !reflect.DeepEqual(labels, []string{"1", "2"}) {
+		t.Errorf("没有页码标签时期望 [1 2]，实际 %q（%v）", labels, err)
+	}
+
+	formats := map[string]string{"R:1994": "MCMXCIV", "r:4": "iv", "A:1": "A", "A:28": "BB", "a:53": "aaa", "D:12": "12", ":3": ""}
+	for input, want := range formats {
+		style, number, _ := strings.Cut(input, ":")
+		n, _ := strconv.Atoi(number)
+		if got := formatPdfPageNumber(style, n); got != want {
+			t.Errorf("样式 %q 的 %d 期望 %q，实际 %q", style, n, want, got)
+		}
+	}
+}
+
+// TestPdfPageLabelLimits 测试重复引用同一节点的数字树不会导致指数级遍历，过大的页码使用阿拉伯数字
+func TestPdfPageLabelLimits(t *testing.T) {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R /PageLabels 5 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /MediaBox [0 0 612 792] >>",
+		"<< /Type /Page /Parent 2 0 R >>",
+		"<< /Type /Page /Parent 2 0 R >>",
+	}
+	// 每个节点的 /Kids 两次引用下一个节点，逐个访问需要 2^30 次
+	for i := 0; i < 30; i++ {
+		objects = append(objects, fmt.Sprintf("<< /Kids [%d 0 R %d 0 R] >>", 6+i, 6+i))
+	}
+	objects = append(objects, "<< /Nums [0 << /S /R /St 5000 >> 1 << /S /a /St 999999999999 >>] >>")
+
+	testFile := filepath.Join(t.TempDir(), "labels.pdf")
+	writePdfFile(t, testFile, "", objects...)
+
+	labels, err := (&PdfReader{}).GetPageLabels(testFile)
+	if err != nil {
+		t.Fatalf("获取页码标签失败: %v", err)
+	}
+	expected := []string{"5000", "1073741824"}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Errorf("期望 %q，实际 %q", expected, labels)
+	}
+}
+
+func TestDocxGetDataBindings(t *testing.T) {
+	binding := func(tag, xpath, storeItemID, shown string) string {
+		tagXML := ""
+		if tag != "" {
+			tagXML = `<w:tag w:val="` + tag + `"/>`
+		}
+		return `<w:sdt><w:sdtPr>` + tagXML +
+			`<w:dataBinding w:prefixMappings="xmlns:ns0='urn:invoice' xmlns:ns1=&quot;urn:other&quot;" w:xpath="` + xpath + `" w:storeItemID="` + storeItemID + `"/>` +
+			`</w:sdtPr><w:sdtContent><w:p><w:r><w:t>` + shown + `</w:t></w:r></w:p></w:sdtContent></w:sdt>`
+	}
+
+	testFile := filepath.Join(t.TempDir(), "bound.docx")
+	writeZipFile(t, testFile, map[string]string{
+		"word/document.xml": docxDocumentXML(
+			binding("customer", "/ns0:invoice[1]/ns0:customer[1]/ns0:name[1]", "{5B1C-0002}", "旧值") +
+				binding("", "/ns0:invoice[1]/ns0:items[1]/ns0:item[2]/@sku", "{5b1c-0001}", "") +
+				binding("second", "/ns0:invoice[1]/ns0:items[1]/ns0:item[2]", "{5B1C-0001}", "") +
+				binding("other", "/ns1:invoice[1]/ns1:items[1]", "", "") +
+				binding("missing", "/ns0:invoice[1]/ns0:total[1]", "{5B1C-0001}", "100") +
+				binding("unsupported", "//ns0:item", "{5B1C-0001}", "") +
+				`<w:sdt><w:sdtPr><w:tag w:val="plain"/></w:sdtPr><w:sdtContent><w:p><w:r><w:t>无绑定</w:t></w:r></w:p></w:sdtContent></w:sdt>`),
+		"customXml/item1.xml": `<?xml version="1.0" encoding="UTF-8"?>` +
+			`<invoice xmlns="urn:invoice"><customer><name>张三</name></customer>` +
+			`<items><item sku="A1">苹果</item><item sku="B2">香蕉</item></items></invoice>`,
+		"customXml/itemProps1.xml": `<ds:datastoreItem ds:itemID="{5B1C-0001}" xmlns:ds="http://schemas.openxmlformats.org/officeDocument/2006/customXml"/>`,
+		"customXml/item2.xml":      `<invoice xmlns="urn:invoice"><customer><name>李四</name></customer></invoice>`,
+		"customXml/itemProps2.xml": `<ds:datastoreItem ds:itemID="{5B1C-0002}" xmlns:ds="http://schemas.openxmlformats.org/officeDocument/2006/customXml"/>`,
+	})
+
+	values, err := (&DocxReader{}).GetDataBindings(testFile)
+	if err != nil {
+		t.Fatalf("读取数据绑定失败: %v", err)
+	}
+	expected := map[string]string{
+		"customer": "李四",
+		"/ns0:invoice[1]/ns0:items[1]/ns0:item[2]/@sku": "B2",
+		"second": "香蕉",
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("期望 %q，实际 %q", expected, values)
+	}
+
+	plainFile := filepath.Join(t.TempDir(), "plain.docx")
+	writeZipFile(t, plainFile, map[string]string{
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>正文</w:t></w:r></w:p>`),
+	})
+	values, err = (&DocxReader{}).GetDataBindings(plainFile)
+	if err != nil || values == nil || len(values) != 0 {
+		t.Errorf("没有数据绑定时期望空 map，实际 %v（%v）", values, err)
+	}
+
+	if _, err := (&DocxReader{}).GetDataBindings(testFile + ".missing"); !errors.Is(err, ErrFileOpen) {
+		t.Errorf("期望 ErrFileOpen，实际: %v", err)
+	}
+}
+
+func TestReadConfigMetadataOnly(t *testing.T) {
+	dir := t.TempDir()
+
+	pdfFile := filepath.Join(dir, "info.pdf")
+	content := "BT /F1 12 Tf 72 700 Td (body text) Tj ET"
+	writePdfFile(t, pdfFile, "/Info 6 0 R",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /MediaBox [0 0 612 792] /Resources << /Font << /F1 5 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Title (Report) >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	)
+
+	pptxFile := filepath.Join(dir, "deck.pptx")
+	writeZipFile(t, pptxFile, map[string]string{
+		"ppt/slides/slide1.xml": pptxSlideXML("第一张"),
+		"ppt/slides/slide2.xml": "<p:sld", // 损坏的幻灯片不会被解析
+	})
+
+	xlsxFile := filepath.Join(dir, "book.xlsx")
+	writeXlsxFile(t, xlsxFile, map[string][][]any{
+		"Sheet1": {{"a", "b"}},
+		"Sheet2": {{"c"}},
+	})
+
+	docxFile := filepath.Join(dir, "doc.docx")
+	writeZipFile(t, docxFile, map[string]string{
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>第一页</w:t><w:br w:type="page"/><w:t>第二页</w:t></w:r></w:p>`),
+	})
+
+	csvFile := filepath.Join(dir, "data.csv")
+	txtFile := filepath.Join(dir, "notes.txt")
+	os.WriteFile(csvFile, []byte("a,b\n1,2\n"), 0644)
+	os.WriteFile(txtFile, []byte("line1\nline2\n"), 0644)
+
+	tests := []struct {
+		path       string
+		totalPages int
+		key        string
+	}{
+		{pdfFile, 2, "title"},
+		{pptxFile, 2, "slide_count"},
+		{xlsxFile, 2, "sheet_count"},
+		{docxFile, 2, "section_count"},
+		{csvFile, 1, "rows"},
+		{txtFile, 1, "size"},
+	}
+
+	for _, tt := range tests {
+		result, err := ReadDocumentWithConfig(tt.path, NewReadConfig().WithMetadataOnly(true).WithPages(0))
+		if err != nil {
+			t.Errorf("%s: 只读取元数据失败: %v", tt.path, err)
+			continue
+		}
+		if result.Pages != nil || result.Content != "" || result.TotalLines != 0 {
+			t.Errorf("%s: 期望没有内容，实际 Pages=%v Content=%q", tt.path, result.Pages, result.Content)
+		}
+		if result.TotalPages != tt.totalPages {
+			t.Errorf("%s: 期望 TotalPages 为 %d，实际 %d", tt.path, tt.totalPages, result.TotalPages)
+		}
+		if result.Metadata[tt.key] == "" {
+			t.Errorf("%s: 期望元数据包含 %s，实际 %v", tt.path, tt.key, result.Metadata)
+		}
+	}
+
+	metadata, _ := (&PdfReader{}).ReadWithConfig(pdfFile, NewReadConfig().WithMetadataOnly(true))
+	if _, ok := metadata.Metadata["has_text_layer"]; ok {
+		t.Errorf("只读取元数据时不应提取文本，实际元数据 %v", metadata.Metadata)
+	}
+
+	if _, err := (&CsvReader{}).ReadWithConfig(csvFile+".missing", NewReadConfig().WithMetadataOnly(true)); !errors.Is(err, ErrFileOpen) {
+		t.Errorf("期望 ErrFileOpen，实际: %v", err)
+	}
+}
+
+func TestPptxIncludeMasterText(t *testing.T) {
+	shape := func(placeholderType, text string) string {
+		nvPr := `<p:nvPr/>`
+		if placeholderType != "" {
+			nvPr = `<p:nvPr><p:ph type="` + placeholderType + `"/></p:nvPr>`
+		}
+		body := `<p:txBody><a:p/></p:txBody>`
+		if text != "" {
+			body = `<p:txBody><a:p><a:r><a:t>` + text + `</a:t></a:r></a:p></p:txBody>`
+		}
+		return `<p:sp><p:nvSpPr><p:cNvPr id="1" name="s"/><p:cNvSpPr/>` + nvPr + `</p:nvSpPr>` + body + `</p:sp>`
+	}
+	part := func(root, attrs string, shapes ...string) string {
+		return `<p:` + root + ` xmlns:p="p" xmlns:a="a"` + attrs + `><p:cSld><p:spTree>` + strings.Join(shapes, "") + `</p:spTree></p:cSld></p:` + root + `>`
+	}
+	rels := func(relType, target string) string {
+		return `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/` + relType + `" Target="` + target + `"/></Relationships>`
+	}
+
+	testFile := filepath.Join(t.TempDir(), "master.pptx")
+	writeZipFile(t, testFile, map[string]string{
+		"ppt/slides/slide1.xml":                        part("sld", "", shape("title", "Q3 Results"), shape("dt", ""), shape("ftr", "")),
+		"ppt/slides/_rels/slide1.xml.rels":             rels("slideLayout", "../slideLayouts/slideLayout1.xml"),
+		"ppt/slides/slide2.xml":                        part("sld", ` showMasterSp="0"`, shape("title", "Second"), shape("ftr", "")),
+		"ppt/slides/_rels/slide2.xml.rels":             rels("slideLayout", "../slideLayouts/slideLayout1.xml"),
+		"ppt/slideLayouts/slideLayout1.xml":            part("sldLayout", "", shape("title", "Click to edit title"), shape("dt", "2024-01-01"), shape("ftr", ""), shape("", "Layout banner")),
+		"ppt/slideLayouts/_rels/slideLayout1.xml.rels": rels("slideMaster", "/ppt/slideMasters/slideMaster1.xml"),
+		"ppt/slideMasters/slideMaster1.xml":            part("sldMaster", "", shape("title", "Click to edit master"), shape("ftr", "Confidential footer"), shape("", "CONFIDENTIAL")),
+	})
+
+	result, err := (&PptxReader{}).ReadWithConfig(testFile, NewReadConfig().WithIncludeMasterText(true))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	expected := [][]string{
+		{"Q3 Results", "2024-01-01", "Confidential footer", "CONFIDENTIAL", "Layout banner"},
+		{"Second", "Confidential footer"},
+	}
+	if len(result.Pages) != len(expected) {
+		t.Fatalf("期望 %d 张幻灯片，实际 %d", len(expected), len(result.Pages))
+	}
+	for i, page := range result.Pages {
+		if !reflect.DeepEqual(page.Lines, expected[i]) {
+			t.Errorf("幻灯片 %d: 期望 %q，实际 %q", i, expected[i], page.Lines)
+		}
+	}
+
+	result, err = (&PptxReader{}).ReadWithConfig(testFile, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if !reflect.DeepEqual(result.Pages[0].Lines, []string{"Q3 Results"}) {
+		t.Errorf("默认不合并母版文本，实际 %q", result.Pages[0].Lines)
+	}
+}
+
+func TestDropConsecutiveDuplicateLines(t *testing.T) {
+	dir := t.TempDir()
+	testFile := filepath.Join(dir, "doubled.txt")
+	if err := os.WriteFile(testFile, []byte("标题\n标题\n正文\n  正文  \n\n正文\n标题\n标题\n标题"), 0644); err != nil {
+		t.Fatalf("创建测试文件失败: %v", err)
+	}
+
+	config := NewReadConfig().WithDropConsecutiveDuplicateLines(true).WithLineNumbers(true)
+	result, err := ReadDocumentWithConfig(testFile, config)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	page := result.Pages[0]
+	expectedLines := []string{"标题", "正文", "  正文  ", "", "正文", "标题"}
+	if !reflect.DeepEqual(page.Lines, expectedLines) || !reflect.DeepEqual(page.LineNumbers, []int{0, 2, 3, 4, 5, 6}) {
+		t.Errorf("期望 %q %v，实际 %q %v", expectedLines, []int{0, 2, 3, 4, 5, 6}, page.Lines, page.LineNumbers)
+	}
+	if result.TotalLines != len(expectedLines) {
+		t.Errorf("期望 TotalLines 为 %d，实际 %d", len(expectedLines), result.TotalLines)
+	}
+
+	// 先去除空白和空行，再移除重复的行
+	result, err = ReadDocumentWithConfig(testFile, config.WithTrimLines(true).WithDropEmptyLines(true))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if expected := []string{"标题", "正文", "标题"}; !reflect.DeepEqual(result.Pages[0].Lines, expected) {
+		t.Errorf("期望 %q，实际 %q", expected, result.Pages[0].Lines)
+	}
+
+	// 流式读取使用相同的规则
+	result, err = (&TxtReader{StreamThreshold: -1}).ReadWithConfig(testFile, NewReadConfig().WithDropConsecutiveDuplicateLines(true))
+	if err != nil {
+		t.Fatalf("流式读取失败: %v", err)
+	}
+	if !reflect.DeepEqual(result.Pages[0].Lines, expectedLines) {
+		t.Errorf("流式读取期望 %q，实际 %q", expectedLines, result.Pages[0].Lines)
+	}
+}
+
+func TestPdfHeaderMetadata(t *testing.T) {
+	dir := t.TempDir()
+
+	linearizedFile := filepath.Join(dir, "web.pdf")
+	writePdfFile(t, linearizedFile, "/Root 2 0 R",
+		"<< /Linearized 1 /L 1000 /O 4 /E 500 /N 1 /T 900 /H [0 0] >>",
+		"<< /Type /Catalog /Pages 3 0 R /PageMode /UseOutlines >>",
+		"<< /Type /Pages /Kids [4 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 3 0 R /MediaBox [0 0 612 792] >>",
+	)
+
+	plainFile := filepath.Join(dir, "plain.pdf")
+	writePdfFile(t, plainFile, "",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>",
+	)
+
+	tests := []struct {
+		path     string
+		expected map[string]string
+	}{
+		{linearizedFile, map[string]string{"pdf_version": "1.4", "linearized": "true", "encrypted": "false", "page_mode": "UseOutlines"}},
+		{plainFile, map[string]string{"pdf_version": "1.4", "linearized": "false", "encrypted": "false", "page_mode": "UseNone"}},
+	}
+	for _, tt := range tests {
+		metadata, err := (&PdfReader{}).GetMetadata(tt.path)
+		if err != nil {
+			t.Fatalf("%s: 获取元数据失败: %v", tt.path, err)
+		}
+		for key, want := range tt.expected {
+			if metadata[key] != want {
+				t.Errorf("%s: 期望 %s 为 %q，实际 %q", filepath.Base(tt.path), key, want, metadata[key])
+			}
+		}
+
+		// ReadMetadata 同样读取文件头
+		catalog, err := ReadMetadata(tt.path)
+		if err != nil || catalog["linearized"] != tt.expected["linearized"] || catalog["pdf_version"] != "1.4" {
+			t.Errorf("%s: ReadMetadata 期望包含文件头信息，实际 %v（%v）", filepath.Base(tt.path), catalog, err)
+		}
+	}
+}
+
+func TestDocumentResultStreamJSON(t *testing.T) {
+	result := &DocumentResult{
+		Pages: []PageContent{
+			{PageNumber: 0, Lines: []string{"第一页", `带 "引号"`}, TotalLines: 2},
+			{PageNumber: 3, PageName: "Sheet2", Lines: []string{"x"}, LineNumbers: []int{5}, TotalLines: 1},
+		},
+		TotalPages: 4,
+	}
+
+	var buf bytes.Buffer
+	if err := result.StreamJSON(&buf); err != nil {
+		t.Fatalf("输出 JSON 失败: %v", err)
+	}
+	output := buf.String()
+	if !strings.HasPrefix(output, "[\n{") || !strings.Contains(output, "},\n{") || !strings.HasSuffix(output, "}\n]\n") {
+		t.Errorf("期望每页一行的 JSON 数组，实际 %q", output)
+	}
+
+	var pages []PageContent
+	if err := json.Unmarshal(buf.Bytes(), &pages); err != nil {
+		t.Fatalf("解析输出失败: %v", err)
+	}
+	if !reflect.DeepEqual(pages, result.Pages) {
+		t.Errorf("期望 %+v，实际 %+v", result.Pages, pages)
+	}
+
+	buf.Reset()
+	if err := (&DocumentResult{}).StreamJSON(&buf); err != nil || buf.String() != "[]\n" {
+		t.Errorf("没有页面时期望 \"[]\\n\"，实际 %q（%v）", buf.String(), err)
+	}
+
+	writeErr := errors.New("disk full")
+	writer := &failingWriter{remaining: 10, err: writeErr}
+	if err := result.StreamJSON(writer); !errors.Is(err, writeErr) {
+		t.Errorf("期望写入错误，实际: %v", err)
+	}
+}
+
+func TestDocumentResultFilter(t *testing.T) {
+	var body strings.Builder
+	for page := 0; page < 3; page++ {
+		if page > 0 {
+			body.WriteString(`<w:p><w:r><w:br w:type="page"/></w:r></w:p>`)
+		}
+		for line := 0; line < 3; line++ {
+			fmt.Fprintf(&body, `<w:p><w:r><w:t>  p%d-l%d  </w:t></w:r></w:p>`, page, line)
+		}
+	}
+	testFile := filepath.Join(t.TempDir(), "pages.docx")
+	writeZipFile(t, testFile, map[string]string{"word/document.xml": docxDocumentXML(body.String())})
+
+	full, err := ReadDocumentWithConfig(testFile, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if full.TotalPages != 3 {
+		t.Fatalf("期望 3 页，实际 %d", full.TotalPages)
+	}
+	original := fmt.Sprint(full.Pages)
+
+	// 与直接按相同配置读取的结果一致
+	configs := []*ReadConfig{
+		NewReadConfig().WithPages(0, 2).WithLines(1),
+		NewReadConfig().WithPageRange(1, 2).WithTrimLines(true).WithLineNumbers(true),
+		NewReadConfig().AddPageConfig(1, []int{0, 2}, nil),
+		nil,
+	}
+	for _, config := range configs {
+		expected, err := ReadDocumentWithConfig(testFile, config)
+		if err != nil {
+			t.Fatalf("读取失败: %v", err)
+		}
+		filtered := full.Filter(config)
+		if fmt.Sprint(filtered.Pages) != fmt.Sprint(expected.Pages) || filtered.Content != expected.Content ||
+			filtered.TotalLines != expected.TotalLines || filtered.TotalPages != expected.TotalPages {
+			t.Errorf("配置 %+v: 期望 %+v，实际 %+v", config, expected.Pages, filtered.Pages)
+		}
+	}
+	if fmt.Sprint(full.Pages) != original {
+		t.Errorf("Filter 不应修改原结果，实际 %+v", full.Pages)
+	}
+
+	// 再次筛选时保留读取文件时的原始行号
+	partial, err := ReadDocumentWithConfig(testFile, NewReadConfig().WithLineRange(1, 2).WithLineNumbers(true))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	filtered := partial.Filter(NewReadConfig().WithPages(2).WithLines(1))
+	if len(filtered.Pages) != 1 || !reflect.DeepEqual(filtered.Pages[0].LineNumbers, []int{2}) ||
+		!reflect.DeepEqual(filtered.Pages[0].Lines, []string{"  p2-l2  "}) {
+		t.Errorf("期望第 2 页原始行号 [2]，实际 %+v", filtered.Pages)
+	}
+}
+
+func TestZipReader(t *testing.T) {
+	dir := t.TempDir()
+
+	docxFile := filepath.Join(dir, "inner.docx")
+	writeZipFile(t, docxFile, map[string]string{
+		"word/document.xml": docxDocumentXML(`<w:p><w:r><w:t>一</w:t><w:br w:type="page"/><w:t>二</w:t></w:r></w:p>`),
+	})
+	docxData, err := os.ReadFile(docxFile)
+	if err != nil {
+		t.Fatalf("读取测试文件失败: %v", err)
+	}
+
+	zipFile := filepath.Join(dir, "bundle.zip")
+	writeZipFile(t, zipFile, map[string]string{
+		"__MACOSX/._a.txt": "resource fork",
+		"a.txt":            "hello\nworld",
+		"b/c.csv":          "x,y\n1,2",
+		"d.docx":           string(docxData),
+		"e.bin":            "binary",
+		"f.docx":           "not a zip",
+		"g/":               "",
+	})
+
+	result, err := ReadDocumentWithConfig(zipFile, NewReadConfig())
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+
+	names := make([]string, 0, len(result.Pages))
+	for _, page := range result.Pages {
+		names = append(names, page.PageName)
+	}
+	if !reflect.DeepEqual(names, []string{"a.txt", "b/c.csv", "d.docx", "f.docx"}) {
+		t.Fatalf("期望每个受支持的文件一页，实际 %v", names)
+	}
+	if result.TotalPages != 4 || result.Metadata["file_count"] != "4" || result.Metadata["section_count"] != "4" {
+		t.Errorf("期望 4 个文件，实际 TotalPages=%d metadata=%v", result.TotalPages, result.Metadata)
+	}
+	if !reflect.DeepEqual(result.Pages[0].Lines, []string{"hello", "world"}) {
+		t.Errorf("a.txt 内容错误: %q", result.Pages[0].Lines)
+	}
+	if !reflect.DeepEqual(result.Pages[1].Lines, []string{"Row 1: x | y", "Row 2: 1 | 2"}) {
+		t.Errorf("b/c.csv 内容错误: %q", result.Pages[1].Lines)
+	}
+	if !reflect.DeepEqual(result.Pages[2].Lines, []string{"一", "二"}) {
+		t.Errorf("d.docx 的所有页应合并为一页: %q", result.Pages[2].Lines)
+	}
+	if len(result.Warnings) != 1 || !strings.HasPrefix(result.Warnings[0], "f.docx: ") {
+		t.Errorf("损坏的文件应记录为警告，实际 %v", result.Warnings)
+	}
+	if !strings.Contains(result.Content, "=== 文件: b/c.csv ===") {
+		t.Errorf("Content 应包含文件标题: %q", result.Content)
+	}
+
+	// 页选择器按文件顺序选择
+	selected, err := ReadDocumentWithConfig(zipFile, NewReadConfig().WithPages(2))
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(selected.Pages) != 1 || selected.Pages[0].PageName != "d.docx" || selected.Pages[0].PageNumber != 2 {
+		t.Errorf("期望只读取 d.docx，实际 %+v", selected.Pages)
+	}
+
+	if _, err := ReadDocumentWithConfig(zipFile, NewReadConfig().WithFailOnPartialError(true)); !errors.Is(err, ErrFileParse) {
+		t.Errorf("期望 ErrFileParse，实际 %v", err)
+	}
+
+	metadata, err := ReadMetadata(zipFile)
+	if err != nil {
+		t.Fatalf("读取元数据失败: %v", err)
+	}
+	if metadata["files"] != "a.txt, b/c.csv, d.docx, f.docx" {
+		t.Errorf("files 元数据错误: %q", metadata["files"])
+	}
+
+	raw, err := ReadDocumentRaw(zipFile)
+	if err != nil {
+		t.Fatalf("ReadDocumentRaw 失败: %v", err)
+	}
+	if !strings.Contains(raw, "hello\nworld") || !strings.Contains(raw, "二") {
+		t.Errorf("纯文本缺少文件内容: %q", raw)
+	}
+
+	// 每个文件都不超过解压上限，但合计超过时整个压缩包视为过大
+	bigFile := filepath.Join(dir, "big.zip")
+	writeZipFile(t, bigFile, map[string]string{
+		"a.txt": strings.Repeat("a", 600),
+		"b.txt": strings.Repeat("b", 600),
+	})
+	if _, err := ReadDocumentWithConfig(bigFile, NewReadConfig().WithMaxDecompressedSize(1024)); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("期望 ErrFileTooLarge，实际 %v", err)
+	}
+	if _, err := ReadDocumentWithConfig(bigFile, NewReadConfig().WithMaxDecompressedSize(1200)); err != nil {
+		t.Errorf("总大小未超过上限时不应出错: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package docreader
+
+import (
+	"time"
+	"unicode"
+)
+
+const (
+	// DefaultWordsPerMinute 估算阅读时间时默认的西文阅读速度（每分钟单词数）
+	DefaultWordsPerMinute = 200
+
+	// DefaultCharactersPerMinute 估算阅读时间时默认的中日文阅读速度（每分钟字数）
+	DefaultCharactersPerMinute = 500
+)
+
+// ReadingTime 估算阅读文档内容所需的时间，结果精确到秒
+// 中日文没有空格分隔单词，汉字和假名按字数计算，其他文字按单词计算（连续的字母或数字为一个单词，韩文也使用空格分词）。
+// wordsPerMinute <= 0 时使用默认的每分钟 200 个单词和 500 个字；指定时按同样的比例（每分钟 2.5 倍的字数）计算中日文的速度
+func (d *Document) ReadingTime(wordsPerMinute int) time.Duration {
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = DefaultWordsPerMinute
+	}
+	charactersPerMinute := float64(wordsPerMinute) * DefaultCharactersPerMinute / DefaultWordsPerMinute
+
+	words, characters := countReadingUnits(d.Content)
+	minutes := float64(words)/float64(wordsPerMinute) + float64(characters)/charactersPerMinute
+	return time.Duration(minutes * float64(time.Minute)).Round(time.Second)
+}
+
+// countReadingUnits 统计文本中的西文单词数和按字阅读的中日文字数
+func countReadingUnits(text string) (words, characters int) {
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isReadByCharacter(r):
+			characters++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			if !inWord {
+				words++
+				inWord = true
+			}
+		case r == '\'' || r == '’' || r == '-':
+			// 单词内的撇号和连字符（如 "don't"、"e-mail"）不分隔单词
+		default:
+			inWord = false
+		}
+	}
+	return words, characters
+}
+
+// isReadByCharacter 判断字符是否按字计算阅读时间（汉字、平假名、片假名）
+func isReadByCharacter(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
@@ -0,0 +1,52 @@
+package docreader
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReadingTime(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		wordsPerMinute int
+		expected       time.Duration
+	}{
+		// 7 个单词，每个 0.3 秒
+		{"英文", "Don't read the e-mail now, 42 times!", 0, 2100 * time.Millisecond},
+		// 6 个汉字，每个 0.12 秒
+		{"中文", "今天天气很好。", 0, 720 * time.Millisecond},
+		// 2 个单词各 1 秒，8 个汉字各 0.4 秒
+		{"中英混合", "使用 Go 语言读取 PDF 文档", 60, 5200 * time.Millisecond},
+		{"韩文按单词", "한국어 테스트 문서", 60, 3 * time.Second},
+		{"空文档", "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &Document{Content: tt.content}
+			if got := doc.ReadingTime(tt.wordsPerMinute); got != tt.expected.Round(time.Second) {
+				t.Errorf("期望 %v，实际 %v", tt.expected.Round(time.Second), got)
+			}
+		})
+	}
+
+	t.Run("长文档", func(t *testing.T) {
+		content := ""
+		for i := 0; i < 1000; i++ {
+			content += "word "
+		}
+		for i := 0; i < 1000; i++ {
+			content += "字"
+		}
+		doc := &Document{Content: content}
+		// 1000 / 200 + 1000 / 500 = 7 分钟
+		if got := doc.ReadingTime(0); got != 7*time.Minute {
+			t.Errorf("期望 7m0s，实际 %v", got)
+		}
+		// 速度加倍时时间减半
+		if got := doc.ReadingTime(400); got != 3*time.Minute+30*time.Second {
+			t.Errorf("期望 3m30s，实际 %v", got)
+		}
+	})
+}
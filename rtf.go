@@ -1,30 +1,61 @@
 package docreader
 
 import (
+	"bytes"
 	"fmt"
-	"os"
-	"regexp"
+	"io"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
 )
 
 // RtfReader 用于读取 .rtf 文件
-type RtfReader struct{}
+type RtfReader struct {
+	// InlineHyperlinks ReadText 是否在超链接的显示文本之后追加 " (URL)"
+	// ReadWithConfig 使用 ReadConfig.InlineHyperlinks
+	InlineHyperlinks bool
+
+	fileSource
+}
+
+// Hyperlink 文档中的超链接
+type Hyperlink struct {
+	// URL 链接目标；指向文档内书签的链接为 "#书签名"
+	URL string
 
-// ReadText 读取 RTF 文件的文本内容（简单提取纯文本）
+	// Text 链接的显示文本
+	Text string
+}
+
+// ReadText 读取 RTF 文件的文本内容，每个段落一行
 func (r *RtfReader) ReadText(filePath string) (string, error) {
+	var builder strings.Builder
+	if err := r.writeText(&builder, filePath); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// writeText 将 RTF 纯文本写入 w
+func (r *RtfReader) writeText(w io.Writer, filePath string) error {
 	// 读取文件内容
-	data, err := os.ReadFile(filePath)
+	data, err := r.readFile(filePath)
 	if err != nil {
-		return "", WrapError("RtfReader.ReadText", filePath, ErrFileRead)
+		return WrapError("RtfReader.ReadText", filePath, ErrFileRead)
 	}
 
-	content := string(data)
-
-	// 简单的 RTF 文本提取
-	// 移除 RTF 控制字符
-	content = removeRtfControls(content)
-
-	return content, nil
+	text, _ := parseRtf(data, r.InlineHyperlinks)
+	_, err = io.WriteString(w, text)
+	return err
 }
 
 // GetMetadata 获取 RTF 文件的元数据
@@ -32,7 +63,7 @@ func (r *RtfReader) GetMetadata(filePath string) (map[string]string, error) {
 	metadata := make(map[string]string)
 
 	// 获取文件信息
-	fileInfo, err := os.Stat(filePath)
+	fileInfo, err := r.stat(filePath)
 	if err != nil {
 		return nil, WrapError("RtfReader.GetMetadata", filePath, ErrFileNotFound)
 	}
@@ -40,40 +71,475 @@ func (r *RtfReader) GetMetadata(filePath string) (map[string]string, error) {
 	metadata["size"] = fmt.Sprintf("%d", fileInfo.Size())
 	metadata["modified"] = fileInfo.ModTime().String()
 
+	// 单页格式只有一个部分
+	metadata["section_count"] = "1"
+
 	return metadata, nil
 }
 
-// removeRtfControls 移除 RTF 控制字符，提取纯文本
-func removeRtfControls(content string) string {
-	// 移除 RTF 头部
-	re := regexp.MustCompile(`\\rtf\d+`)
-	content = re.ReplaceAllString(content, "")
+// GetHyperlinks 获取 RTF 文件中的超链接（HYPERLINK 域），按出现顺序返回
+// 链接位于跳过的内容（如页眉页脚）中时不会返回
+func (r *RtfReader) GetHyperlinks(filePath string) ([]Hyperlink, error) {
+	data, err := r.readFile(filePath)
+	if err != nil {
+		return nil, WrapError("RtfReader.GetHyperlinks", filePath, ErrFileRead)
+	}
+
+	_, links := parseRtf(data, false)
+	return links, nil
+}
+
+// rtfSkipDestinations 不包含正文的目标（destination）组，解析时整个组连同其中的二进制数据一起跳过：
+// 字体表、样式表、文档信息、图片（pict、shppict）、嵌入对象（object）、页眉页脚、主题数据等
+var rtfSkipDestinations = map[string]bool{
+	"fonttbl": true, "colortbl": true, "stylesheet": true, "listtable": true, "listoverridetable": true,
+	"rsidtbl": true, "revtbl": true, "filetbl": true, "info": true, "generator": true, "xmlnstbl": true,
+	"pict": true, "shppict": true, "nonshppict": true, "object": true, "objdata": true,
+	"header": true, "headerl": true, "headerr": true, "headerf": true,
+	"footer": true, "footerl": true, "footerr": true, "footerf": true, "footnote": true,
+	"themedata": true, "colorschememapping": true, "datastore": true, "latentstyles": true,
+	"bkmkstart": true, "bkmkend": true, "xe": true, "tc": true, "pn": true,
+}
+
+// rtfSymbols 直接对应一个字符的控制字
+var rtfSymbols = map[string]string{
+	"par": "\n", "line": "\n", "sect": "\n", "page": "\n", "row": "\n",
+	"tab": "\t", "cell": "\t",
+	"emdash": "—", "endash": "–", "bullet": "•", "emspace": " ", "enspace": " ",
+	"lquote": "‘", "rquote": "’", "ldblquote": "“", "rdblquote": "”",
+}
+
+// rtfState 组内的解析状态，进入组时保存，离开组时恢复
+type rtfState struct {
+	skip      bool // 当前组是否被跳过
+	uc        int  // \u 之后需要跳过的替代字符数（\ucN）
+	ignorable bool // 刚读到 \*，下一个控制字是可忽略的目标
+
+	// field 当前所在的域（\field），instruction 表示当前组是域指令（\fldinst），其文本不输出
+	field       *rtfField
+	instruction bool
+}
+
+// rtfField 解析中的域
+type rtfField struct {
+	// instruction 域指令文本，如 `HYPERLINK "https://example.com"`
+	instruction bytes.Buffer
+
+	// resultStart 域结果（\fldrslt）在输出中的起始位置，hasResult 表示是否有域结果
+	resultStart int
+	hasResult   bool
+}
+
+// rtfParser RTF 解析器：按控制字、控制符号、组和文本逐个处理，输出纯文本
+type rtfParser struct {
+	data  []byte
+	pos   int
+	out   bytes.Buffer
+	state rtfState
+	stack []rtfState
+
+	// decoder 将 \'hh 字节按文档代码页（\ansicpgN）解码，默认为 Windows-1252
+	decoder *encoding.Decoder
+
+	// pending 尚未解码的字节，pendingHex 表示它们来自 \'hh（双字节代码页需要连续解码）
+	pending    []byte
+	pendingHex bool
+
+	// fallback \uN 之后还需跳过的替代字符数，highSurrogate 为等待低位代理项的 UTF-16 高位代理项
+	fallback      int
+	highSurrogate rune
+
+	// inlineLinks 是否在超链接的显示文本之后输出 " (URL)"，links 为解析到的超链接
+	inlineLinks bool
+	links       []Hyperlink
+}
+
+// parseRtf 解析 RTF 内容并返回纯文本，段落（\par）和换行（\line）输出为换行符，表格单元格以制表符分隔
+// 字体表、图片、嵌入对象等目标组以及 \binN 的二进制数据会被完整跳过，不会出现在结果中。
+// \'hh 按 \ansicpg 指定的代码页解码（如 936 为 GBK），\uN 输出对应的 Unicode 字符；
+// 不规范的文件中直接出现的非 ASCII 字节是合法的 UTF-8 时按 UTF-8 处理。
+// 同时返回 HYPERLINK 域中的超链接，inlineLinks 为 true 时在链接的显示文本之后输出 " (URL)"
+func parseRtf(data []byte, inlineLinks bool) (string, []Hyperlink) {
+	p := &rtfParser{
+		data:        data,
+		state:       rtfState{uc: 1},
+		decoder:     charmap.Windows1252.NewDecoder(),
+		inlineLinks: inlineLinks,
+		links:       make([]Hyperlink, 0),
+	}
+	p.parse()
+
+	lines := strings.Split(p.out.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), p.links
+}
+
+// parse 逐字节解析 RTF 内容
+func (p *rtfParser) parse() {
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		switch c {
+		case '{':
+			p.flush()
+			p.stack = append(p.stack, p.state)
+			p.fallback = 0
+			p.pos++
+		case '}':
+			p.flush()
+			if len(p.stack) > 0 {
+				closed := p.state
+				p.state = p.stack[len(p.stack)-1]
+				p.stack = p.stack[:len(p.stack)-1]
+				if closed.field != nil && closed.field != p.state.field {
+					p.endField(closed.field)
+				}
+			}
+			p.fallback = 0
+			p.pos++
+		case '\\':
+			p.pos++
+			p.control()
+		case '\r', '\n':
+			// 原始换行只用于排版 RTF 源文件，不属于文本
+			p.pos++
+		default:
+			p.pos++
+			p.text(c, false)
+		}
+	}
+	p.flush()
+}
+
+// control 处理反斜杠之后的控制字或控制符号
+func (p *rtfParser) control() {
+	if p.pos >= len(p.data) {
+		return
+	}
+
+	c := p.data[p.pos]
+	if !isASCIILetter(c) {
+		p.pos++
+		p.symbol(c)
+		return
+	}
+
+	start := p.pos
+	for p.pos < len(p.data) && isASCIILetter(p.data[p.pos]) {
+		p.pos++
+	}
+	word := string(p.data[start:p.pos])
+
+	paramStart := p.pos
+	if p.pos < len(p.data) && p.data[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '9' {
+		p.pos++
+	}
+	param, hasParam := 0, p.pos > paramStart
+	if hasParam {
+		param, _ = strconv.Atoi(string(p.data[paramStart:p.pos]))
+	}
+	// 控制字之后的一个空格是分隔符，不属于文本
+	if p.pos < len(p.data) && p.data[p.pos] == ' ' {
+		p.pos++
+	}
+
+	p.word(word, param, hasParam)
+}
+
+// symbol 处理控制符号（反斜杠后跟一个非字母字符）
+func (p *rtfParser) symbol(c byte) {
+	switch c {
+	case '\'':
+		if p.pos+2 > len(p.data) {
+			p.pos = len(p.data)
+			return
+		}
+		value, err := strconv.ParseUint(string(p.data[p.pos:p.pos+2]), 16, 8)
+		p.pos += 2
+		if err == nil {
+			p.text(byte(value), true)
+		}
+	case '*':
+		// 可忽略的目标：除域指令之外，本解析器不需要其中的任何内容
+		p.state.ignorable = true
+	case '\\', '{', '}':
+		p.text(c, false)
+	case '~':
+		p.write("\u00a0")
+	case '_':
+		p.write("-")
+	case '\r', '\n':
+		p.write("\n")
+	}
+}
+
+// word 处理控制字
+func (p *rtfParser) word(word string, param int, hasParam bool) {
+	if word == "bin" {
+		// \binN 之后的 N 个字节是原始二进制数据，无论是否在跳过的组中都必须整体跳过
+		p.flush()
+		if param > 0 {
+			p.pos = min(p.pos+param, len(p.data))
+		}
+		return
+	}
+	if p.state.ignorable {
+		p.state.ignorable = false
+		if word != "fldinst" {
+			p.state.skip = true
+		}
+	}
+	if rtfSkipDestinations[word] {
+		p.state.skip = true
+	}
+	if p.state.skip {
+		return
+	}
+
+	switch word {
+	case "field":
+		p.flush()
+		p.state.field = &rtfField{}
+	case "fldinst":
+		// 不在域中的指令没有意义，直接跳过
+		if p.state.field == nil {
+			p.state.skip = true
+			return
+		}
+		p.flush()
+		p.state.instruction = true
+	case "fldrslt":
+		if p.state.field != nil {
+			p.flush()
+			p.state.field.resultStart = p.out.Len()
+			p.state.field.hasResult = true
+		}
+	case "ansicpg":
+		if decoder := rtfCodePageDecoder(param); decoder != nil {
+			p.flush()
+			p.decoder = decoder
+		}
+	case "uc":
+		if hasParam && param >= 0 {
+			p.state.uc = param
+		}
+	case "u":
+		p.unicode(param)
+	default:
+		if symbol, ok := rtfSymbols[word]; ok {
+			p.write(symbol)
+		}
+	}
+}
+
+// unicode 输出 \uN 表示的字符（N 为有符号的 16 位整数），之后的替代字符会被跳过
+func (p *rtfParser) unicode(param int) {
+	if param < 0 {
+		param += 0x10000
+	}
+	r := rune(param)
+
+	switch {
+	case utf16.IsSurrogate(r) && r < 0xdc00:
+		p.flush()
+		p.highSurrogate = r
+	case utf16.IsSurrogate(r):
+		p.write(string(utf16.DecodeRune(p.highSurrogate, r)))
+	default:
+		p.write(string(r))
+	}
+	p.fallback = p.state.uc
+}
+
+// text 处理一个文本字节，hex 表示该字节来自 \'hh
+func (p *rtfParser) text(c byte, hex bool) {
+	if p.state.skip {
+		return
+	}
+	if p.fallback > 0 {
+		p.fallback--
+		return
+	}
+	if len(p.pending) > 0 && p.pendingHex != hex {
+		p.flush()
+	}
+	p.pending = append(p.pending, c)
+	p.pendingHex = hex
+}
+
+// write 输出已解码的文本
+func (p *rtfParser) write(s string) {
+	if p.state.skip {
+		return
+	}
+	p.flush()
+	p.highSurrogate = 0
+	p.target().WriteString(s)
+}
+
+// target 返回当前文本的写入位置：域指令写入所在的域，其他文本写入输出
+func (p *rtfParser) target() *bytes.Buffer {
+	if p.state.instruction && p.state.field != nil {
+		return &p.state.field.instruction
+	}
+	return &p.out
+}
+
+// endField 域结束时记录超链接，需要时在显示文本之后输出 URL
+func (p *rtfParser) endField(field *rtfField) {
+	url, ok := parseHyperlinkInstruction(field.instruction.String())
+	if !ok {
+		return
+	}
 
-	// 移除控制字
-	re = regexp.MustCompile(`\\[a-z]+\d*\s?`)
-	content = re.ReplaceAllString(content, "")
+	text := ""
+	if field.hasResult {
+		text = strings.TrimSpace(p.out.String()[field.resultStart:])
+	}
+	p.links = append(p.links, Hyperlink{URL: url, Text: text})
 
-	// 移除花括号
-	content = strings.ReplaceAll(content, "{", "")
-	content = strings.ReplaceAll(content, "}", "")
+	if p.inlineLinks && text != url {
+		p.write(" (" + url + ")")
+	}
+}
 
-	// 移除多余的空白
-	re = regexp.MustCompile(`\s+`)
-	content = re.ReplaceAllString(content, " ")
+// parseHyperlinkInstruction 解析 HYPERLINK 域指令，返回链接目标
+// 支持 `HYPERLINK "url"` 和指向书签的 `HYPERLINK \l "name"`（返回 "#name"），忽略 \o（提示文字）等开关
+func parseHyperlinkInstruction(instruction string) (string, bool) {
+	tokens := splitFieldInstruction(instruction)
+	if len(tokens) == 0 || !strings.EqualFold(tokens[0], "HYPERLINK") {
+		return "", false
+	}
+
+	url, anchor := "", ""
+	for i := 1; i < len(tokens); i++ {
+		switch tokens[i] {
+		case `\l`:
+			if i+1 < len(tokens) {
+				anchor = tokens[i+1]
+				i++
+			}
+		case `\o`, `\t`:
+			// 开关的参数（提示文字、目标框架）不是链接
+			i++
+		default:
+			if url == "" && !strings.HasPrefix(tokens[i], `\`) {
+				url = tokens[i]
+			}
+		}
+	}
+	if anchor != "" {
+		url += "#" + anchor
+	}
+	return url, url != ""
+}
 
-	return strings.TrimSpace(content)
+// splitFieldInstruction 按空白切分域指令，双引号内的空白不切分，引号本身被去掉
+func splitFieldInstruction(instruction string) []string {
+	tokens := make([]string, 0)
+	var current strings.Builder
+	quoted, hasToken := false, false
+	for _, r := range instruction {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			hasToken = true
+		case unicode.IsSpace(r) && !quoted:
+			if hasToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				hasToken = false
+			}
+		default:
+			current.WriteRune(r)
+			hasToken = true
+		}
+	}
+	if hasToken {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+// flush 解码并输出尚未处理的字节
+func (p *rtfParser) flush() {
+	if len(p.pending) == 0 {
+		return
+	}
+	if !p.pendingHex && utf8.Valid(p.pending) {
+		p.target().Write(p.pending)
+	} else if decoded, err := p.decoder.Bytes(p.pending); err == nil {
+		p.target().Write(decoded)
+	}
+	p.pending = p.pending[:0]
+}
+
+// rtfCodePageDecoder 返回 Windows 代码页对应的解码器，不支持的代码页返回 nil
+func rtfCodePageDecoder(codePage int) *encoding.Decoder {
+	var enc encoding.Encoding
+	switch codePage {
+	case 936:
+		enc = simplifiedchinese.GBK
+	case 950:
+		enc = traditionalchinese.Big5
+	case 932:
+		enc = japanese.ShiftJIS
+	case 949:
+		enc = korean.EUCKR
+	case 874:
+		enc = charmap.Windows874
+	case 1250:
+		enc = charmap.Windows1250
+	case 1251:
+		enc = charmap.Windows1251
+	case 1252:
+		enc = charmap.Windows1252
+	case 1253:
+		enc = charmap.Windows1253
+	case 1254:
+		enc = charmap.Windows1254
+	case 1255:
+		enc = charmap.Windows1255
+	case 1256:
+		enc = charmap.Windows1256
+	case 1257:
+		enc = charmap.Windows1257
+	case 1258:
+		enc = charmap.Windows1258
+	default:
+		return nil
+	}
+	return enc.NewDecoder()
+}
+
+// isASCIILetter 判断字节是否为 ASCII 字母
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// Capabilities 返回 RTF 读取器支持的功能：单页纯文本，没有额外的结构
+func (r *RtfReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{}
 }
 
 // ReadWithConfig 根据配置读取 RTF 文件，返回结构化结果
 func (r *RtfReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
-	data, err := os.ReadFile(filePath)
+	if metadataOnly(config) {
+		metadata, err := r.GetMetadata(filePath)
+		return metadataOnlyResult("RtfReader.ReadWithConfig", filePath, 1, metadata, err)
+	}
+
+	data, err := r.readFile(filePath)
 	if err != nil {
 		return nil, WrapError("RtfReader.ReadWithConfig", filePath, ErrFileRead)
 	}
 
-	content := string(data)
-	content = removeRtfControls(content)
-	lines := strings.Split(content, "\n")
+	text, _ := parseRtf(data, config != nil && config.InlineHyperlinks)
+	lines := strings.Split(text, "\n")
 
 	result := &DocumentResult{
 		FilePath:   filePath,
@@ -87,17 +553,13 @@ func (r *RtfReader) ReadWithConfig(filePath string, config *ReadConfig) (*Docume
 	result.Metadata = metadata
 
 	// 根据配置筛选行
-	filteredLines := filterLinesForSinglePage(lines, config)
-
-	pageContent := PageContent{
-		PageNumber: 0,
-		Lines:      filteredLines,
-		TotalLines: len(filteredLines),
-	}
+	pageContent := newPageContent(0, lines, singlePageFilter(config), config)
 
 	result.Pages = append(result.Pages, pageContent)
-	result.TotalLines = len(filteredLines)
-	result.Content = strings.Join(filteredLines, "\n")
+	result.TotalLines = pageContent.TotalLines
+	result.finish(config)
+
+	reportProgress(config, 1, 1)
 
 	return result, nil
 }
@@ -0,0 +1,143 @@
+package docreader
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// SearchOptions 文档搜索选项
+type SearchOptions struct {
+	// CaseInsensitive 是否忽略大小写
+	CaseInsensitive bool
+
+	// Regex 是否将查询视为正则表达式
+	Regex bool
+
+	// WholeWord 是否只匹配完整单词
+	WholeWord bool
+
+	// ContextLines 每个命中前后附带的上下文行数（不跨页）
+	ContextLines int
+
+	// SnippetChars 以命中内容为中心截取的摘要长度（按字符计），0 表示不生成摘要
+	SnippetChars int
+}
+
+// Match 表示一次搜索命中
+type Match struct {
+	// Page 命中所在的页码/工作表索引/幻灯片编号（从0开始，单页格式始终为0）
+	Page int
+
+	// Line 命中所在的行号（页内索引，从0开始）
+	Line int
+
+	// Text 命中所在行的完整内容
+	Text string
+
+	// Col 命中在该行中的起始列（按字符计，从0开始）
+	Col int
+
+	// Before 命中行之前的上下文行（需设置 ContextLines）
+	Before []string
+
+	// After 命中行之后的上下文行（需设置 ContextLines）
+	After []string
+
+	// Snippet 以命中内容为中心的摘要（需设置 SnippetChars）
+	Snippet string
+}
+
+// SearchDocument 在文档中搜索，返回所有命中的位置
+// 内部使用 ReadDocumentWithConfig 获取结构化内容，同一行中的多个命中会分别返回。
+// 始终使用 NewReadConfig() 读取，不受 SetDefaultReadConfig 影响，因此 Line 总是页内的原始行号
+func SearchDocument(filePath, query string, opts SearchOptions) ([]Match, error) {
+	re, err := compileSearchQuery(query, opts)
+	if err != nil {
+		return nil, WrapError("SearchDocument", filePath, err)
+	}
+
+	result, err := ReadDocumentWithConfig(filePath, NewReadConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	return searchResult(result, re, opts), nil
+}
+
+// compileSearchQuery 根据搜索选项将查询编译为正则表达式
+func compileSearchQuery(query string, opts SearchOptions) (*regexp.Regexp, error) {
+	if query == "" {
+		return nil, ErrInvalidQuery
+	}
+
+	pattern := query
+	if !opts.Regex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if opts.WholeWord {
+		pattern = `\b(?:` + pattern + `)\b`
+	}
+	if opts.CaseInsensitive {
+		pattern = `(?i)` + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+	}
+	return re, nil
+}
+
+// searchResult 在结构化结果的每一行中查找匹配
+func searchResult(result *DocumentResult, re *regexp.Regexp, opts SearchOptions) []Match {
+	matches := make([]Match, 0)
+
+	for _, page := range result.Pages {
+		for lineIndex, line := range page.Lines {
+			for _, loc := range re.FindAllStringIndex(line, -1) {
+				match := Match{
+					Page: page.PageNumber,
+					Line: lineIndex,
+					Text: line,
+					Col:  utf8.RuneCountInString(line[:loc[0]]),
+				}
+
+				// 上下文只在当前页内截取
+				if opts.ContextLines > 0 {
+					start := max(0, lineIndex-opts.ContextLines)
+					end := min(len(page.Lines), lineIndex+opts.ContextLines+1)
+					match.Before = page.Lines[start:lineIndex]
+					match.After = page.Lines[lineIndex+1 : end]
+				}
+
+				if opts.SnippetChars > 0 {
+					match.Snippet = buildSnippet(line, loc[0], loc[1], opts.SnippetChars)
+				}
+
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	return matches
+}
+
+// buildSnippet 以 [start, end) 字节区间的命中内容为中心截取长度不超过 size 个字符的摘要
+// 命中内容本身超过 size 时返回完整的命中内容
+func buildSnippet(line string, start, end, size int) string {
+	runes := []rune(line)
+	matchStart := utf8.RuneCountInString(line[:start])
+	matchEnd := matchStart + utf8.RuneCountInString(line[start:end])
+
+	if matchEnd-matchStart >= size {
+		return string(runes[matchStart:matchEnd])
+	}
+
+	// 在命中内容两侧平均分配剩余长度，靠近行首或行尾时向另一侧补齐
+	snippetStart := max(0, matchStart-(size-(matchEnd-matchStart))/2)
+	snippetEnd := min(len(runes), snippetStart+size)
+	snippetStart = max(0, snippetEnd-size)
+
+	return string(runes[snippetStart:snippetEnd])
+}
@@ -0,0 +1,153 @@
+package docreader
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSearchDocument(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "search.txt")
+	content := "Hello world\n你好 World\nworldwide news\nfoo.bar"
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("创建临时文件失败: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		opts     SearchOptions
+		expected []Match
+	}{
+		{
+			name:  "默认区分大小写",
+			query: "world",
+			expected: []Match{
+				{Page: 0, Line: 0, Text: "Hello world", Col: 6},
+				{Page: 0, Line: 2, Text: "worldwide news", Col: 0},
+			},
+		},
+		{
+			name:  "忽略大小写并按字符计算列",
+			query: "WORLD",
+			opts:  SearchOptions{CaseInsensitive: true, WholeWord: true},
+			expected: []Match{
+				{Page: 0, Line: 0, Text: "Hello world", Col: 6},
+				{Page: 0, Line: 1, Text: "你好 World", Col: 3},
+			},
+		},
+		{
+			name:     "普通模式转义特殊字符",
+			query:    "o.b",
+			expected: []Match{{Page: 0, Line: 3, Text: "foo.bar", Col: 2}},
+		},
+		{
+			name:  "正则模式返回同一行的所有命中",
+			query: `o\b`,
+			opts:  SearchOptions{Regex: true},
+			expected: []Match{
+				{Page: 0, Line: 0, Text: "Hello world", Col: 4},
+				{Page: 0, Line: 3, Text: "foo.bar", Col: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := SearchDocument(testFile, tt.query, tt.opts)
+			if err != nil {
+				t.Fatalf("搜索失败: %v", err)
+			}
+			if len(matches) != len(tt.expected) {
+				t.Fatalf("期望 %d 个命中，实际: %+v", len(tt.expected), matches)
+			}
+			for i := range matches {
+				if !reflect.DeepEqual(matches[i], tt.expected[i]) {
+					t.Errorf("命中 %d 期望 %+v，实际 %+v", i, tt.expected[i], matches[i])
+				}
+			}
+		})
+	}
+
+	t.Run("无效查询", func(t *testing.T) {
+		if _, err := SearchDocument(testFile, "", SearchOptions{}); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("期望 ErrInvalidQuery，实际: %v", err)
+		}
+		if _, err := SearchDocument(testFile, "(", SearchOptions{Regex: true}); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("期望 ErrInvalidQuery，实际: %v", err)
+		}
+	})
+}
+
+func TestSearchDocumentIgnoresDefaultConfig(t *testing.T) {
+	t.Cleanup(func() { SetDefaultReadConfig(nil) })
+
+	testFile := filepath.Join(t.TempDir(), "default.txt")
+	if err := os.WriteFile(testFile, []byte("first\n\n  target\n"), 0644); err != nil {
+		t.Fatalf("创建临时文件失败: %v", err)
+	}
+
+	// 默认配置会跳过空行并去除首尾空白，搜索结果的行号和列号不应受影响
+	SetDefaultReadConfig(NewReadConfig().WithDropEmptyLines(true).WithTrimLines(true))
+	matches, err := SearchDocument(testFile, "target", SearchOptions{})
+	if err != nil {
+		t.Fatalf("搜索失败: %v", err)
+	}
+	expected := []Match{{Page: 0, Line: 2, Text: "  target", Col: 2}}
+	if !reflect.DeepEqual(matches, expected) {
+		t.Errorf("期望 %+v，实际 %+v", expected, matches)
+	}
+}
+
+func TestSearchDocumentContext(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "context.txt")
+	content := "line one\nline two\nthe target is here\nline four"
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("创建临时文件失败: %v", err)
+	}
+
+	matches, err := SearchDocument(testFile, "target", SearchOptions{ContextLines: 2, SnippetChars: 10})
+	if err != nil {
+		t.Fatalf("搜索失败: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("期望 1 个命中，实际: %+v", matches)
+	}
+
+	match := matches[0]
+	if !reflect.DeepEqual(match.Before, []string{"line one", "line two"}) {
+		t.Errorf("前置上下文不符合预期: %q", match.Before)
+	}
+	if !reflect.DeepEqual(match.After, []string{"line four"}) {
+		t.Errorf("后置上下文不符合预期: %q", match.After)
+	}
+	if match.Snippet != "e target i" {
+		t.Errorf("摘要不符合预期: %q", match.Snippet)
+	}
+}
+
+func TestBuildSnippet(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		start, end int
+		size       int
+		expected   string
+	}{
+		{"居中", "abcdefghij", 4, 6, 4, "defg"},
+		{"靠近行首", "abcdefghij", 0, 1, 4, "abcd"},
+		{"靠近行尾", "abcdefghij", 9, 10, 4, "ghij"},
+		{"命中超过长度", "abcdefghij", 2, 8, 3, "cdefgh"},
+		{"中文字符", "你好世界再见", 6, 12, 4, "好世界再"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildSnippet(tt.line, tt.start, tt.end, tt.size); got != tt.expected {
+				t.Errorf("期望 %q，实际 %q", tt.expected, got)
+			}
+		})
+	}
+}
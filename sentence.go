@@ -0,0 +1,124 @@
+package docreader
+
+import (
+	"strings"
+	"unicode"
+)
+
+// sentenceAbbreviations 句点之后不视为句子结束的常见缩写（小写，不含末尾的句点）
+var sentenceAbbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "sr": true, "jr": true, "st": true,
+	"mt": true, "vs": true, "etc": true, "e.g": true, "i.e": true, "cf": true, "al": true, "approx": true,
+	"inc": true, "ltd": true, "co": true, "corp": true, "no": true, "fig": true, "vol": true, "p": true,
+	"pp": true, "jan": true, "feb": true, "mar": true, "apr": true, "jun": true, "jul": true, "aug": true,
+	"sep": true, "sept": true, "oct": true, "nov": true, "dec": true, "u.s": true, "u.k": true,
+}
+
+// Sentences 将文档内容切分为句子，每个句子去除首尾空白，空句子被忽略
+// 中文等使用 "。！？" 的文本在标点处切分；西文的 ".!?" 只有在其后是空白且下一个字符不是小写字母时才切分，
+// 并跳过 "Mr."、"e.g." 等常见缩写和 "J. Smith" 这样的姓名首字母。标点后的右引号和右括号属于前一句。
+// 空行（段落边界）也作为句子边界，因此没有标点的标题会成为单独的句子
+func (d *Document) Sentences() []string {
+	return splitSentences(d.Content)
+}
+
+// splitSentences 按句子边界切分文本
+func splitSentences(text string) []string {
+	runes := []rune(text)
+	sentences := make([]string, 0)
+	start := 0
+
+	emit := func(end int) {
+		if sentence := strings.TrimSpace(string(runes[start:end])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			// 空行是段落边界
+			j := i + 1
+			for j < len(runes) && runes[j] != '\n' && unicode.IsSpace(runes[j]) {
+				j++
+			}
+			if j < len(runes) && runes[j] == '\n' {
+				emit(i)
+			}
+
+		case isCJKSentenceEnd(r):
+			end := skipSentenceClosers(runes, i+1, isCJKSentenceEnd)
+			emit(end)
+			i = end - 1
+
+		case r == '.' || r == '!' || r == '?':
+			end := skipSentenceClosers(runes, i+1, isWesternSentenceEnd)
+			if !isWesternBoundary(runes, i, end) {
+				i = end - 1
+				continue
+			}
+			emit(end)
+			i = end - 1
+		}
+	}
+	emit(len(runes))
+
+	return sentences
+}
+
+// isCJKSentenceEnd 判断是否为中日文的句末标点
+func isCJKSentenceEnd(r rune) bool {
+	return r == '。' || r == '！' || r == '？' || r == '｡'
+}
+
+// isWesternSentenceEnd 判断是否为西文的句末标点
+func isWesternSentenceEnd(r rune) bool {
+	return r == '.' || r == '!' || r == '?'
+}
+
+// skipSentenceClosers 跳过连续的句末标点以及随后的右引号、右括号，返回句子结束的位置
+func skipSentenceClosers(runes []rune, i int, isEnd func(rune) bool) int {
+	for i < len(runes) && isEnd(runes[i]) {
+		i++
+	}
+	for i < len(runes) && strings.ContainsRune(`"')]”’」』）】》`, runes[i]) {
+		i++
+	}
+	return i
+}
+
+// isWesternBoundary 判断 runes[pos] 处的西文标点（其后的标点和右引号到 end 为止）是否结束一个句子
+func isWesternBoundary(runes []rune, pos, end int) bool {
+	if end < len(runes) && !unicode.IsSpace(runes[end]) {
+		return false
+	}
+
+	// 下一个非空白字符是小写字母时不切分，例如 "e.g. something" 或 "approx. five"
+	next := end
+	for next < len(runes) && unicode.IsSpace(runes[next]) {
+		next++
+	}
+	if next < len(runes) && unicode.IsLower(runes[next]) {
+		return false
+	}
+
+	if runes[pos] != '.' || next == len(runes) {
+		return true
+	}
+
+	// 句点前的单词，包括其中的句点（如 "e.g"、"U.S"）
+	wordStart := pos
+	for wordStart > 0 && (unicode.IsLetter(runes[wordStart-1]) || runes[wordStart-1] == '.') {
+		wordStart--
+	}
+	word := string(runes[wordStart:pos])
+	if sentenceAbbreviations[strings.ToLower(word)] {
+		return false
+	}
+
+	// 单个大写字母通常是姓名首字母
+	letters := []rune(word)
+	return !(len(letters) == 1 && unicode.IsUpper(letters[0]))
+}
@@ -0,0 +1,59 @@
+package docreader
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSentences(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			"英文",
+			"Hello world. How are you? I'm fine! Thanks.",
+			[]string{"Hello world.", "How are you?", "I'm fine!", "Thanks."},
+		},
+		{
+			"中文",
+			"今天天气很好。我们去公园吧！好不好？好的",
+			[]string{"今天天气很好。", "我们去公园吧！", "好不好？", "好的"},
+		},
+		{
+			"缩写",
+			"Mr. Smith met Dr. Brown, e.g. at lunch. They talked about the U.S. Economy. J. Doe agreed.",
+			[]string{"Mr. Smith met Dr. Brown, e.g. at lunch.", "They talked about the U.S. Economy.", "J. Doe agreed."},
+		},
+		{
+			"小数和小写",
+			"The value is 3.14 today. it continues here. Done...",
+			[]string{"The value is 3.14 today. it continues here.", "Done..."},
+		},
+		{
+			"引号",
+			`He said "Stop." Then he left. 他说：“走吧。”然后离开了。`,
+			[]string{`He said "Stop."`, "Then he left.", "他说：“走吧。”", "然后离开了。"},
+		},
+		{
+			"段落",
+			"标题\n\n第一段的内容\n跨越两行。\n  \nTitle\nText here.",
+			[]string{"标题", "第一段的内容\n跨越两行。", "Title\nText here."},
+		},
+		{
+			"空文本",
+			"  \n ",
+			[]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &Document{Content: tt.content}
+			if got := doc.Sentences(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("期望 %q，实际 %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -4,6 +4,29 @@ import (
 	"regexp"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// standalonePageNumberPattern 匹配单独成行的页码
+var standalonePageNumberPattern = regexp.MustCompile(`^\s*\d+\s*$|^\s*第\s*\d+\s*页\s*$`)
+
+// repeatedLineMinPages 检测重复页眉页脚所需的最少页数
+const repeatedLineMinPages = 3
+
+// NormalizeForm 文本清理时使用的 Unicode 规范化形式
+type NormalizeForm int
+
+const (
+	// NormalizeNone 不进行 Unicode 规范化（默认）
+	NormalizeNone NormalizeForm = iota
+
+	// NormalizeNFC 规范组合：将分解的字符合并为预组合字符（如 "e" + U+0301 -> "é"），不改变字符的含义
+	NormalizeNFC
+
+	// NormalizeNFKC 兼容组合：在 NFC 的基础上折叠兼容字符，如连字 "ﬁ" -> "fi"、全角 "Ａ１" -> "A1"、上标 "²" -> "2"
+	NormalizeNFKC
 )
 
 // TextCleaner 提供文本清理功能，用于优化大模型理解
@@ -17,11 +40,38 @@ type TextCleaner struct {
 	// RemoveControlChars 是否移除特殊控制字符
 	RemoveControlChars bool
 
-	// MaxBlankLines 最大连续空行数
+	// RemoveCJKSpaces 是否移除两个中日韩字符之间的单个空格
+	// 常用于修复 PDF 提取时在每个汉字之间插入的多余空格（如 "你 好 世 界"），
+	// 中日韩字符与拉丁单词之间的空格会被保留。
+	// 注意：韩文使用空格分词，处理韩文文档时不建议开启
+	RemoveCJKSpaces bool
+
+	// JoinHyphenatedLines 是否合并因换行被连字符断开的单词（如 "inter-\nnational"）
+	// 仅当连字符前的单词全为小写、且下一行以小写字母开头时合并，以避免误合并复合词
+	JoinHyphenatedLines bool
+
+	// NormalizeFullWidth 是否将全角拉丁字母和数字转换为半角（如 "ＡＢＣ１２３" -> "ABC123"）
+	// 汉字以及中文常用的全角标点（如 "，"、"。"）保持不变
+	NormalizeFullWidth bool
+
+	// NormalizeForm 对每一行进行的 Unicode 规范化，默认不处理
+	// PDF 和 DOCX 中的分解字符和连字会影响搜索匹配，NormalizeNFC 统一字符的组合方式，
+	// NormalizeNFKC 还会折叠连字、全角字符等兼容字符，适合跨来源的文档检索。规范化在其他按行清理之前执行
+	NormalizeForm NormalizeForm
+
+	// RemoveStandalonePageNumbers 是否移除单独成行的页码（如 "12"、"第 3 页"）
+	RemoveStandalonePageNumbers bool
+
+	// RemoveRepeatedLines 是否移除在大多数页面中重复出现的行（页眉、页脚等）
+	// 需要页面结构，只在 CleanResult 中生效，对 Clean 处理的纯文本无效
+	RemoveRepeatedLines bool
+
+	// MaxBlankLines 最大连续空行数，是空行处理的唯一配置项，不受其他选项影响
 	// -1: 不限制空行数（保留所有空行）
-	//  0: 移除所有空行
+	//  0: 移除所有空行（-1 以外的负数同样移除所有空行）
 	//  1: 最多保留1个连续空行（压缩多余空行）
 	//  N: 最多保留N个连续空行
+	// 无论取值如何，文本开头和结尾的空行都会被移除
 	MaxBlankLines int
 }
 
@@ -35,26 +85,138 @@ func DefaultTextCleaner() *TextCleaner {
 	}
 }
 
+// CleanReport 记录一次文本清理的统计信息，便于调试清理配置
+type CleanReport struct {
+	// BlankLinesRemoved 移除的空行数（包括开头和结尾的空行）
+	BlankLinesRemoved int
+
+	// ControlCharsRemoved 移除的控制字符数
+	ControlCharsRemoved int
+
+	// SpacesCollapsed 压缩连续空格时移除的空白字符数
+	SpacesCollapsed int
+
+	// BytesBefore 清理前的字节数
+	BytesBefore int
+
+	// BytesAfter 清理后的字节数
+	BytesAfter int
+}
+
 // Clean 清理文本内容
 func (tc *TextCleaner) Clean(text string) string {
+	return tc.clean(text, nil)
+}
+
+// CleanWithReport 清理文本内容，并返回清理过程的统计信息
+func (tc *TextCleaner) CleanWithReport(text string) (string, CleanReport) {
+	report := CleanReport{BytesBefore: len(text)}
+	cleaned := tc.clean(text, &report)
+	report.BytesAfter = len(cleaned)
+	return cleaned, report
+}
+
+// clean 清理文本内容，report 不为 nil 时记录统计信息
+func (tc *TextCleaner) clean(text string, report *CleanReport) string {
 	if text == "" {
 		return ""
 	}
 
 	// 1. 移除控制字符（保留换行符、制表符等有意义的空白字符）
 	if tc.RemoveControlChars {
-		text = tc.removeControlChars(text)
+		cleaned := tc.removeControlChars(text)
+		if report != nil {
+			report.ControlCharsRemoved += utf8.RuneCountInString(text) - utf8.RuneCountInString(cleaned)
+		}
+		text = cleaned
 	}
 
 	// 2. 统一换行符为 \n
 	text = normalizeLineBreaks(text)
 
 	// 3. 按行处理
-	lines := strings.Split(text, "\n")
-	var cleanedLines []string
+	cleanedLines, _ := tc.cleanLines(strings.Split(text, "\n"), report)
+
+	// 4. 合并结果
+	return strings.Join(cleanedLines, "\n")
+}
+
+// CleanResult 清理结构化结果：按页清理 PageContent.Lines，重新计算行数并重新生成 Content
+// 开启 RemoveRepeatedLines 时会先移除页眉页脚等跨页重复的行。
+// 如果页面带有 LineNumbers，清理后保持与 Lines 一一对应
+func (tc *TextCleaner) CleanResult(result *DocumentResult) {
+	if result == nil {
+		return
+	}
+
+	var repeated map[string]bool
+	if tc.RemoveRepeatedLines {
+		repeated = findRepeatedLines(result.Pages)
+	}
+
+	totalLines := 0
+	for i := range result.Pages {
+		page := &result.Pages[i]
+		if len(repeated) > 0 {
+			page.filterLines(func(line string) bool {
+				return !repeated[repeatedLineKey(line)]
+			})
+		}
+
+		lines := page.Lines
+		if tc.RemoveControlChars {
+			lines = make([]string, len(page.Lines))
+			for j, line := range page.Lines {
+				lines[j] = tc.removeControlChars(line)
+			}
+		}
+
+		cleanedLines, indexes := tc.cleanLines(lines, nil)
+		if page.LineNumbers != nil && len(page.LineNumbers) == len(page.Lines) {
+			lineNumbers := make([]int, len(indexes))
+			for j, index := range indexes {
+				lineNumbers[j] = page.LineNumbers[index]
+			}
+			page.LineNumbers = lineNumbers
+		}
+
+		page.Lines = cleanedLines
+		page.TotalLines = len(cleanedLines)
+		totalLines += page.TotalLines
+	}
+
+	result.TotalLines = totalLines
+	result.Content = result.BuildContent()
+}
+
+// cleanLines 逐行清理，返回清理后的行以及每一行对应的输入行索引
+// 被连字符合并的多行以第一行的索引为准；report 不为 nil 时记录统计信息
+func (tc *TextCleaner) cleanLines(lines []string, report *CleanReport) ([]string, []int) {
+	indexes := makeAllPagesSlice(len(lines))
+
+	// 合并被连字符断开的单词
+	if tc.JoinHyphenatedLines {
+		lines, indexes = tc.joinHyphenatedLines(lines, indexes)
+	}
+
+	cleanedLines := make([]string, 0, len(lines))
+	cleanedIndexes := make([]int, 0, len(lines))
 	consecutiveBlankLines := 0
 
-	for _, line := range lines {
+	for i, line := range lines {
+		// Unicode 规范化
+		switch tc.NormalizeForm {
+		case NormalizeNFC:
+			line = norm.NFC.String(line)
+		case NormalizeNFKC:
+			line = norm.NFKC.String(line)
+		}
+
+		// 全角字母数字转半角
+		if tc.NormalizeFullWidth {
+			line = normalizeFullWidth(line)
+		}
+
 		// 移除行首行尾空格
 		if tc.TrimSpaces {
 			line = strings.TrimSpace(line)
@@ -62,7 +224,21 @@ func (tc *TextCleaner) Clean(text string) string {
 
 		// 移除多余空格
 		if tc.RemoveExtraSpaces && line != "" {
-			line = tc.removeExtraSpaces(line)
+			collapsed := tc.removeExtraSpaces(line)
+			if report != nil {
+				report.SpacesCollapsed += len(line) - len(collapsed)
+			}
+			line = collapsed
+		}
+
+		// 移除中日韩字符之间的空格
+		if tc.RemoveCJKSpaces && line != "" {
+			line = tc.removeCJKSpaces(line)
+		}
+
+		// 移除单独成行的页码
+		if tc.RemoveStandalonePageNumbers && standalonePageNumberPattern.MatchString(line) {
+			continue
 		}
 
 		// 处理空行
@@ -70,33 +246,28 @@ func (tc *TextCleaner) Clean(text string) string {
 			consecutiveBlankLines++
 
 			// MaxBlankLines = -1: 不限制，保留所有空行
-			if tc.MaxBlankLines == -1 {
-				cleanedLines = append(cleanedLines, line)
-				continue
-			}
-
-			// MaxBlankLines = 0: 移除所有空行
-			if tc.MaxBlankLines == 0 {
-				continue
-			}
-
 			// MaxBlankLines > 0: 只保留指定数量的连续空行
-			if consecutiveBlankLines <= tc.MaxBlankLines {
-				cleanedLines = append(cleanedLines, line)
+			// 其他取值（0 和 -1 以外的负数）: 移除所有空行
+			if tc.MaxBlankLines != -1 && consecutiveBlankLines > tc.MaxBlankLines {
+				if report != nil {
+					report.BlankLinesRemoved++
+				}
+				continue
 			}
 		} else {
 			consecutiveBlankLines = 0
-			cleanedLines = append(cleanedLines, line)
 		}
-	}
 
-	// 4. 移除开头和结尾的空行
-	cleanedLines = trimEmptyLines(cleanedLines)
-
-	// 5. 合并结果
-	result := strings.Join(cleanedLines, "\n")
+		cleanedLines = append(cleanedLines, line)
+		cleanedIndexes = append(cleanedIndexes, indexes[i])
+	}
 
-	return result
+	// 移除开头和结尾的空行
+	start, end := trimEmptyLineBounds(cleanedLines)
+	if report != nil {
+		report.BlankLinesRemoved += len(cleanedLines) - (end - start)
+	}
+	return cleanedLines[start:end], cleanedIndexes[start:end]
 }
 
 // removeControlChars 移除控制字符，保留必要的空白字符
@@ -121,6 +292,89 @@ func (tc *TextCleaner) removeExtraSpaces(text string) string {
 	return re.ReplaceAllString(text, " ")
 }
 
+// removeCJKSpaces 移除两个中日韩字符之间的单个空格
+func (tc *TextCleaner) removeCJKSpaces(text string) string {
+	if !strings.Contains(text, " ") {
+		return text
+	}
+
+	runes := []rune(text)
+	var builder strings.Builder
+	builder.Grow(len(text))
+
+	for i, r := range runes {
+		if r == ' ' && i > 0 && i+1 < len(runes) && isCJK(runes[i-1]) && isCJK(runes[i+1]) {
+			continue
+		}
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+// joinHyphenatedLines 合并以连字符结尾的行与下一行，同时维护每一行对应的索引
+func (tc *TextCleaner) joinHyphenatedLines(lines []string, indexes []int) ([]string, []int) {
+	joinedLines := make([]string, 0, len(lines))
+	joinedIndexes := make([]int, 0, len(indexes))
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		index := indexes[i]
+		for i+1 < len(lines) && shouldJoinHyphenated(line, lines[i+1]) {
+			trimmed := strings.TrimRight(line, " \t")
+			line = trimmed[:len(trimmed)-1] + strings.TrimLeft(lines[i+1], " \t")
+			i++
+		}
+		joinedLines = append(joinedLines, line)
+		joinedIndexes = append(joinedIndexes, index)
+	}
+
+	return joinedLines, joinedIndexes
+}
+
+// shouldJoinHyphenated 判断以连字符结尾的行是否应与下一行合并
+func shouldJoinHyphenated(line, next string) bool {
+	trimmed := strings.TrimRight(line, " \t")
+	if !strings.HasSuffix(trimmed, "-") {
+		return false
+	}
+
+	// 连字符必须紧跟在单词之后，且该单词全部由小写字母组成
+	body := trimmed[:len(trimmed)-1]
+	if body == "" || strings.TrimRight(body, " \t") != body {
+		return false
+	}
+	fields := strings.Fields(body)
+	for _, r := range fields[len(fields)-1] {
+		if !unicode.IsLower(r) {
+			return false
+		}
+	}
+
+	// 下一行必须以小写字母开头
+	for _, r := range strings.TrimLeft(next, " \t") {
+		return unicode.IsLower(r)
+	}
+	return false
+}
+
+// normalizeFullWidth 将全角拉丁字母和数字转换为对应的半角字符
+func normalizeFullWidth(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '０' && r <= '９', r >= 'Ａ' && r <= 'Ｚ', r >= 'ａ' && r <= 'ｚ':
+			// 全角字符与对应 ASCII 字符的码位相差 0xFEE0
+			return r - 0xFEE0
+		}
+		return r
+	}, text)
+}
+
+// isCJK 判断字符是否为中日韩文字（汉字、平假名、片假名、谚文）
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
 // normalizeLineBreaks 统一换行符
 func normalizeLineBreaks(text string) string {
 	// 将 \r\n 和 \r 统一替换为 \n
@@ -129,8 +383,8 @@ func normalizeLineBreaks(text string) string {
 	return text
 }
 
-// trimEmptyLines 移除开头和结尾的空行
-func trimEmptyLines(lines []string) []string {
+// trimEmptyLineBounds 返回去除开头和结尾空行后的区间 [start, end)
+func trimEmptyLineBounds(lines []string) (int, int) {
 	// 移除开头的空行
 	start := 0
 	for start < len(lines) && lines[start] == "" {
@@ -143,11 +397,59 @@ func trimEmptyLines(lines []string) []string {
 		end--
 	}
 
-	if start >= end {
-		return []string{}
+	return start, end
+}
+
+// CleanResult 使用指定的清理器清理结构化结果，等价于 cleaner.CleanResult(result)
+func CleanResult(result *DocumentResult, cleaner *TextCleaner) {
+	if cleaner == nil {
+		return
+	}
+	cleaner.CleanResult(result)
+}
+
+// findRepeatedLines 查找在超过一半页面中出现的行（按 repeatedLineKey 归一化）
+// 页数少于 repeatedLineMinPages 时不做检测，避免误删正文
+func findRepeatedLines(pages []PageContent) map[string]bool {
+	repeated := make(map[string]bool)
+	if len(pages) < repeatedLineMinPages {
+		return repeated
+	}
+
+	pageCounts := make(map[string]int)
+	for _, page := range pages {
+		seen := make(map[string]bool)
+		for _, line := range page.Lines {
+			key := repeatedLineKey(line)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			pageCounts[key]++
+		}
 	}
 
-	return lines[start:end]
+	for key, count := range pageCounts {
+		if count*2 > len(pages) {
+			repeated[key] = true
+		}
+	}
+	return repeated
+}
+
+// repeatedLineKey 返回用于比较重复行的键：去除首尾空白，并将数字统一替换为 #，
+// 使 "Page 3 of 10" 和 "Page 4 of 10" 这类页脚被视为同一行
+func repeatedLineKey(line string) string {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return ""
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return '#'
+		}
+		return r
+	}, line)
 }
 
 // CleanText 使用默认配置清理文本的便捷函数
@@ -158,22 +460,30 @@ func CleanText(text string) string {
 
 // CleanTextMinimal 使用最小清理配置（仅清理基本的空白，保留所有空行）
 func CleanTextMinimal(text string) string {
-	cleaner := &TextCleaner{
+	return minimalTextCleaner().Clean(text)
+}
+
+// CleanTextAggressive 使用激进的清理配置（最大程度压缩空间，移除所有空行）
+func CleanTextAggressive(text string) string {
+	return aggressiveTextCleaner().Clean(text)
+}
+
+// minimalTextCleaner 返回最小清理配置的清理器
+func minimalTextCleaner() *TextCleaner {
+	return &TextCleaner{
 		TrimSpaces:         true,
 		RemoveExtraSpaces:  true,
 		RemoveControlChars: true,
 		MaxBlankLines:      -1, // 不限制空行数，保留所有空行
 	}
-	return cleaner.Clean(text)
 }
 
-// CleanTextAggressive 使用激进的清理配置（最大程度压缩空间，移除所有空行）
-func CleanTextAggressive(text string) string {
-	cleaner := &TextCleaner{
+// aggressiveTextCleaner 返回激进清理配置的清理器
+func aggressiveTextCleaner() *TextCleaner {
+	return &TextCleaner{
 		TrimSpaces:         true,
 		RemoveExtraSpaces:  true,
 		RemoveControlChars: true,
 		MaxBlankLines:      0, // 移除所有空行
 	}
-	return cleaner.Clean(text)
 }
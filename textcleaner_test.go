@@ -1,6 +1,7 @@
 package docreader
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -127,6 +128,336 @@ func TestTextCleanerCustomConfig(t *testing.T) {
 	}
 }
 
+func TestRemoveCJKSpaces(t *testing.T) {
+	cleaner := DefaultTextCleaner()
+	cleaner.RemoveCJKSpaces = true
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "汉字之间的空格",
+			input:    "你 好 世 界",
+			expected: "你好世界",
+		},
+		{
+			name:     "保留中英文之间的空格",
+			input:    "使 用 Go 语 言 编 写",
+			expected: "使用 Go 语言编写",
+		},
+		{
+			name:     "多个连续空格先压缩再移除",
+			input:    "中   文 test 文 档",
+			expected: "中文 test 文档",
+		},
+		{
+			name:     "日文假名",
+			input:    "こ ん に ち は World",
+			expected: "こんにちは World",
+		},
+		{
+			name:     "纯英文不受影响",
+			input:    "Hello world",
+			expected: "Hello world",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := cleaner.Clean(tt.input)
+			if result != tt.expected {
+				t.Errorf("期望: %q, 实际: %q", tt.expected, result)
+			}
+		})
+	}
+
+	// 关闭选项时保留空格
+	if result := DefaultTextCleaner().Clean("你 好"); result != "你 好" {
+		t.Errorf("未开启时不应移除空格，实际: %q", result)
+	}
+}
+
+func TestJoinHyphenatedLines(t *testing.T) {
+	cleaner := DefaultTextCleaner()
+	cleaner.JoinHyphenatedLines = true
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "合并断行单词",
+			input:    "the inter-\nnational market",
+			expected: "the international market",
+		},
+		{
+			name:     "连字符后有空格",
+			input:    "an exam-  \n  ple here",
+			expected: "an example here",
+		},
+		{
+			name:     "连续多行断开",
+			input:    "super-\ncali-\nfragilistic",
+			expected: "supercalifragilistic",
+		},
+		{
+			name:     "下一行以大写开头不合并",
+			input:    "Pre-\nWar era",
+			expected: "Pre-\nWar era",
+		},
+		{
+			name:     "连字符前是大写单词不合并",
+			input:    "COVID-\nrelated",
+			expected: "COVID-\nrelated",
+		},
+		{
+			name:     "独立的破折号不合并",
+			input:    "item -\nnext",
+			expected: "item -\nnext",
+		},
+		{
+			name:     "下一行为数字不合并",
+			input:    "page-\n12",
+			expected: "page-\n12",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := cleaner.Clean(tt.input)
+			if result != tt.expected {
+				t.Errorf("期望: %q, 实际: %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRemoveStandalonePageNumbers(t *testing.T) {
+	cleaner := DefaultTextCleaner()
+	cleaner.RemoveStandalonePageNumbers = true
+
+	input := "正文第一段\n  12  \n第 3 页\n第3页\n共 12 项\n2024 年报告"
+	expected := "正文第一段\n共 12 项\n2024 年报告"
+	if result := cleaner.Clean(input); result != expected {
+		t.Errorf("期望: %q, 实际: %q", expected, result)
+	}
+}
+
+func TestCleanResult(t *testing.T) {
+	result := &DocumentResult{
+		Pages: []PageContent{
+			{PageNumber: 0, Lines: []string{"ACME Confidential", "Intro text", "Page 1 of 3"}},
+			{PageNumber: 1, Lines: []string{"ACME Confidential", "Body text", "2"}},
+			{PageNumber: 2, Lines: []string{"Closing text", "Page 3 of 3"}},
+		},
+		layout: layoutPages,
+	}
+
+	cleaner := &TextCleaner{RemoveRepeatedLines: true, RemoveStandalonePageNumbers: true}
+	CleanResult(result, cleaner)
+
+	expected := [][]string{{"Intro text"}, {"Body text"}, {"Closing text"}}
+	for i, page := range result.Pages {
+		if strings.Join(page.Lines, "|") != strings.Join(expected[i], "|") {
+			t.Errorf("第 %d 页期望 %q，实际 %q", i, expected[i], page.Lines)
+		}
+		if page.TotalLines != len(page.Lines) {
+			t.Errorf("第 %d 页 TotalLines 未更新: %d", i, page.TotalLines)
+		}
+	}
+	if result.TotalLines != 3 {
+		t.Errorf("期望总行数 3，实际 %d", result.TotalLines)
+	}
+	if strings.Contains(result.Content, "Confidential") || !strings.Contains(result.Content, "Body text") {
+		t.Errorf("Content 未重新生成: %q", result.Content)
+	}
+
+	// 页数不足时不检测重复行
+	short := &DocumentResult{Pages: []PageContent{
+		{Lines: []string{"Same"}},
+		{Lines: []string{"Same"}},
+	}}
+	CleanResult(short, cleaner)
+	if short.TotalLines != 2 {
+		t.Errorf("页数不足时不应移除重复行，实际总行数 %d", short.TotalLines)
+	}
+}
+
+func TestTextCleanerCleanResult(t *testing.T) {
+	result := &DocumentResult{
+		Pages: []PageContent{
+			{
+				PageNumber:  0,
+				Lines:       []string{"", "  Hello    world  ", "", "", "", "end\x00"},
+				LineNumbers: []int{10, 11, 12, 13, 14, 15},
+				TotalLines:  6,
+			},
+			{
+				PageNumber: 1,
+				Lines:      []string{"  second   page "},
+				TotalLines: 1,
+			},
+		},
+		TotalLines: 7,
+		layout:     layoutPages,
+	}
+
+	DefaultTextCleaner().CleanResult(result)
+
+	first := result.Pages[0]
+	if strings.Join(first.Lines, "|") != "Hello world||end" {
+		t.Errorf("第 0 页清理结果不符合预期: %q", first.Lines)
+	}
+	if fmt.Sprint(first.LineNumbers) != "[11 12 15]" {
+		t.Errorf("行号未同步: %v", first.LineNumbers)
+	}
+	if first.TotalLines != 3 || result.Pages[1].TotalLines != 1 || result.TotalLines != 4 {
+		t.Errorf("行数未重新计算: %d, %d, %d", first.TotalLines, result.Pages[1].TotalLines, result.TotalLines)
+	}
+
+	expectedContent := "Hello world\n\nend\n\n--- 第 0 页 ---\n\nsecond page\n\n--- 第 1 页 ---\n\n"
+	if result.Content != expectedContent {
+		t.Errorf("Content 未重新生成:\n期望: %q\n实际: %q", expectedContent, result.Content)
+	}
+}
+
+func TestNormalizeFullWidth(t *testing.T) {
+	cleaner := DefaultTextCleaner()
+	cleaner.NormalizeFullWidth = true
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "全角数字",
+			input:    "０１２３４５６７８９",
+			expected: "0123456789",
+		},
+		{
+			name:     "全角大写字母",
+			input:    "ＡＢＣＤＥＦＧＨＩＪＫＬＭＮＯＰＱＲＳＴＵＶＷＸＹＺ",
+			expected: "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+		},
+		{
+			name:     "全角小写字母",
+			input:    "ａｂｃｄｅｆｇｈｉｊｋｌｍｎｏｐｑｒｓｔｕｖｗｘｙｚ",
+			expected: "abcdefghijklmnopqrstuvwxyz",
+		},
+		{
+			name:     "保留汉字和中文标点",
+			input:    "型号：ＸＹ－１２，价格１００元。",
+			expected: "型号：XY－12，价格100元。",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := cleaner.Clean(tt.input)
+			if result != tt.expected {
+				t.Errorf("期望: %q, 实际: %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNormalizeForm(t *testing.T) {
+	decomposed := "Caf" + "e\u0301" // e + 组合重音符
+	ligature := "\ufb01nance"       // ﬁ 连字
+
+	tests := []struct {
+		name     string
+		form     NormalizeForm
+		input    string
+		expected string
+	}{
+		{"不规范化保留分解字符", NormalizeNone, decomposed, decomposed},
+		{"NFC 合并分解的 é", NormalizeNFC, decomposed, "Caf\u00e9"},
+		{"NFC 保留连字", NormalizeNFC, ligature, ligature},
+		{"NFKC 合并分解的 é", NormalizeNFKC, decomposed, "Caf\u00e9"},
+		{"NFKC 折叠连字", NormalizeNFKC, ligature, "finance"},
+		{"NFKC 折叠全角字符", NormalizeNFKC, "ＡＢＣ１２３，中文", "ABC123,中文"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleaner := DefaultTextCleaner()
+			cleaner.NormalizeForm = tt.form
+			if result := cleaner.Clean(tt.input); result != tt.expected {
+				t.Errorf("期望: %q, 实际: %q", tt.expected, result)
+			}
+		})
+	}
+
+	// 规范化后的文本可以匹配
+	cleaner := &TextCleaner{NormalizeForm: NormalizeNFKC, MaxBlankLines: -1}
+	if !strings.Contains(cleaner.Clean("Café "+ligature), "Café finance") {
+		t.Errorf("规范化后期望包含 %q，实际 %q", "Café finance", cleaner.Clean("Café "+ligature))
+	}
+}
+
+func TestCleanWithReport(t *testing.T) {
+	input := "\n\nHello\x00\x01    world\n\n\n\nEnd\t\t here\n\n"
+	result, report := DefaultTextCleaner().CleanWithReport(input)
+
+	if result != "Hello world\n\nEnd here" {
+		t.Fatalf("清理结果不符合预期: %q", result)
+	}
+
+	expected := CleanReport{
+		BlankLinesRemoved:   6, // 开头2个、中间2个、结尾2个
+		ControlCharsRemoved: 2,
+		SpacesCollapsed:     5, // "    " 移除3个，"\t\t " 移除2个
+		BytesBefore:         len(input),
+		BytesAfter:          len(result),
+	}
+	if report != expected {
+		t.Errorf("统计信息不符合预期\n期望: %+v\n实际: %+v", expected, report)
+	}
+
+	// 与 Clean 的结果保持一致
+	if clean := DefaultTextCleaner().Clean(input); clean != result {
+		t.Errorf("CleanWithReport 与 Clean 结果不一致: %q vs %q", result, clean)
+	}
+}
+
+func TestMaxBlankLines(t *testing.T) {
+	input := "A\n\n\n\nB\n\nC"
+
+	tests := []struct {
+		maxBlankLines int
+		expected      string
+	}{
+		{-1, "A\n\n\n\nB\n\nC"},
+		{-5, "A\nB\nC"},
+		{0, "A\nB\nC"},
+		{1, "A\n\nB\n\nC"},
+		{2, "A\n\n\nB\n\nC"},
+	}
+
+	// 空行数量只由 MaxBlankLines 决定，与其他选项的取值无关
+	for _, tt := range tests {
+		for _, other := range []bool{true, false} {
+			name := fmt.Sprintf("MaxBlankLines=%d/其他选项=%v", tt.maxBlankLines, other)
+			t.Run(name, func(t *testing.T) {
+				cleaner := &TextCleaner{
+					TrimSpaces:         other,
+					RemoveExtraSpaces:  other,
+					RemoveControlChars: other,
+					MaxBlankLines:      tt.maxBlankLines,
+				}
+				if result := cleaner.Clean(input); result != tt.expected {
+					t.Errorf("期望: %q, 实际: %q", tt.expected, result)
+				}
+			})
+		}
+	}
+}
+
 func TestRemoveControlChars(t *testing.T) {
 	cleaner := DefaultTextCleaner()
 
@@ -197,3 +528,40 @@ func BenchmarkCleanTextAggressive(b *testing.B) {
 		CleanTextAggressive(input)
 	}
 }
+
+func TestDocumentResultCleanContent(t *testing.T) {
+	newResult := func() *DocumentResult {
+		return &DocumentResult{
+			Pages: []PageContent{
+				{Lines: []string{"  a    b  ", "", "", "c"}, TotalLines: 4},
+			},
+			TotalLines: 4,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		clean    func(r *DocumentResult)
+		expected string
+	}{
+		{"默认", (*DocumentResult).CleanContent, "a b\n\nc"},
+		{"最小", (*DocumentResult).CleanContentMinimal, "a b\n\n\nc"},
+		{"激进", (*DocumentResult).CleanContentAggressive, "a b\nc"},
+		{"自定义", func(r *DocumentResult) {
+			r.CleanContentWith(&TextCleaner{TrimSpaces: true, MaxBlankLines: 0})
+		}, "a    b\nc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := newResult()
+			tt.clean(result)
+			if result.Content != tt.expected {
+				t.Errorf("期望 %q，实际 %q", tt.expected, result.Content)
+			}
+			if result.TotalLines != len(result.Pages[0].Lines) {
+				t.Errorf("行数未重新计算: %d", result.TotalLines)
+			}
+		})
+	}
+}
@@ -1,23 +1,52 @@
 package docreader
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"regexp"
 	"strings"
 )
 
+const (
+	// DefaultMaxLineLength 流式读取 TXT 文件时单行的默认最大字节数（1MB）
+	DefaultMaxLineLength = 1 << 20
+
+	// DefaultTxtStreamThreshold ReadWithConfig 改为流式读取的默认文件大小阈值（64MB）
+	DefaultTxtStreamThreshold int64 = 64 << 20
+)
+
 // TxtReader 用于读取 .txt 文件
-type TxtReader struct{}
+type TxtReader struct {
+	// MaxLineLength 流式读取时单行的最大字节数，超过时返回 ErrFileRead
+	// 为 0 时使用 DefaultMaxLineLength
+	MaxLineLength int
+
+	// StreamThreshold 文件大小超过该值时 ReadWithConfig 以流的方式读取，只保留选中的行
+	// 为 0 时使用 DefaultTxtStreamThreshold，小于 0 表示始终流式读取
+	// 设置了 LineGroupPattern 或按段落划分时不使用流式读取
+	StreamThreshold int64
+
+	fileSource
+}
+
+// errStopStreaming 用于在回调中提前结束流式读取
+var errStopStreaming = errors.New("stop streaming")
 
 // ReadText 读取 TXT 文件的文本内容
 func (r *TxtReader) ReadText(filePath string) (string, error) {
-	// 读取文件内容
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return "", WrapError("TxtReader.ReadText", filePath, ErrFileRead)
+	var builder strings.Builder
+	if err := r.writeText(&builder, filePath); err != nil {
+		return "", err
 	}
+	return builder.String(), nil
+}
 
-	return string(data), nil
+// writeText 按行将文件内容写入 w
+func (r *TxtReader) writeText(w io.Writer, filePath string) error {
+	return r.streamFileLines(w, filePath, "TxtReader.ReadText")
 }
 
 // GetMetadata 获取 TXT 文件的元数据
@@ -25,7 +54,7 @@ func (r *TxtReader) GetMetadata(filePath string) (map[string]string, error) {
 	metadata := make(map[string]string)
 
 	// 获取文件信息
-	fileInfo, err := os.Stat(filePath)
+	fileInfo, err := r.stat(filePath)
 	if err != nil {
 		return nil, WrapError("TxtReader.GetMetadata", filePath, ErrFileNotFound)
 	}
@@ -33,19 +62,159 @@ func (r *TxtReader) GetMetadata(filePath string) (map[string]string, error) {
 	metadata["size"] = fmt.Sprintf("%d", fileInfo.Size())
 	metadata["modified"] = fileInfo.ModTime().String()
 
+	// 单页格式只有一个部分
+	metadata["section_count"] = "1"
+
 	return metadata, nil
 }
 
+// StreamLines 使用 bufio.Scanner 逐行读取文件并调用 fn，不会将整个文件读入内存，开头的 UTF-8 BOM 会被去掉
+// 行按 "\n" 分割，与 strings.Split(content, "\n") 的结果一致（保留 "\r"，以换行结尾时最后一行为空行）
+// 单行超过 MaxLineLength 时返回 ErrFileRead；fn 返回错误时停止读取并原样返回该错误
+func (r *TxtReader) StreamLines(filePath string, fn func(line string) error) error {
+	file, err := r.open(filePath)
+	if err != nil {
+		return WrapError("TxtReader.StreamLines", filePath, ErrFileOpen)
+	}
+	defer file.Close()
+
+	maxLineLength := r.MaxLineLength
+	if maxLineLength <= 0 {
+		maxLineLength = DefaultMaxLineLength
+	}
+
+	scanner := bufio.NewScanner(newBOMSkippingReader(file))
+	scanner.Buffer(make([]byte, 0, min(64*1024, maxLineLength)), maxLineLength)
+	scanner.Split(splitLines)
+
+	for scanner.Scan() {
+		if err := fn(scanner.Text()); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return WrapError("TxtReader.StreamLines", filePath, ErrFileRead)
+	}
+	return nil
+}
+
+// splitLines 按 "\n" 分割，保留 "\r"，并在数据以换行结尾（或为空）时输出最后的空行
+func splitLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), append([]byte{}, data...), bufio.ErrFinalToken
+	}
+	return 0, nil, nil
+}
+
+// shouldStream 判断 ReadWithConfig 是否使用流式读取
+func (r *TxtReader) shouldStream(filePath string, config *ReadConfig) bool {
+	if config != nil && (config.LineGroupPattern != "" || config.SplitMode == SplitParagraphs) {
+		return false
+	}
+
+	threshold := r.StreamThreshold
+	if threshold == 0 {
+		threshold = DefaultTxtStreamThreshold
+	}
+	if threshold < 0 {
+		return true
+	}
+
+	info, err := r.stat(filePath)
+	return err == nil && info.Size() > threshold
+}
+
+// streamSelectedLines 流式读取并只保留过滤器选中的行，读过最后一个选中的行后立即停止
+func (r *TxtReader) streamSelectedLines(filePath string, filter pageLineFilter, config *ReadConfig) (PageContent, error) {
+	maxIndex := -1
+	for index := range filter.lines {
+		maxIndex = max(maxIndex, index)
+	}
+
+	page := PageContent{Lines: make([]string, 0)}
+	preserve := config != nil && config.PreserveLineNumbers
+	index := 0
+
+	err := r.StreamLines(filePath, func(line string) error {
+		if !filter.readAll && index > maxIndex {
+			return errStopStreaming
+		}
+		if filter.readAll || filter.lines[index] {
+			page.Lines = append(page.Lines, line)
+			if preserve {
+				page.LineNumbers = append(page.LineNumbers, index)
+			}
+		}
+		index++
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopStreaming) {
+		return PageContent{}, err
+	}
+
+	page.TotalLines = len(page.Lines)
+	if preserve && page.LineNumbers == nil {
+		page.LineNumbers = make([]int, 0)
+	}
+	page.tidyLines(config)
+	return page, nil
+}
+
+// Capabilities 返回 TXT 读取器支持的功能：单页纯文本，没有额外的结构
+func (r *TxtReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{}
+}
+
 // ReadWithConfig 根据配置读取 TXT 文件，返回结构化结果
+// 文件超过 StreamThreshold 时以流的方式读取，只在内存中保留选中的行
 func (r *TxtReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
-	data, err := os.ReadFile(filePath)
+	if metadataOnly(config) {
+		metadata, err := r.GetMetadata(filePath)
+		return metadataOnlyResult("TxtReader.ReadWithConfig", filePath, 1, metadata, err)
+	}
+
+	if r.shouldStream(filePath, config) {
+		pageContent, err := r.streamSelectedLines(filePath, singlePageFilter(config), config)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &DocumentResult{
+			FilePath:   filePath,
+			TotalPages: 1,
+			Pages:      []PageContent{pageContent},
+			TotalLines: pageContent.TotalLines,
+		}
+		result.Metadata, _ = r.GetMetadata(filePath)
+		result.finish(config)
+
+		reportProgress(config, 1, 1)
+
+		return result, nil
+	}
+
+	data, err := r.readFile(filePath)
 	if err != nil {
 		return nil, WrapError("TxtReader.ReadWithConfig", filePath, ErrFileRead)
 	}
 
-	content := string(data)
+	content := string(trimBOM(data))
 	lines := strings.Split(content, "\n")
 
+	// 按分组模式将物理行合并为逻辑记录
+	if config != nil && config.LineGroupPattern != "" {
+		re, err := regexp.Compile(config.LineGroupPattern)
+		if err != nil {
+			return nil, WrapError("TxtReader.ReadWithConfig", filePath, ErrInvalidConfig)
+		}
+		lines = groupLines(lines, re)
+	} else if config != nil && config.SplitMode == SplitParagraphs {
+		lines = splitParagraphs(lines)
+	}
+
 	result := &DocumentResult{
 		FilePath:   filePath,
 		TotalPages: 1,
@@ -58,17 +227,33 @@ func (r *TxtReader) ReadWithConfig(filePath string, config *ReadConfig) (*Docume
 	result.Metadata = metadata
 
 	// 根据配置筛选行
-	filteredLines := filterLinesForSinglePage(lines, config)
-
-	pageContent := PageContent{
-		PageNumber: 0,
-		Lines:      filteredLines,
-		TotalLines: len(filteredLines),
-	}
+	pageContent := newPageContent(0, lines, singlePageFilter(config), config)
 
 	result.Pages = append(result.Pages, pageContent)
-	result.TotalLines = len(filteredLines)
-	result.Content = strings.Join(filteredLines, "\n")
+	result.TotalLines = pageContent.TotalLines
+	result.finish(config)
+
+	reportProgress(config, 1, 1)
 
 	return result, nil
 }
+
+// groupLines 将行按分组模式合并：匹配 re 的行开始新分组，其余行以换行符追加到当前分组
+// 第一个匹配行之前的行合并为一个分组
+func groupLines(lines []string, re *regexp.Regexp) []string {
+	groups := make([]string, 0)
+	var current []string
+
+	for _, line := range lines {
+		if re.MatchString(line) && len(current) > 0 {
+			groups = append(groups, strings.Join(current, "\n"))
+			current = nil
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		groups = append(groups, strings.Join(current, "\n"))
+	}
+
+	return groups
+}
@@ -1,25 +1,52 @@
 package docreader
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 
 	"github.com/xuri/excelize/v2"
 )
 
 // XlsxReader 用于读取 .xlsx 文件
-type XlsxReader struct{}
+type XlsxReader struct {
+	// CellSeparator ReadText 输出时单元格之间的分隔符，为空时使用 " | "
+	// ReadWithConfig 使用 ReadConfig.CellSeparator
+	CellSeparator string
+
+	// ShowFormulas ReadText 是否输出单元格的公式（以 "=" 开头）而不是计算结果
+	// ReadWithConfig 使用 ReadConfig.ShowFormulas
+	ShowFormulas bool
+
+	// RawValues ReadText 是否输出单元格存储的原始值而不是应用数字格式后的显示值
+	// ReadWithConfig 使用 ReadConfig.XlsxRawValues
+	RawValues bool
+
+	fileSource
+}
 
 // ReadText 读取 XLSX 文件的文本内容
 func (r *XlsxReader) ReadText(filePath string) (string, error) {
+	var builder strings.Builder
+	if err := r.writeText(&builder, filePath); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// writeText 逐行将所有工作表的文本写入 w，使用行迭代器避免一次性加载整个工作表
+func (r *XlsxReader) writeText(w io.Writer, filePath string) error {
 	// 打开 Excel 文件
-	f, err := excelize.OpenFile(filePath)
+	f, err := r.openXlsx(filePath, newZipLimits(nil))
 	if err != nil {
-		return "", WrapError("XlsxReader.ReadText", filePath, ErrFileOpen)
+		return WrapError("XlsxReader.ReadText", filePath, err)
 	}
 	defer f.Close()
 
-	var builder strings.Builder
+	builder := newTextWriter(w)
 
 	// 获取所有工作表
 	sheets := f.GetSheetList()
@@ -27,15 +54,31 @@ func (r *XlsxReader) ReadText(filePath string) (string, error) {
 	for _, sheetName := range sheets {
 		builder.WriteString(fmt.Sprintf("\n=== 工作表: %s ===\n\n", sheetName))
 
-		// 获取工作表中的所有行
-		rows, err := f.GetRows(sheetName)
+		// 获取工作表的行迭代器
+		rows, err := f.Rows(sheetName)
 		if err != nil {
+			logWarn("skipped sheet", "op", "XlsxReader.ReadText", "file", filePath, "sheet", sheetName, "error", err)
 			builder.WriteString(fmt.Sprintf("Failed to read sheet: %v\n", err))
 			continue
 		}
 
+		width := 0
+		if r.ShowFormulas {
+			width = sheetWidth(f, sheetName)
+		}
+
 		// 逐行输出
-		for rowIndex, row := range rows {
+		for rowIndex := 0; rows.Next(); rowIndex++ {
+			row, err := rows.Columns(excelize.Options{RawCellValue: r.RawValues})
+			if err != nil {
+				logWarn("stopped reading sheet", "op", "XlsxReader.ReadText", "file", filePath, "sheet", sheetName, "row", rowIndex+1, "error", err)
+				break
+			}
+
+			if r.ShowFormulas {
+				row = applyFormulas(f, sheetName, rowIndex+1, row, width)
+			}
+
 			// 跳过空行
 			if len(row) == 0 {
 				continue
@@ -45,26 +88,40 @@ func (r *XlsxReader) ReadText(filePath string) (string, error) {
 
 			for colIndex, cell := range row {
 				if colIndex > 0 {
-					builder.WriteString(" | ")
+					builder.WriteString(cellSeparator(r.CellSeparator))
 				}
 				builder.WriteString(cell)
 			}
 			builder.WriteString("\n")
+
+			if builder.err != nil {
+				break
+			}
 		}
+		rows.Close()
+
 		builder.WriteString("\n")
+		if builder.err != nil {
+			return builder.err
+		}
 	}
 
-	return builder.String(), nil
+	return nil
 }
 
 // GetMetadata 获取 XLSX 文件的元数据
 func (r *XlsxReader) GetMetadata(filePath string) (map[string]string, error) {
-	f, err := excelize.OpenFile(filePath)
+	f, err := r.openXlsx(filePath, newZipLimits(nil))
 	if err != nil {
-		return nil, WrapError("XlsxReader.GetMetadata", filePath, ErrFileOpen)
+		return nil, WrapError("XlsxReader.GetMetadata", filePath, err)
 	}
 	defer f.Close()
 
+	return xlsxMetadata(f), nil
+}
+
+// xlsxMetadata 从已打开的工作簿中提取 XLSX 元数据
+func xlsxMetadata(f *excelize.File) map[string]string {
 	metadata := make(map[string]string)
 
 	// 获取文档属性
@@ -80,10 +137,20 @@ func (r *XlsxReader) GetMetadata(filePath string) (map[string]string, error) {
 		metadata["keywords"] = props.Keywords
 	}
 
+	// 获取扩展属性（公司、应用程序等）
+	if appProps, err := f.GetAppProps(); err == nil {
+		appProperties{
+			Application: appProps.Application,
+			AppVersion:  appProps.AppVersion,
+			Company:     appProps.Company,
+		}.addTo(metadata)
+	}
+
 	// 获取工作表信息
 	sheets := f.GetSheetList()
 	metadata["sheets"] = strings.Join(sheets, ", ")
 	metadata["sheet_count"] = fmt.Sprintf("%d", len(sheets))
+	metadata["section_count"] = metadata["sheet_count"]
 
 	// 获取活动工作表
 	activeSheet := f.GetActiveSheetIndex()
@@ -91,52 +158,270 @@ func (r *XlsxReader) GetMetadata(filePath string) (map[string]string, error) {
 		metadata["active_sheet"] = sheets[activeSheet]
 	}
 
-	return metadata, nil
+	// 获取每个工作表的使用范围
+	for _, sheetName := range sheets {
+		if dimension, err := f.GetSheetDimension(sheetName); err == nil && dimension != "" {
+			metadata["sheet_"+sheetName+"_dimension"] = dimension
+		}
+	}
+
+	return metadata
 }
 
-// GetSheetData 获取指定工作表的结构化数据
-func (r *XlsxReader) GetSheetData(filePath, sheetName string) ([][]string, error) {
-	f, err := excelize.OpenFile(filePath)
+// SheetInfo 工作表的基本信息
+type SheetInfo struct {
+	// Name 工作表名称
+	Name string
+
+	// Dimension 工作表记录的使用范围，例如 "A1:F250"；文件中没有记录时为空
+	Dimension string
+
+	// Rows 使用范围包含的行数
+	Rows int
+
+	// Cols 使用范围包含的列数
+	Cols int
+
+	// Hidden 工作表是否被隐藏
+	Hidden bool
+}
+
+// GetSheetInfo 获取所有工作表的名称、使用范围和可见性，按工作簿中的顺序返回
+// 使用范围来自工作表的 dimension 记录，不需要读取单元格，适合在读取前校验上传的文件
+func (r *XlsxReader) GetSheetInfo(filePath string) ([]SheetInfo, error) {
+	f, err := r.openXlsx(filePath, newZipLimits(nil))
 	if err != nil {
-		return nil, WrapError("XlsxReader.GetSheetData", filePath, ErrFileOpen)
+		return nil, WrapError("XlsxReader.GetSheetInfo", filePath, err)
 	}
 	defer f.Close()
 
-	rows, err := f.GetRows(sheetName)
+	sheets := f.GetSheetList()
+	infos := make([]SheetInfo, 0, len(sheets))
+	for _, sheetName := range sheets {
+		info := SheetInfo{Name: sheetName}
+
+		if dimension, err := f.GetSheetDimension(sheetName); err == nil {
+			info.Dimension = dimension
+			info.Rows, info.Cols = dimensionSize(dimension)
+		}
+		if visible, err := f.GetSheetVisible(sheetName); err == nil {
+			info.Hidden = !visible
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// dimensionSize 计算使用范围（如 "B2:F250" 或 "A1"）包含的行数和列数，无法解析时返回 0
+func dimensionSize(dimension string) (rows, cols int) {
+	cells := strings.Split(strings.ReplaceAll(dimension, "$", ""), ":")
+	if len(cells) == 1 {
+		cells = append(cells, cells[0])
+	}
+	if len(cells) != 2 {
+		return 0, 0
+	}
+
+	startCol, startRow, err := excelize.CellNameToCoordinates(cells[0])
+	if err != nil {
+		return 0, 0
+	}
+	endCol, endRow, err := excelize.CellNameToCoordinates(cells[1])
+	if err != nil {
+		return 0, 0
+	}
+	return endRow - startRow + 1, endCol - startCol + 1
+}
+
+// GetSheetData 获取指定工作表的结构化数据，空工作表返回空切片（不是 nil），工作表不存在时返回 ErrSheetNotFound
+// 可选参数 maxRows 大于 0 时只读取前 maxRows 行，读取到上限后立即停止，适合预览很大的工作表
+func (r *XlsxReader) GetSheetData(filePath, sheetName string, maxRows ...int) ([][]string, error) {
+	f, err := r.openXlsx(filePath, newZipLimits(nil))
+	if err != nil {
+		return nil, WrapError("XlsxReader.GetSheetData", filePath, err)
+	}
+	defer f.Close()
+
+	limit := 0
+	if len(maxRows) > 0 {
+		limit = maxRows[0]
+	}
+
+	rows, _, stopErr, err := sheetRows(f, sheetName, limit, false, false)
 	if err != nil {
 		return nil, WrapError("XlsxReader.GetSheetData", filePath, ErrSheetNotFound)
 	}
+	if stopErr != nil {
+		logWarn("stopped reading sheet", "op", "XlsxReader.GetSheetData", "file", filePath, "sheet", sheetName, "error", stopErr)
+	}
 
 	return rows, nil
 }
 
 // GetAllSheetsData 获取所有工作表的数据
+// 返回的 map 不保留工作表顺序，需要按工作簿顺序处理时使用 GetAllSheetsDataOrdered
 func (r *XlsxReader) GetAllSheetsData(filePath string) (map[string][][]string, error) {
-	f, err := excelize.OpenFile(filePath)
+	sheets, err := r.allSheetsData(filePath, "XlsxReader.GetAllSheetsData")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][][]string, len(sheets))
+	for _, sheet := range sheets {
+		result[sheet.Name] = sheet.Rows
+	}
+	return result, nil
+}
+
+// CellLink 单元格上的超链接
+type CellLink struct {
+	// Cell 单元格名称，如 "B3"
+	Cell string
+
+	// URL 链接目标：外部链接为地址，工作簿内部链接为位置（如 "Sheet2!A1"）
+	URL string
+
+	// Display 单元格显示的文本
+	Display string
+}
+
+// GetHyperlinks 获取指定工作表中带超链接的单元格，按行、列顺序返回
+// 只检查有内容的单元格，没有值的单元格上的超链接不会被返回
+func (r *XlsxReader) GetHyperlinks(filePath, sheetName string) ([]CellLink, error) {
+	f, err := r.openXlsx(filePath, newZipLimits(nil))
 	if err != nil {
-		return nil, WrapError("XlsxReader.GetAllSheetsData", filePath, ErrFileOpen)
+		return nil, WrapError("XlsxReader.GetHyperlinks", filePath, err)
 	}
 	defer f.Close()
 
-	result := make(map[string][][]string)
-	sheets := f.GetSheetList()
+	rows, err := f.GetRows(sheetName)
+	if err != nil {
+		return nil, WrapError("XlsxReader.GetHyperlinks", filePath, ErrSheetNotFound)
+	}
+
+	links := make([]CellLink, 0)
+	for rowIndex, row := range rows {
+		for colIndex, value := range row {
+			if value == "" {
+				continue
+			}
+			cell, err := excelize.CoordinatesToCellName(colIndex+1, rowIndex+1)
+			if err != nil {
+				continue
+			}
+			if ok, target, err := f.GetCellHyperLink(sheetName, cell); err == nil && ok {
+				links = append(links, CellLink{Cell: cell, URL: target, Display: value})
+			}
+		}
+	}
+
+	return links, nil
+}
+
+// NumericCell 数值单元格
+type NumericCell struct {
+	// Cell 单元格名称，如 "B3"
+	Cell string
+
+	// Value 单元格存储的数值
+	Value float64
+}
+
+// GetNumericCells 获取指定工作表中所有数值类型的单元格，按行、列顺序返回
+// 只返回单元格类型为数值（包括结果为数值的公式）的单元格，文本、布尔值、错误和空单元格被跳过。
+// Value 为存储的原始数值，不应用数字格式：百分比为小数，日期为 Excel 序列号
+func (r *XlsxReader) GetNumericCells(filePath, sheetName string) ([]NumericCell, error) {
+	f, err := r.openXlsx(filePath, newZipLimits(nil))
+	if err != nil {
+		return nil, WrapError("XlsxReader.GetNumericCells", filePath, err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetName, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, WrapError("XlsxReader.GetNumericCells", filePath, ErrSheetNotFound)
+	}
+
+	cells := make([]NumericCell, 0)
+	for rowIndex, row := range rows {
+		for colIndex, raw := range row {
+			if raw == "" {
+				continue
+			}
+			cell, err := excelize.CoordinatesToCellName(colIndex+1, rowIndex+1)
+			if err != nil {
+				continue
+			}
+			// 数值单元格的类型属性可以省略，此时为 CellTypeUnset
+			cellType, err := f.GetCellType(sheetName, cell)
+			if err != nil || (cellType != excelize.CellTypeNumber && cellType != excelize.CellTypeUnset) {
+				continue
+			}
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				continue
+			}
+			cells = append(cells, NumericCell{Cell: cell, Value: value})
+		}
+	}
+
+	return cells, nil
+}
+
+// SheetData 单个工作表的名称和数据
+type SheetData struct {
+	// Name 工作表名称
+	Name string
 
+	// Rows 工作表的所有行
+	Rows [][]string
+}
+
+// GetAllSheetsDataOrdered 获取所有工作表的数据，按工作簿中的工作表顺序（GetSheetList）排列
+func (r *XlsxReader) GetAllSheetsDataOrdered(filePath string) ([]SheetData, error) {
+	return r.allSheetsData(filePath, "XlsxReader.GetAllSheetsDataOrdered")
+}
+
+// allSheetsData 按工作表顺序读取所有工作表的数据，无法读取的工作表会被跳过
+func (r *XlsxReader) allSheetsData(filePath, op string) ([]SheetData, error) {
+	f, err := r.openXlsx(filePath, newZipLimits(nil))
+	if err != nil {
+		return nil, WrapError(op, filePath, err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	result := make([]SheetData, 0, len(sheets))
 	for _, sheetName := range sheets {
 		rows, err := f.GetRows(sheetName)
 		if err != nil {
+			logWarn("skipped sheet", "op", op, "file", filePath, "sheet", sheetName, "error", err)
 			continue
 		}
-		result[sheetName] = rows
+		result = append(result, SheetData{Name: sheetName, Rows: rows})
 	}
 
 	return result, nil
 }
 
+// Capabilities 返回 XLSX 读取器支持的功能：按工作表分页，支持表格和列选择，元数据包含文档记录的创建和修改时间
+func (r *XlsxReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{
+		Pagination:    true,
+		Tables:        true,
+		Columns:       true,
+		Sheets:        true,
+		MetadataDates: true,
+	}
+}
+
 // ReadWithConfig 根据配置读取 XLSX 文件，返回结构化结果
 func (r *XlsxReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
-	f, err := excelize.OpenFile(filePath)
+	f, err := r.openXlsx(filePath, newZipLimits(config))
 	if err != nil {
-		return nil, WrapError("XlsxReader.ReadWithConfig", filePath, ErrFileOpen)
+		return nil, WrapError("XlsxReader.ReadWithConfig", filePath, err)
 	}
 	defer f.Close()
 
@@ -148,20 +433,41 @@ func (r *XlsxReader) ReadWithConfig(filePath string, config *ReadConfig) (*Docum
 		TotalPages: totalSheets,
 		Pages:      make([]PageContent, 0),
 		Metadata:   make(map[string]string),
+		layout:     layoutSheets,
 	}
 
-	// 获取元数据
-	metadata, _ := r.GetMetadata(filePath)
-	result.Metadata = metadata
+	// 获取元数据（复用已打开的工作簿）
+	result.Metadata = xlsxMetadata(f)
+	if metadataOnly(config) {
+		return metadataOnlyResult("XlsxReader.ReadWithConfig", filePath, totalSheets, result.Metadata, nil)
+	}
 
 	// 确定要读取的工作表
 	var sheetsToRead []int
 	sheetNamesSet := make(map[string]bool)
 
-	// 如果指定了工作表名称
+	// 如果指定了工作表名称，按匹配模式归一化
 	if config != nil && config.SheetNames != nil {
 		for _, name := range config.SheetNames {
-			sheetNamesSet[name] = true
+			sheetNamesSet[normalizeSheetName(name, config.SheetNameMatch)] = true
+		}
+	}
+
+	// 根据工作表名称确定索引
+	var namedSheets []int
+	if len(sheetNamesSet) > 0 {
+		matched := make(map[string]bool)
+		for i, sheetName := range sheets {
+			key := normalizeSheetName(sheetName, config.SheetNameMatch)
+			if sheetNamesSet[key] {
+				namedSheets = append(namedSheets, i)
+				matched[key] = true
+			}
+		}
+
+		// 严格模式下，任何未匹配的名称都视为错误，无论最终按哪种方式选择工作表
+		if config.StrictSheetNames && len(matched) < len(sheetNamesSet) {
+			return nil, WrapError("XlsxReader.ReadWithConfig", filePath, ErrSheetNotFound)
 		}
 	}
 
@@ -176,12 +482,7 @@ func (r *XlsxReader) ReadWithConfig(filePath string, config *ReadConfig) (*Docum
 	} else if config != nil && (len(config.PageSelector.Indexes) > 0 || len(config.PageSelector.Ranges) > 0) {
 		sheetsToRead = determinePagesToRead(config, totalSheets)
 	} else if len(sheetNamesSet) > 0 {
-		// 根据工作表名称确定索引
-		for i, sheetName := range sheets {
-			if sheetNamesSet[sheetName] {
-				sheetsToRead = append(sheetsToRead, i)
-			}
-		}
+		sheetsToRead = namedSheets
 	} else {
 		// 读取所有工作表
 		sheetsToRead = make([]int, 0, totalSheets)
@@ -193,19 +494,38 @@ func (r *XlsxReader) ReadWithConfig(filePath string, config *ReadConfig) (*Docum
 	// 构建页面行配置映射
 	pageLineMap := buildPageLineMap(config, totalSheets)
 
-	var contentBuilder strings.Builder
 	totalLines := 0
+	colFilter := columnFilter(config)
+
+	maxRows, rawValues := 0, false
+	if config != nil {
+		maxRows, rawValues = config.MaxRowsPerSheet, config.XlsxRawValues
+	}
+	truncated := false
 
-	for _, sheetIndex := range sheetsToRead {
+	for i, sheetIndex := range sheetsToRead {
 		if sheetIndex < 0 || sheetIndex >= totalSheets {
+			reportProgress(config, i+1, len(sheetsToRead))
 			continue
 		}
 
 		sheetName := sheets[sheetIndex]
-		rows, err := f.GetRows(sheetName)
+		rows, sheetTruncated, stopErr, err := sheetRows(f, sheetName, maxRows, rawValues, config != nil && config.ShowFormulas)
 		if err != nil {
+			logWarn("skipped sheet", "op", "XlsxReader.ReadWithConfig", "file", filePath, "sheet", sheetName, "error", err)
+			if err := result.partialFailure(config, "XlsxReader.ReadWithConfig", "sheet %q: failed to read: %v", sheetName, err); err != nil {
+				return nil, err
+			}
+			reportProgress(config, i+1, len(sheetsToRead))
 			continue
 		}
+		if stopErr != nil {
+			logWarn("stopped reading sheet", "op", "XlsxReader.ReadWithConfig", "file", filePath, "sheet", sheetName, "error", stopErr)
+			if err := result.partialFailure(config, "XlsxReader.ReadWithConfig", "sheet %q: stopped reading at %v", sheetName, stopErr); err != nil {
+				return nil, err
+			}
+		}
+		truncated = truncated || sheetTruncated
 
 		// 将每行转换为字符串
 		lines := make([]string, 0, len(rows))
@@ -214,46 +534,164 @@ func (r *XlsxReader) ReadWithConfig(filePath string, config *ReadConfig) (*Docum
 				continue
 			}
 
-			var lineBuilder strings.Builder
-			lineBuilder.WriteString(fmt.Sprintf("Row %d: ", rowIndex))
-			for colIndex, cell := range row {
-				if colIndex > 0 {
-					lineBuilder.WriteString(" | ")
-				}
-				lineBuilder.WriteString(cell)
-			}
-			lines = append(lines, lineBuilder.String())
+			// 按列选择器筛选单元格
+			cells, _ := filterLinesWithIndexes(row, colFilter)
+
+			lines = append(lines, formatRow(rowIndex, cells, config))
 		}
 
 		// 根据配置筛选行
-		var filteredLines []string
-		if lineConfig, ok := pageLineMap[sheetIndex]; ok {
-			filteredLines = filterLinesForPage(lines, lineConfig)
-		} else {
-			filteredLines = lines
+		lineConfig, ok := pageLineMap[sheetIndex]
+		if !ok {
+			lineConfig = pageLineFilter{readAll: true}
 		}
 
-		pageContent := PageContent{
-			PageNumber: sheetIndex,
-			PageName:   sheetName,
-			Lines:      filteredLines,
-			TotalLines: len(filteredLines),
-		}
+		pageContent := newPageContent(sheetIndex, lines, lineConfig, config)
+		pageContent.PageName = sheetName
 
 		result.Pages = append(result.Pages, pageContent)
-		totalLines += len(filteredLines)
+		totalLines += pageContent.TotalLines
 
-		// 构建完整内容
-		contentBuilder.WriteString(fmt.Sprintf("\n=== 工作表: %s ===\n\n", sheetName))
-		for _, line := range filteredLines {
-			contentBuilder.WriteString(line)
-			contentBuilder.WriteString("\n")
-		}
-		contentBuilder.WriteString("\n")
+		reportProgress(config, i+1, len(sheetsToRead))
+	}
+
+	if maxRows > 0 {
+		result.Metadata["truncated"] = fmt.Sprintf("%t", truncated)
 	}
 
 	result.TotalLines = totalLines
-	result.Content = contentBuilder.String()
+	result.finish(config)
 
 	return result, nil
 }
+
+// sheetRows 使用行迭代器读取工作表的行，结果与 excelize 的 GetRows 一致（中间的空行为空切片，末尾的空行被去除）
+// maxRows 大于 0 时只保留前 maxRows 行并停止迭代，truncated 表示之后是否还有非空行
+// raw 为 true 时返回单元格存储的原始值，不应用数字格式；formulas 为 true 时包含公式的单元格替换为公式（见 applyFormulas）
+// 某一行无法解析时停止迭代，已读取的行与 stopErr（包含行号）一起返回；工作表无法打开时返回 err
+func sheetRows(f *excelize.File, sheetName string, maxRows int, raw, formulas bool) (rows [][]string, truncated bool, stopErr error, err error) {
+	iter, err := f.Rows(sheetName)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	defer iter.Close()
+
+	width := 0
+	if formulas {
+		width = sheetWidth(f, sheetName)
+	}
+
+	results, current, lastNonEmpty := make([][]string, 0, 64), 0, 0
+	for iter.Next() {
+		current++
+		row, err := iter.Columns(excelize.Options{RawCellValue: raw})
+		if err != nil {
+			stopErr = fmt.Errorf("row %d: %w", current, err)
+			break
+		}
+		if formulas {
+			row = applyFormulas(f, sheetName, current, row, width)
+		}
+		if len(row) == 0 {
+			continue
+		}
+		if maxRows > 0 && current > maxRows {
+			truncated = true
+			break
+		}
+		if emptyRows := current - lastNonEmpty - 1; emptyRows > 0 {
+			results = append(results, make([][]string, emptyRows)...)
+		}
+		results = append(results, row)
+		lastNonEmpty = current
+	}
+
+	return results[:lastNonEmpty], truncated, stopErr, nil
+}
+
+// applyFormulas 将一行中包含公式的单元格替换为以 "=" 开头的公式，rowNumber 为工作表中的行号（从1开始）
+// Columns 会去掉末尾的空单元格，没有缓存结果的公式单元格也是空的，因此检查到 width 列（见 sheetWidth）为止，
+// 并去掉替换后末尾的空单元格；返回新的切片，不修改 row
+func applyFormulas(f *excelize.File, sheetName string, rowNumber int, row []string, width int) []string {
+	cells := make([]string, max(len(row), width))
+	copy(cells, row)
+	for colIndex := range cells {
+		cell, err := excelize.CoordinatesToCellName(colIndex+1, rowNumber)
+		if err != nil {
+			continue
+		}
+		if formula, err := f.GetCellFormula(sheetName, cell); err == nil && formula != "" {
+			cells[colIndex] = "=" + strings.TrimPrefix(formula, "=")
+		}
+	}
+
+	end := len(cells)
+	for end > 0 && cells[end-1] == "" {
+		end--
+	}
+	return cells[:end]
+}
+
+// sheetWidth 返回工作表 <dimension> 中声明的列数，没有声明或无法解析时返回 0
+func sheetWidth(f *excelize.File, sheetName string) int {
+	dimension, err := f.GetSheetDimension(sheetName)
+	if err != nil || dimension == "" {
+		return 0
+	}
+	if _, last, ok := strings.Cut(dimension, ":"); ok {
+		dimension = last
+	}
+	col, _, err := excelize.CellNameToCoordinates(dimension)
+	if err != nil {
+		return 0
+	}
+	return col
+}
+
+// openXlsx 检查资源限制后打开工作簿，失败时返回未包装的 ErrFileOpen 或 ErrFileTooLarge
+// 文件只读取一次，资源检查和 excelize 使用同一份数据，避免两次打开之间文件被修改
+func (s *fileSource) openXlsx(filePath string, limits zipLimits) (*excelize.File, error) {
+	data, err := s.readFile(filePath)
+	if err != nil {
+		return nil, ErrFileOpen
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, ErrFileOpen
+	}
+	if err := checkZipLimits(zipReader, limits, isWorksheetPart); err != nil {
+		return nil, err
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrFileOpen
+	}
+	return f, nil
+}
+
+// isWorksheetPart 判断 zip 条目是否为工作表部件
+func isWorksheetPart(name string) bool {
+	return strings.HasPrefix(name, "xl/worksheets/sheet") && strings.HasSuffix(name, ".xml")
+}
+
+// normalizeSheetName 根据匹配模式归一化工作表名称
+// Trimmed 模式移除所有空白（包括名称内部的空白），使 "Sheet1" 与 " Sheet 1 " 匹配
+func normalizeSheetName(name string, mode SheetNameMatchMode) string {
+	switch mode {
+	case SheetNameCaseInsensitive:
+		return strings.ToLower(name)
+	case SheetNameTrimmed:
+		return removeSpaces(name)
+	case SheetNameTrimmedCaseInsensitive:
+		return strings.ToLower(removeSpaces(name))
+	default:
+		return name
+	}
+}
+
+// removeSpaces 移除字符串中的所有空白字符
+func removeSpaces(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
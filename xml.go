@@ -0,0 +1,202 @@
+package docreader
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// XmlReader 用于读取通用 .xml 文件
+// 每个包含文本的叶子元素输出为一行 "路径/到/元素: 文本"
+type XmlReader struct {
+	fileSource
+}
+
+// xmlElement 解析过程中栈上的元素状态
+type xmlElement struct {
+	name     string
+	hasChild bool
+	text     strings.Builder
+}
+
+// xmlSummary XML 文档的统计信息
+type xmlSummary struct {
+	root     string // 根元素名称
+	elements int    // 元素总数
+}
+
+// scanXML 以流的方式解析 XML，对每个包含非空文本的叶子元素调用 emit
+// 路径使用元素的本地名称（忽略命名空间前缀），以 "/" 连接；emit 返回的错误会原样返回
+func scanXML(source io.Reader, emit func(path, text string) error) (xmlSummary, error) {
+	var summary xmlSummary
+	var stack []*xmlElement
+	var path []string
+
+	decoder := xml.NewDecoder(source)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return summary, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 {
+				if summary.root != "" {
+					return summary, errors.New("multiple root elements")
+				}
+				summary.root = t.Name.Local
+			} else {
+				stack[len(stack)-1].hasChild = true
+			}
+			summary.elements++
+			stack = append(stack, &xmlElement{name: t.Name.Local})
+			path = append(path, t.Name.Local)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		case xml.EndElement:
+			element := stack[len(stack)-1]
+			if !element.hasChild {
+				if text := strings.TrimSpace(element.text.String()); text != "" {
+					if err := emit(strings.Join(path, "/"), text); err != nil {
+						return summary, err
+					}
+				}
+			}
+			stack = stack[:len(stack)-1]
+			path = path[:len(path)-1]
+		}
+	}
+
+	if summary.root == "" {
+		return summary, errors.New("missing root element")
+	}
+	return summary, nil
+}
+
+// ReadText 读取 XML 文件的文本内容
+func (r *XmlReader) ReadText(filePath string) (string, error) {
+	var builder strings.Builder
+	if err := r.writeText(&builder, filePath); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// writeText 逐个将叶子元素的文本写入 w
+func (r *XmlReader) writeText(w io.Writer, filePath string) error {
+	tw := newTextWriter(w)
+	return r.forEachLine(filePath, "XmlReader.ReadText", func(line string) error {
+		tw.WriteString(line)
+		tw.WriteString("\n")
+		return tw.err
+	})
+}
+
+// forEachLine 按文档顺序生成叶子元素的文本行，emit 返回错误时停止并返回该错误
+func (r *XmlReader) forEachLine(filePath, op string, emit func(line string) error) error {
+	file, err := r.open(filePath)
+	if err != nil {
+		return WrapError(op, filePath, ErrFileOpen)
+	}
+	defer file.Close()
+
+	var emitErr error
+	_, err = scanXML(file, func(path, text string) error {
+		emitErr = emit(path + ": " + text)
+		return emitErr
+	})
+	if emitErr != nil {
+		return emitErr
+	}
+	if err != nil {
+		return WrapError(op, filePath, ErrInvalidFormat)
+	}
+	return nil
+}
+
+// GetMetadata 获取 XML 文件的元数据，包括根元素名称（root）和元素总数（elements）
+func (r *XmlReader) GetMetadata(filePath string) (map[string]string, error) {
+	metadata := make(map[string]string)
+
+	file, err := r.open(filePath)
+	if err != nil {
+		return nil, WrapError("XmlReader.GetMetadata", filePath, ErrFileOpen)
+	}
+	defer file.Close()
+
+	summary, err := scanXML(file, func(string, string) error { return nil })
+	if err != nil {
+		return nil, WrapError("XmlReader.GetMetadata", filePath, ErrInvalidFormat)
+	}
+
+	metadata["root"] = summary.root
+	metadata["elements"] = fmt.Sprintf("%d", summary.elements)
+
+	// 获取文件信息
+	fileInfo, err := r.stat(filePath)
+	if err == nil {
+		metadata["size"] = fmt.Sprintf("%d", fileInfo.Size())
+		metadata["modified"] = fileInfo.ModTime().String()
+	}
+
+	// 单页格式只有一个部分
+	metadata["section_count"] = "1"
+
+	return metadata, nil
+}
+
+// Capabilities 返回 XML 读取器支持的功能：单页纯文本，没有额外的结构
+func (r *XmlReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{}
+}
+
+// ReadWithConfig 根据配置读取 XML 文件，返回结构化结果
+// 整个文件作为单页处理，每个叶子元素为一行
+func (r *XmlReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
+	if metadataOnly(config) {
+		metadata, err := r.GetMetadata(filePath)
+		return metadataOnlyResult("XmlReader.ReadWithConfig", filePath, 1, metadata, err)
+	}
+
+	lines := make([]string, 0)
+	err := r.forEachLine(filePath, "XmlReader.ReadWithConfig", func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DocumentResult{
+		FilePath:   filePath,
+		TotalPages: 1,
+		Pages:      make([]PageContent, 0),
+		Metadata:   make(map[string]string),
+	}
+
+	// 获取元数据
+	metadata, _ := r.GetMetadata(filePath)
+	if metadata != nil {
+		result.Metadata = metadata
+	}
+
+	// 根据配置筛选行
+	pageContent := newPageContent(0, lines, singlePageFilter(config), config)
+
+	result.Pages = append(result.Pages, pageContent)
+	result.TotalLines = pageContent.TotalLines
+	result.finish(config)
+
+	reportProgress(config, 1, 1)
+
+	return result, nil
+}
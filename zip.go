@@ -0,0 +1,215 @@
+package docreader
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// ZipReader 用于读取包含多个文档的 zip 压缩包（.zip），将压缩包作为一个多部分的文档
+// 压缩包中扩展名受支持的每个文件是一页，PageName 为文件在压缩包中的路径，页码为文件在压缩包中的顺序（从 0 开始）。
+// 目录、嵌套的 .zip、macOS 生成的 __MACOSX/ 和 "._" 资源文件以及不支持的格式会被忽略。
+// 每个文件解压到内存后交给对应格式的读取器，不写入临时文件；MaxDecompressedSize 限制所有文件解压后的总大小
+type ZipReader struct {
+	fileSource
+}
+
+// ReadText 读取压缩包中所有受支持文件的文本内容
+func (r *ZipReader) ReadText(filePath string) (string, error) {
+	var builder strings.Builder
+	if err := r.writeText(&builder, filePath); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// writeText 将压缩包中所有受支持文件的文本写入 w，每个文件之前附加文件路径
+func (r *ZipReader) writeText(w io.Writer, filePath string) error {
+	result, err := r.read(filePath, nil, "ZipReader.ReadText")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, result.Content)
+	return err
+}
+
+// GetMetadata 获取压缩包的元数据：受支持的文件数量和路径，不读取文件内容
+func (r *ZipReader) GetMetadata(filePath string) (map[string]string, error) {
+	zipReader, err := r.openZip(filePath)
+	if err != nil {
+		return nil, WrapError("ZipReader.GetMetadata", filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	return r.zipMetadata(filePath, zipDocumentEntries(zipReader.Reader)), nil
+}
+
+// Capabilities 返回 zip 读取器支持的功能：每个文件是一页
+func (r *ZipReader) Capabilities() ReaderCapabilities {
+	return ReaderCapabilities{Pagination: true}
+}
+
+// ReadWithConfig 根据配置读取压缩包，返回结构化结果
+// PageSelector 和 PageConfigs 按文件在压缩包中的顺序选择文件，LineSelector 作用于每个文件合并后的行；
+// 格式相关的选项（如 CellSeparator、TableMode）传递给各个文件的读取器。
+// 单个文件读取失败时记录在 Warnings 中，配置了 FailOnPartialError 时返回 ErrFileParse
+func (r *ZipReader) ReadWithConfig(filePath string, config *ReadConfig) (*DocumentResult, error) {
+	return r.read(filePath, config, "ZipReader.ReadWithConfig")
+}
+
+// read 读取压缩包中选中的文件，op 为错误中使用的操作名
+func (r *ZipReader) read(filePath string, config *ReadConfig, op string) (*DocumentResult, error) {
+	zipReader, err := r.openZip(filePath)
+	if err != nil {
+		return nil, WrapError(op, filePath, ErrFileOpen)
+	}
+	defer zipReader.Close()
+
+	limits := newZipLimits(config)
+	budget := newZipBudget(limits)
+	entries := zipDocumentEntries(zipReader.Reader)
+	if limits.tooManyParts(len(entries)) {
+		return nil, WrapError(op, filePath, ErrFileTooLarge)
+	}
+
+	// 只需要元数据时不解压文件
+	if metadataOnly(config) {
+		return metadataOnlyResult(op, filePath, len(entries), r.zipMetadata(filePath, entries), nil)
+	}
+
+	result := &DocumentResult{
+		FilePath:   filePath,
+		TotalPages: len(entries),
+		Pages:      make([]PageContent, 0),
+		Metadata:   r.zipMetadata(filePath, entries),
+		layout:     layoutFiles,
+	}
+
+	pageLineMap := buildPageLineMap(config, len(entries))
+	innerConfig := zipEntryConfig(config)
+	totalLines := 0
+	processed := 0
+
+	for index, entry := range entries {
+		lineConfig, shouldRead := pageLineMap[index]
+		if !shouldRead {
+			continue
+		}
+		processed++
+
+		// 超出总大小预算时整个压缩包视为过大，不再读取其余文件
+		data, err := budget.read(entry)
+		if errors.Is(err, ErrFileTooLarge) {
+			return nil, WrapError(op, filePath, ErrFileTooLarge)
+		}
+		var lines, warnings []string
+		if err == nil {
+			lines, warnings, err = readZipEntryLines(entry.Name, data, innerConfig)
+		}
+		for _, warning := range warnings {
+			result.Warnings = append(result.Warnings, entry.Name+": "+warning)
+		}
+		if err != nil {
+			if err := result.partialFailure(config, op, "%s: %v", entry.Name, err); err != nil {
+				return nil, err
+			}
+			lines = nil
+		}
+
+		pageContent := newPageContent(index, lines, lineConfig, config)
+		pageContent.PageName = entry.Name
+
+		result.Pages = append(result.Pages, pageContent)
+		totalLines += pageContent.TotalLines
+
+		reportProgress(config, processed, len(pageLineMap))
+	}
+
+	result.TotalLines = totalLines
+	result.finish(config)
+
+	return result, nil
+}
+
+// zipDocumentEntries 返回压缩包中可以读取的文件，保持压缩包中的顺序
+func zipDocumentEntries(zipReader *zip.Reader) []*zip.File {
+	entries := make([]*zip.File, 0)
+	for _, file := range zipReader.File {
+		if isZipDocumentEntry(file) {
+			entries = append(entries, file)
+		}
+	}
+	return entries
+}
+
+// isZipDocumentEntry 判断 zip 条目是否为可以读取的文档：不是目录、macOS 资源文件或嵌套的压缩包，且扩展名受支持
+func isZipDocumentEntry(file *zip.File) bool {
+	if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") || strings.HasPrefix(path.Base(file.Name), "._") {
+		return false
+	}
+	ext := strings.ToLower(path.Ext(file.Name))
+	return ext != ".zip" && newFormatReader(ext) != nil
+}
+
+// zipMetadata 生成压缩包的元数据，files 为受支持文件的路径（以逗号分隔）
+func (r *ZipReader) zipMetadata(filePath string, entries []*zip.File) map[string]string {
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name
+	}
+
+	metadata := map[string]string{
+		"files":         strings.Join(names, ", "),
+		"file_count":    fmt.Sprintf("%d", len(entries)),
+		"section_count": fmt.Sprintf("%d", len(entries)),
+	}
+	if fileInfo, err := r.stat(filePath); err == nil {
+		metadata["size"] = fmt.Sprintf("%d", fileInfo.Size())
+		metadata["modified"] = fileInfo.ModTime().String()
+	}
+	return metadata
+}
+
+// zipEntryConfig 生成读取压缩包中单个文件时使用的配置
+// 页、行选择和行清理由压缩包这一层处理，这里只保留格式相关的选项和 FailOnPartialError，并跳过生成 Content
+func zipEntryConfig(config *ReadConfig) *ReadConfig {
+	inner := &ReadConfig{}
+	if config != nil {
+		*inner = *config
+	}
+	inner.PageSelector = Selector{}
+	inner.PageLabels = nil
+	inner.LineSelector = Selector{}
+	inner.PageConfigs = nil
+	inner.ProgressFunc = nil
+	inner.PreserveLineNumbers = false
+	inner.TrimLines = false
+	inner.DropEmptyLines = false
+	inner.DropConsecutiveDuplicateLines = false
+	inner.SkipEmptyPages = false
+	inner.SkipContentString = true
+	return inner
+}
+
+// readZipEntryLines 用 name 扩展名对应的读取器读取内存中的 zip 条目，返回所有页的行和读取器的警告
+// 错误中不包含文件路径，由调用方加上条目名称
+func readZipEntryLines(name string, data []byte, config *ReadConfig) ([]string, []string, error) {
+	reader, fileName := newMemoryReader(name, data)
+	result, err := reader.ReadWithConfig(fileName, config)
+	if err != nil {
+		var docErr *DocumentError
+		if errors.As(err, &docErr) {
+			return nil, nil, docErr.Err
+		}
+		return nil, nil, err
+	}
+
+	lines := make([]string, 0, result.TotalLines)
+	for _, page := range result.Pages {
+		lines = append(lines, page.Lines...)
+	}
+	return lines, result.Warnings, nil
+}